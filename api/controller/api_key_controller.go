@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"encoding/json"
+	"main/domain"
+	"main/internal"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ApiKeyController struct {
+	ApiKeyUseCase domain.ApiKeyUseCase
+}
+
+func (ac *ApiKeyController) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	apiKeys, err := ac.ApiKeyUseCase.GetByUserID(r.Context(), id)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.ApiKeyArray{
+		Count: len(apiKeys),
+		Items: apiKeys,
+	})
+}
+
+func (ac *ApiKeyController) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	var request domain.CreateApiKeyRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	id := domain.UserIDFromContext(r.Context())
+	created, err := ac.ApiKeyUseCase.Create(r.Context(), id, &request)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (ac *ApiKeyController) DeleteApiKey(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	err := ac.ApiKeyUseCase.Delete(r.Context(), id, chi.URLParam(r, "id"))
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "API key deleted",
+	})
+}
@@ -1,9 +1,15 @@
 package controller
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"main/domain"
+	"main/internal"
 	"net/http"
+	"net/url"
 	"slices"
 	"strconv"
 	"strings"
@@ -15,6 +21,7 @@ type CollectionController struct {
 	CollectionUseCase domain.CollectionUseCase
 	UserUseCase       domain.UserUseCase
 	HistoryUseCase    domain.HistoryUseCase
+	UploadUseCase     domain.UploadUseCase
 }
 
 func (cc *CollectionController) Create(w http.ResponseWriter, r *http.Request) {
@@ -22,27 +29,27 @@ func (cc *CollectionController) Create(w http.ResponseWriter, r *http.Request) {
 
 	err := json.NewDecoder(r.Body).Decode(&collection)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 
 	if collection.Name == "" {
-		http.Error(w, jsonError("Invalid name"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("name"))
 		return
 	}
 
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 	collection.Author = userID
 
 	id, err := cc.CollectionUseCase.Create(r.Context(), &collection, userID)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
 	collection, err = cc.CollectionUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -60,41 +67,41 @@ func (cc *CollectionController) Create(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 	err = json.NewEncoder(w).Encode(collectionInfo)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
 func (cc *CollectionController) Update(w http.ResponseWriter, r *http.Request) {
 	var collection domain.Collection
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	err := json.NewDecoder(r.Body).Decode(&collection)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 
 	if collection.Name == "" {
-		http.Error(w, jsonError("Invalid name"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("name"))
 		return
 	}
 
 	id := chi.URLParam(r, "id")
 	coll, err := cc.CollectionUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, jsonError("There is no collection with this ID"), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrCollectionNotFound)
 		return
 	}
 
 	if coll.Author != userID {
-		http.Error(w, jsonError("You are not the owner of this collection"), http.StatusForbidden)
+		internal.WriteError(w, r, domain.ErrNotCollectionOwner)
 		return
 	}
 
 	err = cc.CollectionUseCase.PutByID(r.Context(), id, &collection)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -104,40 +111,41 @@ func (cc *CollectionController) Update(w http.ResponseWriter, r *http.Request) {
 		Message: "Collection updated",
 	})
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
 func (cc *CollectionController) AddLike(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	user, err := cc.UserUseCase.GetByID(r.Context(), userID)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrUserNotFound)
+		return
 	}
 	for _, favID := range user.Favourite {
 		if favID == id {
-			http.Error(w, jsonError("Collection already in favourites"), http.StatusBadRequest)
+			internal.WriteError(w, r, domain.ErrAlreadyInFavourites)
 			return
 		}
 	}
 
 	coll, err := cc.CollectionUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, jsonError("There is no collection with this ID"), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrCollectionNotFound)
 		return
 	}
 
 	if !coll.IsPublic && userID != coll.Author {
-		http.Error(w, jsonError("Collection is not public"), http.StatusForbidden)
+		internal.WriteError(w, r, domain.ErrCollectionNotPublic)
 		return
 	}
 
 	collection, err := cc.CollectionUseCase.AddLike(r.Context(), id, userID)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -146,18 +154,19 @@ func (cc *CollectionController) AddLike(w http.ResponseWriter, r *http.Request)
 	response := map[string]int{"likes": collection.Likes}
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
 func (cc *CollectionController) RemoveLike(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	user, err := cc.UserUseCase.GetByID(r.Context(), userID)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrUserNotFound)
+		return
 	}
 	found := false
 	for _, favID := range user.Favourite {
@@ -168,24 +177,24 @@ func (cc *CollectionController) RemoveLike(w http.ResponseWriter, r *http.Reques
 	}
 
 	if !found {
-		http.Error(w, jsonError("Collection not in favourites"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrNotInFavourites)
 		return
 	}
 
 	coll, err := cc.CollectionUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, jsonError("There is no collection with this ID"), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrCollectionNotFound)
 		return
 	}
 
 	if !coll.IsPublic && userID != coll.Author {
-		http.Error(w, jsonError("Collection is not public"), http.StatusForbidden)
+		internal.WriteError(w, r, domain.ErrCollectionNotPublic)
 		return
 	}
 
 	collection, err := cc.CollectionUseCase.RemoveLike(r.Context(), id, userID)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -194,24 +203,70 @@ func (cc *CollectionController) RemoveLike(w http.ResponseWriter, r *http.Reques
 	response := map[string]int{"likes": collection.Likes}
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
+// Get serves the collection to the legacy tree, each card has the one picture it had before Attachments.
 func (cc *CollectionController) Get(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("x-user-id").(string)
+	collectionInfo, err := cc.getCollection(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	legacyInfo := domain.LegacyCollectionInfo{
+		ID:        collectionInfo.ID,
+		Name:      collectionInfo.Name,
+		IsPublic:  collectionInfo.IsPublic,
+		Cards:     make([]domain.LegacyCard, 0, len(collectionInfo.Cards)),
+		Author:    collectionInfo.Author,
+		Likes:     collectionInfo.Likes,
+		Trainings: collectionInfo.Trainings,
+	}
+	for _, card := range collectionInfo.Cards {
+		legacyInfo.Cards = append(legacyInfo.Cards, card.Legacy())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(legacyInfo)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+}
+
+// GetV1 serves the collection with the attachments of the cards.
+func (cc *CollectionController) GetV1(w http.ResponseWriter, r *http.Request) {
+	collectionInfo, err := cc.getCollection(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(collectionInfo)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+}
+
+// getCollection returns the collection of the id path parameter if the user can see it.
+func (cc *CollectionController) getCollection(r *http.Request) (domain.CollectionInfo, error) {
+	userID := domain.UserIDFromContext(r.Context())
 
 	id := chi.URLParam(r, "id")
 	collection, err := cc.CollectionUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, jsonError("There is no collection with this ID"), http.StatusNotFound)
-		return
+		return domain.CollectionInfo{}, domain.ErrCollectionNotFound
 	}
 
 	if userID != collection.Author && !collection.IsPublic {
-		http.Error(w, jsonError("You are not the owner of this collection"), http.StatusForbidden)
-		return
+		return domain.CollectionInfo{}, domain.ErrNotCollectionOwner
 	}
 
 	for ind, elem := range collection.Cards {
@@ -220,7 +275,7 @@ func (cc *CollectionController) Get(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	collectionInfo := domain.CollectionInfo{
+	return domain.CollectionInfo{
 		ID:        collection.ID,
 		Name:      collection.Name,
 		IsPublic:  collection.IsPublic,
@@ -228,36 +283,28 @@ func (cc *CollectionController) Get(w http.ResponseWriter, r *http.Request) {
 		Author:    collection.Author,
 		Likes:     collection.Likes,
 		Trainings: collection.Trainings,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(collectionInfo)
-	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
-		return
-	}
+	}, nil
 }
 
 func (cc *CollectionController) Delete(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	id := chi.URLParam(r, "id")
 
 	coll, err := cc.CollectionUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, jsonError("There is no collection with this ID"), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrCollectionNotFound)
 		return
 	}
 
 	if coll.Author != userID {
-		http.Error(w, jsonError("You are not the owner of this collection"), http.StatusForbidden)
+		internal.WriteError(w, r, domain.ErrNotCollectionOwner)
 		return
 	}
 
 	err = cc.CollectionUseCase.DeleteByID(r.Context(), coll.ID, coll.Author)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -267,7 +314,7 @@ func (cc *CollectionController) Delete(w http.ResponseWriter, r *http.Request) {
 		Message: "Collection deleted successfully",
 	})
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
@@ -277,24 +324,24 @@ func (cc *CollectionController) Search(w http.ResponseWriter, r *http.Request) {
 	var result domain.CollectionPreviewArray
 	var err error
 
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	queryParams := r.URL.Query()
 	name := queryParams.Get("name")
 	count, err := strconv.Atoi(queryParams.Get("count"))
 	if err != nil || count < 1 || count > 100 {
-		http.Error(w, jsonError("Invalid count"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("count"))
 		return
 	}
 	offset, err := strconv.Atoi(queryParams.Get("offset"))
 	if err != nil || offset < 0 {
-		http.Error(w, jsonError("Invalid offset"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("offset"))
 		return
 	}
 	sortBy := queryParams.Get("sort_by")
 
 	if sortBy != "likes" && sortBy != "trainings" && sortBy != "" {
-		http.Error(w, jsonError("invalid sort method"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("sort_by"))
 		return
 	}
 	if sortBy == "" {
@@ -303,19 +350,19 @@ func (cc *CollectionController) Search(w http.ResponseWriter, r *http.Request) {
 
 	category := queryParams.Get("category")
 	if category != "" && category != "favourite" {
-		http.Error(w, jsonError("invalid category"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("category"))
 		return
 	}
 
 	collections, err = cc.CollectionUseCase.SearchPublic(r.Context(), name, count, offset, sortBy, category, userID)
 
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
 	if len(collections) == 0 {
-		http.Error(w, "Сouldn't find anything", http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrNothingFound)
 		return
 	}
 
@@ -337,41 +384,41 @@ func (cc *CollectionController) Search(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(result)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
 func (cc *CollectionController) CreateCard(w http.ResponseWriter, r *http.Request) {
 	var card domain.Card
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	err := json.NewDecoder(r.Body).Decode(&card)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 	if card.Question == "" || card.Answer == "" || card.OtherAnswers.Items == nil ||
 		card.OtherAnswers.Count != len(card.OtherAnswers.Items) {
-		http.Error(w, jsonError("Invalid body data"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("question", "answer", "other_answers"))
 		return
 	}
 	collectionID := chi.URLParam(r, "id")
 
 	coll, err := cc.CollectionUseCase.GetByID(r.Context(), collectionID)
 	if err != nil {
-		http.Error(w, jsonError("There is no collection with this ID"), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrCollectionNotFound)
 		return
 	}
 
 	if coll.Author != userID {
-		http.Error(w, jsonError("You are not the owner of this collection"), http.StatusForbidden)
+		internal.WriteError(w, r, domain.ErrNotCollectionOwner)
 		return
 	}
 
 	card, err = cc.CollectionUseCase.AddCard(r.Context(), collectionID, &card)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -379,48 +426,48 @@ func (cc *CollectionController) CreateCard(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusCreated)
 	err = json.NewEncoder(w).Encode(card)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
 func (cc *CollectionController) UpdateCard(w http.ResponseWriter, r *http.Request) {
 	var card domain.Card
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	err := json.NewDecoder(r.Body).Decode(&card)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 
 	if card.Question == "" || card.Answer == "" || card.OtherAnswers.Items == nil ||
 		card.OtherAnswers.Count != len(card.OtherAnswers.Items) {
-		http.Error(w, jsonError("Invalid body data"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("question", "answer", "other_answers"))
 		return
 	}
 
 	id := chi.URLParam(r, "id")
 	card.LocalID, err = strconv.Atoi(chi.URLParam(r, "cardID"))
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("cardID"))
 		return
 	}
 
 	coll, err := cc.CollectionUseCase.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, jsonError("There is no collection with this ID"), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrCollectionNotFound)
 		return
 	}
 
 	if coll.Author != userID {
-		http.Error(w, jsonError("You are not the owner of this collection"), http.StatusForbidden)
+		internal.WriteError(w, r, domain.ErrNotCollectionOwner)
 		return
 	}
 
 	err = cc.CollectionUseCase.UpdateCard(r.Context(), id, &card)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -430,48 +477,36 @@ func (cc *CollectionController) UpdateCard(w http.ResponseWriter, r *http.Reques
 		Message: "Card updated",
 	})
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
 func (cc *CollectionController) DeleteCard(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	collectionID := chi.URLParam(r, "id")
 	cardID, err := strconv.Atoi(chi.URLParam(r, "cardID"))
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
 	coll, err := cc.CollectionUseCase.GetByID(r.Context(), collectionID)
 	if err != nil {
-		http.Error(w, jsonError("There is no collection with this ID"), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrCollectionNotFound)
 		return
 	}
 
 	if coll.Author != userID {
-		http.Error(w, jsonError("You are not the owner of this collection"), http.StatusForbidden)
+		internal.WriteError(w, r, domain.ErrNotCollectionOwner)
 		return
 	}
 
-	for _, elem := range coll.Cards {
-		if elem.LocalID == cardID {
-			if elem.Attachment != "" {
-				err = cc.CollectionUseCase.RemoveCardPicture(r.Context(), userID, collectionID, cardID, elem.Attachment)
-				if err != nil {
-					http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
-					return
-				}
-			}
-			break
-		}
-	}
-
-	err = cc.CollectionUseCase.DeleteCard(r.Context(), collectionID, cardID)
+	// the attachments and the audio of the card are removed with it
+	err = cc.CollectionUseCase.DeleteCard(r.Context(), userID, collectionID, cardID)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+		internal.WriteError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -480,49 +515,45 @@ func (cc *CollectionController) DeleteCard(w http.ResponseWriter, r *http.Reques
 		Message: "Card deleted successfully",
 	})
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
 func (cc *CollectionController) AddTraining(w http.ResponseWriter, r *http.Request) {
 	var historyItem domain.HistoryItem
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	err := json.NewDecoder(r.Body).Decode(&historyItem)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 
 	if historyItem.AllCardsCount == 0 || historyItem.CollectionName == "" || historyItem.CollectionID == "" ||
 		historyItem.AllCardsCount < len(historyItem.CorrectCards) {
-		http.Error(
-			w,
-			jsonError("Invalid body data. Check all_cards_count/collection_name/collection_id"),
-			http.StatusBadRequest,
-		)
+		internal.WriteError(w, r, domain.InvalidFields("all_cards_count", "collection_name", "collection_id"))
 		return
 	}
 
 	if historyItem.IncorrectCards == nil || historyItem.CorrectCards == nil || historyItem.Errors == nil ||
 		historyItem.RightAnswers == nil {
-		http.Error(
+		internal.WriteError(
 			w,
-			jsonError("Invalid body data. Check errors/right_answers/(in)correct_cards"),
-			http.StatusBadRequest,
+			r,
+			domain.RequiredFields("errors", "right_answers", "correct_cards", "incorrect_cards"),
 		)
 		return
 	}
 
 	if historyItem.Time < 0 {
-		http.Error(w, jsonError("Invalid body data. Check time"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("time"))
 		return
 	}
 
 	err = cc.HistoryUseCase.AddTraining(r.Context(), userID, historyItem)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -532,185 +563,673 @@ func (cc *CollectionController) AddTraining(w http.ResponseWriter, r *http.Reque
 		Message: "History item successfully added",
 	})
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
+// GetCardPicture serves the attachment of the object_name query parameter,
+// it is kept for the clients released before the attachments list.
 func (cc *CollectionController) GetCardPicture(w http.ResponseWriter, r *http.Request) {
-	authID := r.Context().Value("x-user-id").(string)
+	objectName := r.URL.Query().Get("object_name")
+	if objectName == "" {
+		internal.WriteError(w, r, domain.InvalidFields("object_name"))
+		return
+	}
+	cc.serveAttachment(w, r, objectName)
+}
 
-	queryParams := r.URL.Query()
-	objectName := queryParams.Get("object_name")
+func (cc *CollectionController) GetCardAttachment(w http.ResponseWriter, r *http.Request) {
+	cc.serveAttachment(w, r, chi.URLParam(r, "attachmentID"))
+}
 
-	if objectName == "" || strings.Count(objectName, "_") != 2 {
-		http.Error(w, jsonError("Invalid object_name"), http.StatusBadRequest)
+func (cc *CollectionController) serveAttachment(w http.ResponseWriter, r *http.Request, attachmentID string) {
+	size, err := domain.ParsePictureSize(r.URL.Query().Get("size"))
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	spl := strings.Split(objectName, "_")
-	collectionID := spl[0]
+	attachment, err := cc.checkAttachment(r, attachmentID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
 
-	if collectionID != chi.URLParam(r, "id") {
-		http.Error(w, jsonError("invalid collection / object_name"), http.StatusBadRequest)
+	picture, err := cc.CollectionUseCase.GetCardPhoto(r.Context(), attachment.ObjectName(), size)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrCardPictureNotFound)
 		return
 	}
 
-	coll, err := cc.CollectionUseCase.GetByID(r.Context(), collectionID)
+	internal.ServeObject(w, r, picture)
+}
+
+// GetCardAttachmentURL returns the short-lived URL the client gets the attachment from the storage by.
+// If the storage can't presign, it is the URL of GetCardAttachment.
+func (cc *CollectionController) GetCardAttachmentURL(w http.ResponseWriter, r *http.Request) {
+	size, err := domain.ParsePictureSize(r.URL.Query().Get("size"))
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	attachmentID := chi.URLParam(r, "attachmentID")
+	attachment, err := cc.checkAttachment(r, attachmentID)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+		internal.WriteError(w, r, err)
 		return
 	}
 
+	presigned, err := cc.CollectionUseCase.GetCardPhotoURL(r.Context(), attachment.ObjectName(), size)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrCardPictureNotFound)
+		return
+	}
+	if presigned.Proxy {
+		presigned.URL = proxyURL(r, strings.TrimSuffix(r.URL.Path, "/url"))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(presigned)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+}
+
+// checkAttachment checks that the user can see the card and returns the attachment of the card,
+// the id comes from the client.
+func (cc *CollectionController) checkAttachment(r *http.Request, attachmentID string) (domain.CardAttachment, error) {
+	coll, card, err := cc.collectionCard(r)
+	if err != nil {
+		return domain.CardAttachment{}, err
+	}
+
 	if !coll.IsPublic {
-		var user domain.User
-		user, err = cc.UserUseCase.GetByID(r.Context(), authID)
+		err = cc.checkMember(r, coll.ID)
 		if err != nil {
-			http.Error(w, jsonError(err.Error()), http.StatusNotFound)
-			return
+			return domain.CardAttachment{}, err
 		}
+	}
 
-		if !slices.Contains(user.Collections, collectionID) {
-			http.Error(w, jsonError("Access denied"), http.StatusForbidden)
-			return
+	attachment, ok := card.Attachment(attachmentID)
+	if !ok {
+		return domain.CardAttachment{}, domain.ErrCardPictureNotFound
+	}
+	return attachment, nil
+}
+
+// UploadCardPicture replaces the first picture of the question side of the card,
+// it is kept for the clients released before the attachments list.
+func (cc *CollectionController) UploadCardPicture(w http.ResponseWriter, r *http.Request) {
+	picture, contentType, err := readPicture(w, r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	userID := domain.UserIDFromContext(r.Context())
+	coll, card, err := cc.collectionCard(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = cc.checkMember(r, coll.ID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	// the attachments are sorted by their order
+	for _, attachment := range card.Attachments {
+		if attachment.Side == domain.CardSideQuestion {
+			err = cc.CollectionUseCase.RemoveCardAttachment(r.Context(), userID, coll.ID, card.LocalID, attachment.ID)
+			if err != nil {
+				internal.WriteError(w, r, err)
+				return
+			}
+			break
 		}
 	}
 
-	fileBytes, err := cc.CollectionUseCase.GetCardPhoto(r.Context(), objectName)
+	attachment, err := cc.CollectionUseCase.AddCardAttachment(
+		r.Context(), userID, coll.ID, card.LocalID, domain.CardSideQuestion,
+		bytes.NewReader(picture), int64(len(picture)), contentType,
+	)
 	if err != nil {
-		http.Error(w, jsonError("Card picture not found"), http.StatusNotFound)
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	_, err = w.Write(fileBytes)
+	err = json.NewEncoder(w).Encode(domain.UploadCardPhotoResult{
+		ObjectName: attachment.ID,
+	})
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
-//nolint:mnd // 5 MB
-func (cc *CollectionController) UploadCardPicture(w http.ResponseWriter, r *http.Request) {
-	err := r.ParseMultipartForm(5 << 20)
+func (cc *CollectionController) AddCardAttachment(w http.ResponseWriter, r *http.Request) {
+	side, err := domain.ParseCardSide(r.URL.Query().Get("side"))
 	if err != nil {
-		http.Error(w, jsonError("Error with file or its max size"), http.StatusBadRequest)
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	file, handler, err := r.FormFile("image")
+	picture, contentType, err := readPicture(w, r)
 	if err != nil {
-		http.Error(w, "Error retrieving the file", http.StatusBadRequest)
+		internal.WriteError(w, r, err)
 		return
 	}
-	defer file.Close()
 
-	if handler.Size > (5 << 20) {
-		http.Error(w, jsonError("Image's size should be less than 5 MB"), http.StatusBadRequest)
+	userID := domain.UserIDFromContext(r.Context())
+	coll, card, err := cc.collectionCard(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	//nolint:staticcheck // business logic
-	if !(strings.HasSuffix(handler.Filename, ".jpg") || strings.HasSuffix(handler.Filename, ".jpeg")) {
-		http.Error(w, jsonError("Image's extension should be JPG/JPEG"), http.StatusBadRequest)
+	err = cc.checkMember(r, coll.ID)
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	userID := r.Context().Value("x-user-id").(string)
-	id := chi.URLParam(r, "id")
-	cardID, err := strconv.Atoi(chi.URLParam(r, "cardID"))
+	attachment, err := cc.CollectionUseCase.AddCardAttachment(
+		r.Context(), userID, coll.ID, card.LocalID, side, bytes.NewReader(picture), int64(len(picture)), contentType,
+	)
 	if err != nil {
-		http.Error(w, jsonError("Invalid path cardID"), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	// check if attachment already is
-	coll, err := cc.CollectionUseCase.GetByID(r.Context(), id)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(attachment)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+}
+
+// StartCardAttachmentUpload starts the upload of an attachment the client puts to the storage by itself,
+// the attachment is added once the upload is completed.
+func (cc *CollectionController) StartCardAttachmentUpload(w http.ResponseWriter, r *http.Request) {
+	side, err := domain.ParseCardSide(r.URL.Query().Get("side"))
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	userID := domain.UserIDFromContext(r.Context())
+	coll, card, err := cc.collectionCard(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = cc.checkMember(r, coll.ID)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	// it is checked again on the completion, here the client is told before it puts the picture
+	if len(card.Attachments) >= domain.MaxCardAttachments {
+		internal.WriteError(w, r, domain.ErrTooManyAttachments)
 		return
 	}
 
-	for _, elem := range coll.Cards {
-		if elem.LocalID == cardID {
-			if elem.Attachment != "" {
-				err = cc.CollectionUseCase.RemoveCardPicture(r.Context(), userID, id, cardID, elem.Attachment)
-				if err != nil {
-					http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
-					return
-				}
+	startUpload(w, r, cc.UploadUseCase, &domain.Upload{
+		UserID:       userID,
+		Target:       domain.UploadTargetCardAttachment,
+		CollectionID: coll.ID,
+		CardID:       card.LocalID,
+		Side:         side,
+	})
+}
+
+// PutCardAttachmentUpload takes the picture of the upload when the storage can't presign.
+func (cc *CollectionController) PutCardAttachmentUpload(w http.ResponseWriter, r *http.Request) {
+	putUpload(w, r, cc.UploadUseCase, domain.UploadTargetCardAttachment)
+}
+
+// CompleteCardAttachmentUpload checks the uploaded picture and adds it to the card
+// as AddCardAttachment does, it counts toward the total file size from now on.
+func (cc *CollectionController) CompleteCardAttachmentUpload(w http.ResponseWriter, r *http.Request) {
+	userID := domain.UserIDFromContext(r.Context())
+	coll, card, err := cc.collectionCard(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = cc.checkMember(r, coll.ID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	var attachment domain.CardAttachment
+	err = completeUpload(r, cc.UploadUseCase, domain.UploadTargetCardAttachment,
+		func(c context.Context, upload domain.Upload, picture []byte, contentType string) error {
+			if upload.CollectionID != coll.ID || upload.CardID != card.LocalID {
+				return domain.ErrUploadNotFound
 			}
-			break
-		}
+			attachment, err = cc.CollectionUseCase.AddCardAttachment(
+				c, userID, coll.ID, card.LocalID, upload.Side,
+				bytes.NewReader(picture), int64(len(picture)), contentType,
+			)
+			return err
+		})
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(attachment)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+}
+
+func (cc *CollectionController) ReorderCardAttachments(w http.ResponseWriter, r *http.Request) {
+	var request domain.ReorderAttachmentsRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	coll, card, err := cc.collectionCard(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = cc.checkMember(r, coll.ID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
 	}
-	//
 
-	objectName, err := cc.CollectionUseCase.UploadCardPhoto(r.Context(), userID, id, cardID, file, handler.Size)
+	attachments, err := cc.CollectionUseCase.ReorderCardAttachments(r.Context(), coll.ID, card.LocalID, request.IDs)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(domain.UploadCardPhotoResult{
-		ObjectName: objectName,
-	})
+	err = json.NewEncoder(w).Encode(attachments)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
 
+// RemoveCardPicture removes the attachment of the object_name query parameter,
+// it is kept for the clients released before the attachments list.
 func (cc *CollectionController) RemoveCardPicture(w http.ResponseWriter, r *http.Request) {
-	id := r.Context().Value("x-user-id").(string)
-	queryParams := r.URL.Query()
-	objectName := queryParams.Get("object_name")
+	objectName := r.URL.Query().Get("object_name")
+	if objectName == "" {
+		internal.WriteError(w, r, domain.InvalidFields("object_name"))
+		return
+	}
+	cc.removeAttachment(w, r, objectName, "Card picture deleted")
+}
 
-	collectionID := chi.URLParam(r, "id")
-	cardID, err := strconv.Atoi(chi.URLParam(r, "cardID"))
+func (cc *CollectionController) RemoveCardAttachment(w http.ResponseWriter, r *http.Request) {
+	cc.removeAttachment(w, r, chi.URLParam(r, "attachmentID"), "Card attachment deleted")
+}
+
+func (cc *CollectionController) removeAttachment(
+	w http.ResponseWriter,
+	r *http.Request,
+	attachmentID string,
+	message string,
+) {
+	userID := domain.UserIDFromContext(r.Context())
+	coll, card, err := cc.collectionCard(r)
 	if err != nil {
-		http.Error(w, jsonError("Invalid path cardID"), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	if objectName == "" || strings.Count(objectName, "_") != 2 {
-		http.Error(w, jsonError("Invalid object_name"), http.StatusBadRequest)
+	err = cc.checkMember(r, coll.ID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = cc.CollectionUseCase.RemoveCardAttachment(r.Context(), userID, coll.ID, card.LocalID, attachmentID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: message,
+	})
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+}
+
+// maxPictureForm is the size of a form with a picture, the rest of the form is small.
+const maxPictureForm = domain.MaxPictureSize + 1<<20
+
+// readPicture reads the picture of the "image" field of the form
+// and returns it without the metadata with its content type.
+func readPicture(w http.ResponseWriter, r *http.Request) ([]byte, string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxPictureForm)
+	err := r.ParseMultipartForm(domain.MaxPictureSize)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, "", domain.ErrImageTooLarge
+	}
+	if err != nil {
+		return nil, "", domain.ErrInvalidFile
+	}
+
+	file, handler, err := r.FormFile("image")
+	if err != nil {
+		return nil, "", domain.RequiredFields("image")
+	}
+	defer file.Close()
+
+	if handler.Size > domain.MaxPictureSize {
+		return nil, "", domain.ErrImageTooLarge
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, "", domain.ErrInvalidFile
+	}
+	// the type is told by the content, the name of the file can be anything
+	return internal.CleanImage(data)
+}
+
+// startUpload starts the upload and writes its ticket, the URL of the proxy ticket is under the path of r.
+func startUpload(w http.ResponseWriter, r *http.Request, uploads domain.UploadUseCase, upload *domain.Upload) {
+	ticket, err := uploads.Start(r.Context(), upload)
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
+	if ticket.Proxy {
+		ticket.URL = strings.TrimSuffix(r.URL.Path, "/") + "/" + ticket.ID
+	}
 
-	spl := strings.Split(objectName, "_")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(ticket)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+}
 
-	if spl[0] != chi.URLParam(r, "id") {
-		http.Error(w, jsonError("invalid collection / object_name"), http.StatusBadRequest)
+// putUpload stores the picture of the upload of the uploadID path parameter put to the server.
+func putUpload(w http.ResponseWriter, r *http.Request, uploads domain.UploadUseCase, target domain.UploadTarget) {
+	data, err := readUpload(w, r)
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	user, err := cc.UserUseCase.GetByID(r.Context(), id)
+	userID := domain.UserIDFromContext(r.Context())
+	err = uploads.Put(
+		r.Context(), userID, chi.URLParam(r, "uploadID"), target, bytes.NewReader(data), int64(len(data)),
+	)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+		internal.WriteError(w, r, err)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "File uploaded",
+	})
+}
+
+// completeUpload completes the upload of the uploadID path parameter, the picture is cleaned as readPicture does
+// before it is passed to store. The upload is kept if it is not stored, see domain.UploadUseCase.
+func completeUpload(
+	r *http.Request,
+	uploads domain.UploadUseCase,
+	target domain.UploadTarget,
+	store func(c context.Context, upload domain.Upload, picture []byte, contentType string) error,
+) error {
+	userID := domain.UserIDFromContext(r.Context())
+	return uploads.Complete(r.Context(), userID, chi.URLParam(r, "uploadID"), target,
+		func(c context.Context, upload domain.Upload, data []byte) error {
+			picture, contentType, err := internal.CleanImage(data)
+			if err != nil {
+				return err
+			}
+			return store(c, upload, picture, contentType)
+		})
+}
+
+// readUpload reads the picture put to the server in place of the storage, it is the body of the request.
+func readUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, domain.MaxPictureSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, domain.ErrImageTooLarge
+	}
+	if err != nil {
+		return nil, domain.ErrInvalidFile
+	}
+	return data, nil
+}
+
+// proxyURL is the URL of the path the object goes through the server by, the query of the request is kept.
+func proxyURL(r *http.Request, path string) string {
+	u := url.URL{Path: path, RawQuery: r.URL.RawQuery}
+	return u.String()
+}
+
+// collectionCard returns the collection of the id path parameter and its card of the cardID one.
+func (cc *CollectionController) collectionCard(r *http.Request) (domain.Collection, domain.Card, error) {
+	cardID, err := strconv.Atoi(chi.URLParam(r, "cardID"))
+	if err != nil {
+		return domain.Collection{}, domain.Card{}, domain.InvalidFields("cardID")
+	}
+
+	coll, err := cc.CollectionUseCase.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		return domain.Collection{}, domain.Card{}, domain.ErrCollectionNotFound
+	}
+	for _, card := range coll.Cards {
+		if card.LocalID == cardID {
+			return coll, card, nil
+		}
+	}
+	return domain.Collection{}, domain.Card{}, domain.ErrCardNotFound
+}
+
+// checkMember returns ErrAccessDenied if the collection is not among the collections of the user.
+func (cc *CollectionController) checkMember(r *http.Request, collectionID string) error {
+	user, err := cc.UserUseCase.GetByID(r.Context(), domain.UserIDFromContext(r.Context()))
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
 	if !slices.Contains(user.Collections, collectionID) {
-		http.Error(w, jsonError("Access denied"), http.StatusBadRequest)
+		return domain.ErrAccessDenied
+	}
+	return nil
+}
+
+// cardAudio reads the side of the card from the query and returns the object name of its audio,
+// it is empty if the side has no audio.
+func (cc *CollectionController) cardAudio(r *http.Request) (domain.Collection, int, domain.CardSide, string, error) {
+	side, err := domain.ParseCardSide(r.URL.Query().Get("side"))
+	if err != nil {
+		return domain.Collection{}, 0, "", "", err
+	}
+	coll, card, err := cc.collectionCard(r)
+	if err != nil {
+		return domain.Collection{}, 0, "", "", err
+	}
+	return coll, card.LocalID, side, card.Audio(side), nil
+}
+
+func (cc *CollectionController) GetCardAudio(w http.ResponseWriter, r *http.Request) {
+	coll, _, _, objectName, err := cc.cardAudio(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	if !coll.IsPublic {
+		err = cc.checkMember(r, coll.ID)
+		if err != nil {
+			internal.WriteError(w, r, err)
+			return
+		}
+	}
+
+	// the audio is the one of the card, it is shared with the cards that have the same one
+	if objectName == "" {
+		internal.WriteError(w, r, domain.ErrCardAudioNotFound)
+		return
+	}
+
+	audio, err := cc.CollectionUseCase.GetCardAudio(r.Context(), objectName)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrCardAudioNotFound)
+		return
+	}
+
+	internal.ServeObject(w, r, audio)
+}
+
+// maxAudioForm is the size of a form with an audio, the rest of the form is small.
+const maxAudioForm = domain.MaxAudioSize + 1<<20
+
+func (cc *CollectionController) UploadCardAudio(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxAudioForm)
+	err := r.ParseMultipartForm(domain.MaxAudioSize)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		internal.WriteError(w, r, domain.ErrAudioTooLarge)
+		return
+	}
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidFile)
+		return
+	}
+
+	file, handler, err := r.FormFile("audio")
+	if err != nil {
+		internal.WriteError(w, r, domain.RequiredFields("audio"))
+		return
+	}
+	defer file.Close()
+
+	if handler.Size > domain.MaxAudioSize {
+		internal.WriteError(w, r, domain.ErrAudioTooLarge)
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidFile)
+		return
+	}
+	contentType, duration, err := internal.CheckAudio(data)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+	if duration > domain.MaxAudioDuration {
+		internal.WriteError(w, r, domain.ErrAudioTooLarge)
+		return
+	}
+
+	userID := domain.UserIDFromContext(r.Context())
+	coll, cardID, side, _, err := cc.cardAudio(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = cc.checkMember(r, coll.ID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	// the previous audio of the side is replaced by the usecase
+	objectName, err := cc.CollectionUseCase.UploadCardAudio(
+		r.Context(), userID, coll.ID, cardID, side, bytes.NewReader(data), int64(len(data)), contentType,
+	)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(domain.UploadCardAudioResult{
+		ObjectName: objectName,
+		DurationMs: duration.Milliseconds(),
+	})
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+}
+
+func (cc *CollectionController) RemoveCardAudio(w http.ResponseWriter, r *http.Request) {
+	userID := domain.UserIDFromContext(r.Context())
+
+	coll, cardID, side, objectName, err := cc.cardAudio(r)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = cc.checkMember(r, coll.ID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	if objectName == "" {
+		internal.WriteError(w, r, domain.ErrCardAudioNotFound)
 		return
 	}
 
-	err = cc.CollectionUseCase.RemoveCardPicture(r.Context(), id, collectionID, cardID, objectName)
+	err = cc.CollectionUseCase.RemoveCardAudio(r.Context(), userID, coll.ID, cardID, side, objectName)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(domain.SuccessResponse{
-		Message: "Card picture deleted",
+		Message: "Card audio deleted",
 	})
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 }
@@ -1,5 +0,0 @@
-package controller
-
-func jsonError(message string) string {
-	return `{"message": "` + message + `"}`
-}
@@ -5,6 +5,7 @@ import (
 	"main/api/middleware"
 	"main/bootstrap"
 	"main/domain"
+	"main/internal"
 	"net/http"
 	"strconv"
 )
@@ -17,28 +18,25 @@ type GlobalController struct {
 func (gc *GlobalController) GetTrainingPlan(w http.ResponseWriter, r *http.Request) {
 	startDate, err := strconv.Atoi(r.URL.Query().Get("start_date"))
 	if err != nil {
-		http.Error(w, jsonError("invalid start date"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("start_date"))
 		return
 	}
 
 	endDate, err := strconv.Atoi(r.URL.Query().Get("end_date"))
 	if err != nil {
-		http.Error(w, jsonError("invalid end date"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("end_date"))
 		return
 	}
 
 	preferredTime, err := strconv.Atoi(r.URL.Query().Get("preferred_time"))
 	if err != nil || preferredTime < 0 || preferredTime > 86399 {
-		http.Error(w, jsonError("invalid preferred time"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("preferred_time"))
 		return
 	}
 
 	if endDate-startDate < 24*3600 {
-		http.Error(
-			w,
-			jsonError("difference between the beginning and the end should be at least a day"),
-			http.StatusBadRequest,
-		)
+		// the end should be at least a day after the beginning
+		internal.WriteError(w, r, domain.InvalidFields("start_date", "end_date"))
 		return
 	}
 
@@ -57,22 +55,24 @@ func (gc *GlobalController) AddMetrics(w http.ResponseWriter, r *http.Request) {
 	var req domain.MetricsRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, jsonError("Invalid data"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 	if req.FilterClicks < 0 || req.ProfileClicks < 0 || req.LastInAppTime < 0 || req.SumTrainingsTime < 0 ||
 		req.TrainingsCount < 0 {
-		http.Error(w, jsonError("Metrics cannot be negative"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields(
+			"filter_clicks", "profile_clicks", "last_in_app_time", "sum_trainings_time", "trainings_count",
+		))
 		return
 	}
 
 	if req.SumTrainingsTime > 0 && req.TrainingsCount == 0 {
-		http.Error(w, jsonError("invalid trainings (count or time)"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("trainings_count", "sum_trainings_time"))
 		return
 	}
 
 	if req.SumTrainingsTime > req.LastInAppTime {
-		http.Error(w, jsonError("in app time cannot be lower than training time"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("last_in_app_time", "sum_trainings_time"))
 		return
 	}
 
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"encoding/json"
+	"main/bootstrap"
+	"main/domain"
+	"main/internal"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type IdentityController struct {
+	OAuthUseCase domain.OAuthUseCase
+	Env          *bootstrap.Env
+}
+
+func (ic *IdentityController) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	identities, err := ic.OAuthUseCase.GetIdentities(r.Context(), id)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.IdentityArray{
+		Count: len(identities),
+		Items: identities,
+	})
+}
+
+// Link starts an authorization whose callback attaches the provider account
+// to the current user instead of signing in by email.
+func (ic *IdentityController) Link(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	provider := chi.URLParam(r, "provider")
+	start, err := ic.OAuthUseCase.Start(r.Context(), provider, id, ic.Env.OAuthStateExpiryMinute)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(start)
+}
+
+func (ic *IdentityController) Unlink(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	provider := chi.URLParam(r, "provider")
+	err := ic.OAuthUseCase.Unlink(r.Context(), id, provider)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Identity unlinked",
+	})
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"encoding/json"
+	"main/domain"
+	"net/http"
+)
+
+type JWKSController struct {
+	KeySet domain.KeySet
+}
+
+// GetJWKS publishes the public keys, so that other services can verify access tokens.
+func (jc *JWKSController) GetJWKS(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(jc.KeySet.JWKS())
+}
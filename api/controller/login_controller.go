@@ -4,14 +4,25 @@ import (
 	"encoding/json"
 	"main/bootstrap"
 	"main/domain"
+	"main/internal"
 	"net/http"
+	"net/url"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/gookit/slog"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type LoginController struct {
-	LoginUseCase domain.LoginUseCase
-	Env          *bootstrap.Env
+	LoginUseCase        domain.LoginUseCase
+	LoginAttemptUseCase domain.LoginAttemptUseCase
+	TwoFactorUseCase    domain.TwoFactorUseCase
+	OAuthUseCase        domain.OAuthUseCase
+	KeySet              domain.KeySet
+	Env                 *bootstrap.Env
+	// LegacyHeaders keeps the token expiry headers in the format the clients released before /v1 parse
+	LegacyHeaders bool
 }
 
 func (lc *LoginController) Login(w http.ResponseWriter, r *http.Request) {
@@ -19,39 +30,168 @@ func (lc *LoginController) Login(w http.ResponseWriter, r *http.Request) {
 
 	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	ip := internal.ClientIP(r)
+	err = lc.LoginAttemptUseCase.Reserve(r.Context(), request.Email, ip)
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
 
 	user, err := lc.LoginUseCase.GetUserByEmail(r.Context(), request.Email)
 	if err != nil {
-		http.Error(w, jsonError("User with this email not found"), http.StatusNotFound)
+		lc.loginFailed(r, request.Email, ip, "user not found")
+		internal.WriteError(w, r, domain.ErrUserNotFound)
 		return
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password))
 	if err != nil {
-		http.Error(w, jsonError("Invalid credentials"), http.StatusBadRequest)
+		lc.loginFailed(r, request.Email, ip, "invalid password")
+		internal.WriteError(w, r, domain.ErrInvalidCredentials)
 		return
 	}
 
-	accessToken, expAccess, err := lc.LoginUseCase.CreateAccessToken(
-		&user,
-		lc.Env.AccessTokenSecret,
-		lc.Env.AccessTokenExpiryHour,
-	)
+	err = lc.LoginAttemptUseCase.Succeed(r.Context(), request.Email, ip)
+	if err != nil {
+		slog.Errorf("Can't reset login attempts of %s: %v", user.ID, err)
+	}
+
+	lc.login(w, r, &user, request.Device)
+}
+
+func (lc *LoginController) loginFailed(r *http.Request, email string, ip string, reason string) {
+	err := lc.LoginAttemptUseCase.Fail(r.Context(), &domain.LoginAudit{
+		Email:     email,
+		IP:        ip,
+		UserAgent: r.UserAgent(),
+		Reason:    reason,
+	})
+	if err != nil {
+		slog.Errorf("Can't record failed login from %s: %v", ip, err)
+	}
+}
+
+// LoginMFA is the second step of the login for users with two-factor authentication.
+func (lc *LoginController) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var request domain.LoginMFARequest
+
+	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 
+	if request.MFAToken == "" || request.Code == "" {
+		internal.WriteError(w, r, domain.RequiredFields("mfa_token", "code"))
+		return
+	}
+
+	user, err := lc.TwoFactorUseCase.VerifyChallenge(r.Context(), request.MFAToken, request.Code)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	lc.issueTokens(w, r, &user, request.Device)
+}
+
+// OAuthStart begins a sign in through an external provider.
+func (lc *LoginController) OAuthStart(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+	start, err := lc.OAuthUseCase.Start(r.Context(), provider, "", lc.Env.OAuthStateExpiryMinute)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(start)
+}
+
+// OAuthCallback finishes the sign in with the code the provider redirected back with.
+func (lc *LoginController) OAuthCallback(w http.ResponseWriter, r *http.Request) {
+	var request domain.OAuthCallbackRequest
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	if request.Code == "" || request.State == "" {
+		internal.WriteError(w, r, domain.RequiredFields("code", "state"))
+		return
+	}
+
+	extra := url.Values{}
+	if request.DeviceID != "" {
+		extra.Set("device_id", request.DeviceID)
+		extra.Set("state", request.State)
+	}
+
+	provider := chi.URLParam(r, "provider")
+	user, err := lc.OAuthUseCase.Complete(r.Context(), provider, request.Code, request.State, extra)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	lc.login(w, r, &user, request.Device)
+}
+
+// login finishes a successful first factor: users with two-factor
+// authentication get a challenge, everyone else gets the tokens.
+func (lc *LoginController) login(w http.ResponseWriter, r *http.Request, user *domain.User, device string) {
+	if user.TwoFactor.Enabled {
+		mfaToken, err := lc.TwoFactorUseCase.CreateChallenge(r.Context(), user, lc.Env.MFAChallengeExpiryMinute)
+		if err != nil {
+			internal.WriteError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(domain.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		})
+		return
+	}
+
+	lc.issueTokens(w, r, user, device)
+}
+
+func (lc *LoginController) issueTokens(w http.ResponseWriter, r *http.Request, user *domain.User, device string) {
+	session := &domain.Session{
+		DeviceName: device,
+		UserAgent:  r.UserAgent(),
+		IP:         internal.ClientIP(r),
+	}
 	refreshToken, expRefresh, err := lc.LoginUseCase.CreateRefreshToken(
-		&user,
+		r.Context(),
+		user,
+		session,
 		lc.Env.RefreshTokenSecret,
 		lc.Env.RefreshTokenExpiryHour,
 	)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	accessToken, expAccess, err := lc.LoginUseCase.CreateAccessToken(
+		user,
+		session.ID,
+		lc.KeySet,
+		lc.Env.AccessTokenExpiryHour,
+	)
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -61,8 +201,20 @@ func (lc *LoginController) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Access-Expires-After", expAccess.UTC().String())
-	w.Header().Set("X-Refresh-Expires-After", expRefresh.UTC().String())
+	setExpiryHeaders(w, expAccess, expRefresh, lc.LegacyHeaders)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(loginResponse)
 }
+
+// setExpiryHeaders tells when the tokens expire: in RFC 3339 on /v1
+// and in the format of time.Time.String on the legacy tree.
+func setExpiryHeaders(w http.ResponseWriter, expAccess time.Time, expRefresh time.Time, legacy bool) {
+	format := func(t time.Time) string {
+		if legacy {
+			return t.UTC().String()
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	w.Header().Set("X-Access-Expires-After", format(expAccess))
+	w.Header().Set("X-Refresh-Expires-After", format(expRefresh))
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"main/bootstrap"
+	"main/domain"
+	"main/internal"
+	"net/http"
+)
+
+type LogoutController struct {
+	SessionUseCase domain.SessionUseCase
+	Env            *bootstrap.Env
+}
+
+func (lc *LogoutController) Logout(w http.ResponseWriter, r *http.Request) {
+	var request domain.LogoutRequest
+
+	// the body is optional: without it the session of the access token is closed
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	userID := domain.UserIDFromContext(r.Context())
+	sessionID := domain.SessionIDFromContext(r.Context())
+
+	if request.RefreshToken != "" {
+		var claims *domain.JwtCustomRefreshClaims
+		claims, err = lc.SessionUseCase.ParseRefreshToken(request.RefreshToken, lc.Env.RefreshTokenSecret)
+		if err != nil {
+			internal.WriteError(w, r, domain.ErrInvalidRefreshToken)
+			return
+		}
+		sessionID = claims.SessionID
+	}
+
+	if sessionID == "" {
+		internal.WriteError(w, r, domain.RequiredFields("refresh_token"))
+		return
+	}
+
+	err = lc.SessionUseCase.Revoke(r.Context(), userID, sessionID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Logged out",
+	})
+}
+
+func (lc *LogoutController) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	userID := domain.UserIDFromContext(r.Context())
+	err := lc.SessionUseCase.RevokeAll(r.Context(), userID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "All sessions revoked",
+	})
+}
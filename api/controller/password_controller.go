@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"encoding/json"
+	"main/bootstrap"
+	"main/domain"
+	"main/internal"
+	"net/http"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordController struct {
+	PasswordUseCase domain.PasswordUseCase
+	Env             *bootstrap.Env
+}
+
+func (pc *PasswordController) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var request domain.ForgotPasswordRequest
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	if request.Email == "" {
+		internal.WriteError(w, r, domain.RequiredFields("email"))
+		return
+	}
+	if !internal.ValidateEmail(request.Email) {
+		internal.WriteError(w, r, domain.InvalidFields("email"))
+		return
+	}
+
+	err = pc.PasswordUseCase.RequestReset(r.Context(), request.Email, pc.Env.ResetTokenExpiryMinute)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "If the account exists, a reset code has been sent",
+	})
+}
+
+func (pc *PasswordController) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var request domain.ResetPasswordRequest
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	if request.Token == "" || request.NewPassword == "" {
+		internal.WriteError(w, r, domain.RequiredFields("token", "new_password"))
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword(
+		[]byte(request.NewPassword),
+		bcrypt.DefaultCost,
+	)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = pc.PasswordUseCase.Reset(r.Context(), request.Token, string(hashedPassword))
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Password has been reset",
+	})
+}
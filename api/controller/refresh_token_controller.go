@@ -4,12 +4,16 @@ import (
 	"encoding/json"
 	"main/bootstrap"
 	"main/domain"
+	"main/internal"
 	"net/http"
 )
 
 type RefreshTokenController struct {
 	RefreshTokenUseCase domain.RefreshTokenUseCase
+	KeySet              domain.KeySet
 	Env                 *bootstrap.Env
+	// LegacyHeaders keeps the token expiry headers in the format the clients released before /v1 parse
+	LegacyHeaders bool
 }
 
 func (rtc *RefreshTokenController) RefreshToken(w http.ResponseWriter, r *http.Request) {
@@ -17,39 +21,42 @@ func (rtc *RefreshTokenController) RefreshToken(w http.ResponseWriter, r *http.R
 
 	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 
-	id, err := rtc.RefreshTokenUseCase.ExtractIDFromToken(request.RefreshToken, rtc.Env.RefreshTokenSecret)
+	claims, err := rtc.RefreshTokenUseCase.ParseRefreshToken(request.RefreshToken, rtc.Env.RefreshTokenSecret)
 	if err != nil {
-		http.Error(w, jsonError("Invalid refresh token"), http.StatusUnauthorized)
+		internal.WriteError(w, r, domain.ErrInvalidRefreshToken)
 		return
 	}
 
-	user, err := rtc.RefreshTokenUseCase.GetUserByID(r.Context(), id)
+	user, err := rtc.RefreshTokenUseCase.GetUserByID(r.Context(), claims.ID)
 	if err != nil {
-		http.Error(w, jsonError("User not found"), http.StatusUnauthorized)
+		internal.WriteError(w, r, domain.ErrInvalidRefreshToken)
 		return
 	}
 
-	accessToken, expAccess, err := rtc.RefreshTokenUseCase.CreateAccessToken(
+	refreshToken, expRefresh, err := rtc.RefreshTokenUseCase.RotateRefreshToken(
+		r.Context(),
 		&user,
-		rtc.Env.AccessTokenSecret,
-		rtc.Env.AccessTokenExpiryHour,
+		claims,
+		rtc.Env.RefreshTokenSecret,
+		rtc.Env.RefreshTokenExpiryHour,
 	)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	refreshToken, expRefresh, err := rtc.RefreshTokenUseCase.CreateRefreshToken(
+	accessToken, expAccess, err := rtc.RefreshTokenUseCase.CreateAccessToken(
 		&user,
-		rtc.Env.RefreshTokenSecret,
-		rtc.Env.RefreshTokenExpiryHour,
+		claims.SessionID,
+		rtc.KeySet,
+		rtc.Env.AccessTokenExpiryHour,
 	)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -59,8 +66,7 @@ func (rtc *RefreshTokenController) RefreshToken(w http.ResponseWriter, r *http.R
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Access-Expires-After", expAccess.UTC().String())
-	w.Header().Set("X-Refresh-Expires-After", expRefresh.UTC().String())
+	setExpiryHeaders(w, expAccess, expRefresh, rtc.LegacyHeaders)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(refreshTokenResponse)
 }
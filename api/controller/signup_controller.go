@@ -4,14 +4,20 @@ import (
 	"encoding/json"
 	"main/bootstrap"
 	"main/domain"
+	"main/internal"
 	"net/http"
 
+	"github.com/gookit/slog"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type SignupController struct {
-	SignupUseCase domain.SignupUseCase
-	Env           *bootstrap.Env
+	SignupUseCase       domain.SignupUseCase
+	VerificationUseCase domain.VerificationUseCase
+	KeySet              domain.KeySet
+	Env                 *bootstrap.Env
+	// LegacyHeaders keeps the token expiry headers in the format the clients released before /v1 parse
+	LegacyHeaders bool
 }
 
 func (sc *SignupController) Signup(w http.ResponseWriter, r *http.Request) {
@@ -19,18 +25,22 @@ func (sc *SignupController) Signup(w http.ResponseWriter, r *http.Request) {
 
 	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 
 	if request.Username == "" || request.Email == "" || request.Password == "" {
-		http.Error(w, jsonError("Invalid data"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.RequiredFields("username", "email", "password"))
+		return
+	}
+	if !internal.ValidateEmail(request.Email) {
+		internal.WriteError(w, r, domain.InvalidFields("email"))
 		return
 	}
 
 	_, err = sc.SignupUseCase.GetUserByEmail(r.Context(), request.Email)
 	if err == nil {
-		http.Error(w, jsonError("User with this email already exists"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrUserExists)
 		return
 	}
 
@@ -39,7 +49,7 @@ func (sc *SignupController) Signup(w http.ResponseWriter, r *http.Request) {
 		bcrypt.DefaultCost,
 	)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -51,27 +61,41 @@ func (sc *SignupController) Signup(w http.ResponseWriter, r *http.Request) {
 
 	userID, err := sc.SignupUseCase.Create(r.Context(), user)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	accessToken, expAccess, err := sc.SignupUseCase.CreateAccessToken(
-		user,
-		sc.Env.AccessTokenSecret,
-		sc.Env.AccessTokenExpiryHour,
-	)
+	// the account already exists, so a mail failure must not fail the signup;
+	// the user can ask for another email later
+	err = sc.VerificationUseCase.SendVerification(r.Context(), userID, sc.Env.VerificationTokenExpiryHour)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
-		return
+		slog.Errorf("Can't send verification email to %s: %v", userID, err)
 	}
 
+	session := &domain.Session{
+		UserAgent: r.UserAgent(),
+		IP:        internal.ClientIP(r),
+	}
 	refreshToken, expRefresh, err := sc.SignupUseCase.CreateRefreshToken(
+		r.Context(),
 		user,
+		session,
 		sc.Env.RefreshTokenSecret,
 		sc.Env.RefreshTokenExpiryHour,
 	)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	accessToken, expAccess, err := sc.SignupUseCase.CreateAccessToken(
+		user,
+		session.ID,
+		sc.KeySet,
+		sc.Env.AccessTokenExpiryHour,
+	)
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -82,8 +106,7 @@ func (sc *SignupController) Signup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Access-Expires-After", expAccess.UTC().String())
-	w.Header().Set("X-Refresh-Expires-After", expRefresh.UTC().String())
+	setExpiryHeaders(w, expAccess, expRefresh, sc.LegacyHeaders)
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(signupResponse)
 }
@@ -0,0 +1,80 @@
+package tests_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"main/api/controller"
+	"main/api/middleware"
+	"main/domain"
+	mocks "main/mocks/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiKeyController_CreateApiKey_Success(t *testing.T) {
+	mockApiKeyUseCase := new(mocks.ApiKeyUseCase)
+	ac := &controller.ApiKeyController{ApiKeyUseCase: mockApiKeyUseCase}
+
+	request := domain.CreateApiKeyRequest{Name: "backup", Scopes: []string{domain.ScopeCollectionsRead}}
+	mockApiKeyUseCase.On("Create", mock.Anything, "user-id", &request).Return(domain.CreateApiKeyResponse{
+		Key:    "tp_secret",
+		ApiKey: domain.ApiKey{ID: "key-id", Name: "backup", Scopes: request.Scopes},
+	}, nil)
+
+	body, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/user/api-keys", bytes.NewReader(body))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: "user-id"}))
+	rr := httptest.NewRecorder()
+	ac.CreateApiKey(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var response domain.CreateApiKeyResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, "tp_secret", response.Key)
+	assert.Equal(t, "key-id", response.ApiKey.ID)
+	mockApiKeyUseCase.AssertExpectations(t)
+}
+
+func TestJwtAuthMiddleware_ApiKeyScopes(t *testing.T) {
+	mockApiKeyUseCase := new(mocks.ApiKeyUseCase)
+	mockSessionUseCase := new(mocks.SessionUseCase)
+	mockApiKeyUseCase.On("Authenticate", mock.Anything, "tp_secret").Return(domain.ApiKey{
+		UserID: "user-id",
+		Scopes: []string{domain.ScopeCollectionsRead},
+	}, nil)
+	mockApiKeyUseCase.On("Authenticate", mock.Anything, "tp_revoked").Return(domain.ApiKey{}, domain.ErrInvalidApiKey)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := domain.PrincipalFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, "user-id", principal.UserID)
+		assert.Equal(t, domain.AuthMethodApiKey, principal.AuthMethod)
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.JwtAuthMiddleware(newTestKeySet(t), mockSessionUseCase, mockApiKeyUseCase)(next)
+
+	tests := []struct {
+		method string
+		path   string
+		key    string
+		status int
+	}{
+		{http.MethodGet, "/collection/collection-id", "tp_secret", http.StatusOK},
+		{http.MethodPost, "/collection", "tp_secret", http.StatusForbidden},
+		{http.MethodGet, "/user/history", "tp_secret", http.StatusForbidden},
+		{http.MethodGet, "/user/sessions", "tp_secret", http.StatusForbidden},
+		{http.MethodGet, "/collection", "tp_revoked", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Header.Set("Authorization", "ApiKey "+tt.key)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tt.status, rr.Code, "%s %s", tt.method, tt.path)
+	}
+}
@@ -47,8 +47,7 @@ func TestCollectionController_Create_Success(t *testing.T) {
 
 	bodyJSON, _ := easyjson.Marshal(collection)
 	req := httptest.NewRequest(http.MethodPost, "/collection", strings.NewReader(string(bodyJSON)))
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", userID))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
 	rr := httptest.NewRecorder()
 
 	controller.Create(rr, req)
@@ -77,13 +76,12 @@ func TestCollectionController_Create_EmptyName(t *testing.T) {
 	bodyJSON, _ := easyjson.Marshal(invalidCollection)
 
 	req := httptest.NewRequest(http.MethodPost, "/collection", strings.NewReader(string(bodyJSON)))
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", "user-id"))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: "user-id"}))
 	rr := httptest.NewRecorder()
 	controller.Create(rr, req)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
-	assert.Contains(t, rr.Body.String(), "Invalid name")
+	assert.Contains(t, rr.Body.String(), `"field":"name"`)
 }
 
 func TestCollectionController_Update_Success(t *testing.T) {
@@ -105,8 +103,7 @@ func TestCollectionController_Update_Success(t *testing.T) {
 
 	bodyJSON, _ := easyjson.Marshal(updCollection)
 	req := httptest.NewRequest(http.MethodPut, "/collection/{id}", strings.NewReader(string(bodyJSON)))
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", userID))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
 	chiCtx := chi.NewRouteContext()
 	chiCtx.URLParams.Add("id", collID)
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
@@ -126,15 +123,16 @@ func TestCollectionController_Update_EmptyName(t *testing.T) {
 	collection := domain.Collection{Name: ""}
 	bodyJSON, _ := easyjson.Marshal(collection)
 	req := httptest.NewRequest(http.MethodPut, "/collection/{id}", strings.NewReader(string(bodyJSON)))
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", "user-id"))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: "user-id"}))
 	rr := httptest.NewRecorder()
 	controller.Update(rr, req)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
-	var resp domain.SuccessResponse
+	var resp domain.ErrorResponse
 	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
-	assert.Contains(t, "Invalid name", resp.Message)
+	assert.Equal(t, domain.ErrInvalidData.Code, resp.Code)
+	require.Len(t, resp.Details, 1)
+	assert.Equal(t, "name", resp.Details[0].Field)
 }
 
 func TestCollectionController_Update_NotFound(t *testing.T) {
@@ -145,8 +143,7 @@ func TestCollectionController_Update_NotFound(t *testing.T) {
 	bodyJSON, _ := easyjson.Marshal(collection)
 	collID := "coll-id"
 	req := httptest.NewRequest(http.MethodPut, "/collections/{id}", bytes.NewReader(bodyJSON))
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", "user-id"))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: "user-id"}))
 	ctx := chi.NewRouteContext()
 	ctx.URLParams.Add("id", collID)
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
@@ -173,8 +170,7 @@ func TestCollectionController_Update_NotAuthor(t *testing.T) {
 	mockCollUseCase.On("GetByID", mock.Anything, collID).Return(collection, nil)
 
 	req := httptest.NewRequest(http.MethodPut, "/collection/{id}", strings.NewReader(string(bodyJSON)))
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", "userID"))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: "userID"}))
 	ctx := chi.NewRouteContext()
 	ctx.URLParams.Add("id", collID)
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
@@ -200,15 +196,14 @@ func TestCollectionController_Delete_Success(t *testing.T) {
 		ID:     collID,
 		Author: userID,
 		Cards: []domain.Card{
-			{LocalID: 1, Attachment: "pic.jpg"},
+			{LocalID: 1, Attachments: []domain.CardAttachment{{ID: "pic", Side: domain.CardSideQuestion}}},
 		},
 	}
 	mockCollUseCase.On("GetByID", mock.Anything, collID).Return(collection, nil)
 	mockCollUseCase.On("DeleteByID", mock.Anything, collID, userID).Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/collection/{id}", nil)
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", userID))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
 	chiCtx := chi.NewRouteContext()
 	chiCtx.URLParams.Add("id", collID)
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
@@ -238,8 +233,7 @@ func TestCollectionController_Delete_NotFound(t *testing.T) {
 	mockCollUseCase.On("GetByID", mock.Anything, collID).Return(domain.Collection{}, errors.New("not found"))
 
 	req := httptest.NewRequest(http.MethodDelete, "/collections/{id}", nil)
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", userID))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
 	chiCtx := chi.NewRouteContext()
 	chiCtx.URLParams.Add("id", collID)
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
@@ -272,8 +266,7 @@ func TestCollectionController_Delete_NotAuthor(t *testing.T) {
 	mockCollUseCase.On("GetByID", mock.Anything, collID).Return(collection, nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/collections/{id}", nil)
-	//nolint:revive,staticcheck // uselless
-	req = req.WithContext(context.WithValue(req.Context(), "x-user-id", userID))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
 	chiCtx := chi.NewRouteContext()
 	chiCtx.URLParams.Add("id", collID)
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
@@ -0,0 +1,210 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"io"
+	"main/api/middleware"
+	"main/api/route"
+	"main/bootstrap"
+	"main/domain"
+	"main/internal"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contractPath = "../../contract.yaml"
+
+// routes that are not a part of the API
+var uncontractedRoutes = map[string]bool{
+	"/":        true,
+	"/metrics": true,
+}
+
+func loadTestContract(t *testing.T) *internal.Contract {
+	t.Helper()
+	contract, err := internal.LoadContract(contractPath)
+	require.NoError(t, err)
+	return contract
+}
+
+func TestContract_MatchesRoutes(t *testing.T) {
+	contract := loadTestContract(t)
+	r := chi.NewRouter()
+	route.Setup(&bootstrap.Env{}, time.Second, nil, nil, nil, nil, newTestKeySet(t), contract, r)
+
+	var routes, legacyRoutes []string
+	err := chi.Walk(r, func(method string, path string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if uncontractedRoutes[path] {
+			return nil
+		}
+		path = strings.TrimSuffix(path, "/")
+		if versioned, ok := strings.CutPrefix(path, "/v1"); ok {
+			routes = append(routes, method+" "+versioned)
+		} else if path == "/.well-known/jwks.json" {
+			routes = append(routes, method+" "+path)
+		} else {
+			legacyRoutes = append(legacyRoutes, method+" "+path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Strings(routes)
+
+	operations := contract.Operations()
+	for _, operation := range operations {
+		assert.Contains(t, routes, operation, "the contract has an operation without a route")
+	}
+	for _, r := range routes {
+		assert.Contains(t, operations, r, "the route is missing from the contract")
+	}
+	for _, r := range legacyRoutes {
+		assert.Contains(t, routes, r, "the legacy route has no /v1 version")
+	}
+}
+
+func TestContractValidationMiddleware_Request(t *testing.T) {
+	var body string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.ContractValidationMiddleware(loadTestContract(t), false)(next)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		fields []string
+	}{
+		{"valid body", http.MethodPost, "/public/login", `{"email":"a@b.c","password":"qwerty123"}`, http.StatusOK, nil},
+		{"missing fields", http.MethodPost, "/public/signup", `{"email":"a@b.c"}`, http.StatusBadRequest,
+			[]string{"username", "password"}},
+		{"wrong type", http.MethodPost, "/public/login", `{"email":"a@b.c","password":123}`, http.StatusBadRequest,
+			[]string{"password"}},
+		{"invalid query", http.MethodGet, "/collection/search?count=many&offset=0", "", http.StatusBadRequest,
+			[]string{"count"}},
+		{"missing query", http.MethodGet, "/global/getTrainingPlan?start_date=1&end_date=2", "", http.StatusBadRequest,
+			[]string{"preferred_time"}},
+		{"not in contract", http.MethodGet, "/metrics", "", http.StatusOK, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body = ""
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.status, rr.Code, rr.Body.String())
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.body, body, "the handler should get the whole body")
+				return
+			}
+
+			var resp domain.ErrorResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+			assert.Equal(t, domain.ErrInvalidData.Code, resp.Code)
+			var fields []string
+			for _, detail := range resp.Details {
+				fields = append(fields, detail.Field)
+			}
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+// countingReader counts the bytes read from the body before the handler gets it.
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.Reader.Read(p)
+	cr.n += n
+	return n, err
+}
+
+func TestContractValidationMiddleware_RequestBodyLimits(t *testing.T) {
+	var readBefore int
+	var body *countingReader
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		readBefore = body.n
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.ContractValidationMiddleware(loadTestContract(t), false)(next)
+
+	// a JSON body is limited before it is read for the validation
+	body = &countingReader{Reader: strings.NewReader(
+		`{"email":"a@b.c","password":"` + strings.Repeat("a", domain.MaxJSONBodySize) + `"}`,
+	)}
+	req := httptest.NewRequest(http.MethodPost, "/public/login", body)
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	var resp domain.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, domain.ErrInvalidBody.Code, resp.Code)
+	assert.LessOrEqual(t, body.n, domain.MaxJSONBodySize+1)
+
+	// a binary body is left to the handler and its limits
+	body = &countingReader{Reader: strings.NewReader(strings.Repeat("a", 2*domain.MaxPictureSize))}
+	req = httptest.NewRequest(http.MethodPut, "/collection/id/card/1/attachment/upload/upload-id", body)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Zero(t, readBefore)
+}
+
+func TestContractValidationMiddleware_Response(t *testing.T) {
+	contract := loadTestContract(t)
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   int
+	}{
+		{"valid", http.StatusOK, `{"keys":[{"kty":"OKP","kid":"2025-01"}]}`, http.StatusOK},
+		{"wrong type", http.StatusOK, `{"keys":"none"}`, http.StatusInternalServerError},
+		{"undocumented status", http.StatusTeapot, `{}`, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			rr := httptest.NewRecorder()
+			middleware.ContractValidationMiddleware(contract, true)(next).ServeHTTP(rr, req)
+			assert.Equal(t, tt.want, rr.Code)
+			if tt.want == http.StatusOK {
+				assert.JSONEq(t, tt.body, rr.Body.String())
+			}
+
+			// without the response validation the response is passed as is
+			rr = httptest.NewRecorder()
+			middleware.ContractValidationMiddleware(contract, false)(next).ServeHTTP(rr, req)
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
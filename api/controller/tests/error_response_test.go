@@ -0,0 +1,87 @@
+package tests_test
+
+import (
+	"encoding/json"
+	"errors"
+	"main/api/controller"
+	"main/domain"
+	"main/internal"
+	mocks "main/mocks/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteError_Localized(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		language       string
+		message        string
+	}{
+		{"", "en", "Collection is not public"},
+		{"ru-RU,ru;q=0.9,en;q=0.8", "ru", "Колода не публичная"},
+		{"de, en;q=0.5, ru;q=0.7", "ru", "Колода не публичная"},
+		{"de", "en", "Collection is not public"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/collection/id", nil)
+		req.Header.Set("Accept-Language", tt.acceptLanguage)
+		rr := httptest.NewRecorder()
+		internal.WriteError(rr, req, domain.ErrCollectionNotPublic)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, tt.language, rr.Header().Get("Content-Language"))
+		var resp domain.ErrorResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, "collection_not_public", resp.Code)
+		assert.Equal(t, tt.message, resp.Message, tt.acceptLanguage)
+	}
+}
+
+func TestWriteError_HidesInternalErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/collection/id", nil)
+	rr := httptest.NewRecorder()
+	internal.WriteError(rr, req, errors.New("connection refused: mongo:27017"))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "mongo")
+	assert.Contains(t, rr.Body.String(), `"code":"internal"`)
+}
+
+func TestWriteError_ValidationDetails(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/public/signup", strings.NewReader(`{"email":"a@b.c"}`))
+	req.Header.Set("Accept-Language", "ru")
+	rr := httptest.NewRecorder()
+	(&controller.SignupController{}).Signup(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var resp domain.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "invalid_data", resp.Code)
+	assert.Equal(t, "Некорректные данные", resp.Message)
+	require.Len(t, resp.Details, 3)
+	assert.Equal(t, domain.FieldError{Field: "username", Code: domain.FieldRequired, Message: "обязательное поле"},
+		resp.Details[0])
+}
+
+func TestCollectionController_Search_NothingFound(t *testing.T) {
+	mockCollUseCase := new(mocks.CollectionUseCase)
+	controller := &controller.CollectionController{CollectionUseCase: mockCollUseCase}
+	mockCollUseCase.On("SearchPublic", mock.Anything, "nothing", 10, 0, "likes", "", "").
+		Return([]domain.Collection{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/collection/search?name=nothing&count=10&offset=0", nil)
+	rr := httptest.NewRecorder()
+	controller.Search(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var resp domain.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, domain.ErrNothingFound.Code, resp.Code)
+}
@@ -0,0 +1,88 @@
+package tests_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"main/api/controller"
+	"main/domain"
+	"main/internal"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSController_KeyRotation(t *testing.T) {
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	user := &domain.User{ID: "user-id", Username: "user"}
+
+	before, err := internal.NewKeySet(internal.KeySetConfig{
+		SigningKeyID: "2024-old",
+		PrivateKeys:  map[string]crypto.Signer{"2024-old": oldKey},
+		LegacySecret: "access-secret",
+		AcceptLegacy: true,
+	})
+	require.NoError(t, err)
+	oldToken, _, err := internal.CreateAccessToken(user, "", before, 1)
+	require.NoError(t, err)
+
+	legacy, err := internal.NewKeySet(internal.KeySetConfig{LegacySecret: "access-secret"})
+	require.NoError(t, err)
+	legacyToken, _, err := internal.CreateAccessToken(user, "", legacy, 1)
+	require.NoError(t, err)
+
+	// the old key is only kept for verification until its tokens expire
+	after, err := internal.NewKeySet(internal.KeySetConfig{
+		SigningKeyID: "2025-new",
+		PrivateKeys:  map[string]crypto.Signer{"2025-new": newKey},
+		PublicKeys:   map[string]crypto.PublicKey{"2024-old": oldKey.Public()},
+		LegacySecret: "access-secret",
+		AcceptLegacy: true,
+	})
+	require.NoError(t, err)
+	newToken, _, err := internal.CreateAccessToken(user, "", after, 1)
+	require.NoError(t, err)
+
+	for _, token := range []string{oldToken, newToken, legacyToken} {
+		claims, err := internal.ParseAccessToken(token, after)
+		require.NoError(t, err)
+		assert.Equal(t, "user-id", claims.ID)
+	}
+
+	strict, err := internal.NewKeySet(internal.KeySetConfig{
+		SigningKeyID: "2025-new",
+		PrivateKeys:  map[string]crypto.Signer{"2025-new": newKey},
+	})
+	require.NoError(t, err)
+	_, err = internal.ParseAccessToken(legacyToken, strict)
+	require.Error(t, err)
+	_, err = internal.ParseAccessToken(oldToken, strict)
+	require.Error(t, err)
+
+	jc := &controller.JWKSController{KeySet: after}
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rr := httptest.NewRecorder()
+	jc.GetJWKS(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var jwks domain.JWKS
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&jwks))
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "2024-old", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.Equal(t, "2025-new", jwks.Keys[1].Kid)
+	assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+	assert.NotEmpty(t, jwks.Keys[1].N)
+}
@@ -0,0 +1,59 @@
+package tests_test
+
+import (
+	"main/api/middleware"
+	"main/domain"
+	"main/internal"
+	mocks "main/mocks/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJwtAuthMiddleware_Principal(t *testing.T) {
+	keySet := newTestKeySet(t)
+	mockSessionUseCase := new(mocks.SessionUseCase)
+	mockSessionUseCase.On("IsActive", mock.Anything, "session-id").Return(true, nil)
+
+	var principal domain.Principal
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ok bool
+		principal, ok = domain.PrincipalFromContext(r.Context())
+		assert.True(t, ok)
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.JwtAuthMiddleware(keySet, mockSessionUseCase, new(mocks.ApiKeyUseCase))(next)
+
+	user := &domain.User{ID: "user-id", Username: "user", Roles: []string{"moderator"}}
+	accessToken, _, err := internal.CreateAccessToken(user, "session-id", keySet, 1)
+	require.NoError(t, err)
+	// signed with the same secret as access tokens, which is a valid configuration
+	refreshToken, _, err := internal.CreateRefreshToken(user, "session-id", "token-id", "access-secret", 1)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, domain.Principal{
+		UserID:     "user-id",
+		Username:   "user",
+		Roles:      []string{"moderator"},
+		SessionID:  "session-id",
+		AuthMethod: domain.AuthMethodJwt,
+	}, principal)
+	assert.True(t, principal.HasScope(domain.ScopeCollectionsWrite))
+
+	req = httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Authorization", "Bearer "+refreshToken)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
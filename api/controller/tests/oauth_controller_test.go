@@ -0,0 +1,204 @@
+package tests_test
+
+import (
+	"context"
+	"encoding/json"
+	"main/api/controller"
+	"main/bootstrap"
+	"main/database"
+	"main/domain"
+	"main/internal"
+	mocks "main/mocks/domain"
+	"main/oauth"
+	"main/usecase"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// newMockOIDCProvider serves the token and userinfo endpoints of a provider
+// that only accepts the code together with the verifier for challenge.
+func newMockOIDCProvider(t *testing.T, challenge *string, email string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "auth-code" ||
+			internal.PKCEChallenge(r.FormValue("code_verifier")) != *challenge {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"provider-access-token","token_type":"Bearer"}`))
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer provider-access-token" {
+			http.Error(w, "", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"sub":            "provider-subject",
+			"email":          email,
+			"email_verified": true,
+			"name":           "Provider User",
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func withProvider(req *http.Request, provider string) *http.Request {
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("provider", provider)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+func TestLoginController_OAuth_CreatesUserAgainstMockProvider(t *testing.T) {
+	keySet := newTestKeySet(t)
+	var challenge string
+	email := "provider@example.com"
+	server := newMockOIDCProvider(t, &challenge, email)
+	providers := oauth.Providers{
+		"google": oauth.NewProvider(oauth.Config{
+			ClientID:    "client-id",
+			RedirectURL: "tprep://oauth/callback",
+			AuthURL:     server.URL + "/authorize",
+			TokenURL:    server.URL + "/token",
+			UserInfoURL: server.URL + "/userinfo",
+			Scopes:      []string{"openid", "email"},
+			Claims: oauth.Claims{
+				Subject:       "sub",
+				Email:         "email",
+				EmailVerified: "email_verified",
+				Name:          "name",
+			},
+		}),
+	}
+
+	mockUserRepository := new(mocks.UserRepository)
+	mockIdentityRepository := new(mocks.IdentityRepository)
+	mockStateRepository := new(mocks.OAuthStateRepository)
+	mockLoginUseCase := new(mocks.LoginUseCase)
+	controller := &controller.LoginController{
+		LoginUseCase: mockLoginUseCase,
+		OAuthUseCase: usecase.NewOAuthUseCase(
+			mockUserRepository, mockIdentityRepository, mockStateRepository, providers, time.Second,
+		),
+		KeySet: keySet,
+		Env: &bootstrap.Env{
+			OAuthStateExpiryMinute: 10,
+			RefreshTokenSecret:     "refresh-secret",
+			AccessTokenExpiryHour:  1,
+			RefreshTokenExpiryHour: 24,
+		},
+	}
+
+	var stored domain.OAuthState
+	mockStateRepository.On("Create", mock.Anything, mock.AnythingOfType("*domain.OAuthState")).
+		Run(func(args mock.Arguments) { stored = *args.Get(1).(*domain.OAuthState) }).
+		Return("", nil)
+
+	req := withProvider(httptest.NewRequest(http.MethodPost, "/public/oauth/google/start", nil), "google")
+	rr := httptest.NewRecorder()
+	controller.OAuthStart(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var start domain.OAuthStartResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&start))
+	authURL, err := url.Parse(start.AuthorizationURL)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", authURL.Query().Get("code_challenge_method"))
+	assert.Equal(t, start.State, authURL.Query().Get("state"))
+	challenge = authURL.Query().Get("code_challenge")
+
+	mockStateRepository.On("GetByID", mock.Anything, internal.HashSecretToken(start.State)).Return(stored, nil)
+	mockStateRepository.On("Update", mock.Anything, mock.Anything, mock.Anything).
+		Return(database.UpdateResult{MatchedCount: 1}, nil)
+	mockIdentityRepository.On("GetByID", mock.Anything, "google:provider-subject").
+		Return(domain.Identity{}, mongo.ErrNoDocuments)
+	mockUserRepository.On("GetByEmail", mock.Anything, email).Return(domain.User{}, mongo.ErrNoDocuments)
+	mockUserRepository.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Email == email && u.EmailVerified && u.Username == "Provider User"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.User).ID = "new-user-id"
+	}).Return("new-user-id", nil)
+	mockIdentityRepository.On("Create", mock.Anything, mock.MatchedBy(func(i *domain.Identity) bool {
+		return i.ID == "google:provider-subject" && i.UserID == "new-user-id"
+	})).Return("google:provider-subject", nil)
+	mockLoginUseCase.On("CreateRefreshToken", mock.Anything, mock.Anything, mock.Anything, "refresh-secret", 24).
+		Return("refresh-token", time.Now().Add(24*time.Hour), nil)
+	mockLoginUseCase.On("CreateAccessToken", mock.Anything, mock.Anything, keySet, 1).
+		Return("access-token", time.Now().Add(time.Hour), nil)
+
+	body := `{"code":"auth-code","state":"` + start.State + `"}`
+	req = httptest.NewRequest(http.MethodPost, "/public/oauth/google/callback", strings.NewReader(body))
+	req = withProvider(req, "google")
+	rr = httptest.NewRecorder()
+	controller.OAuthCallback(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var resp domain.LoginResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "access-token", resp.AccessToken)
+	mockUserRepository.AssertExpectations(t)
+	mockIdentityRepository.AssertExpectations(t)
+	mockStateRepository.AssertExpectations(t)
+}
+
+func TestLoginController_OAuthCallback_WrongVerifierRejected(t *testing.T) {
+	challenge := internal.PKCEChallenge("another-verifier")
+	server := newMockOIDCProvider(t, &challenge, "provider@example.com")
+	providers := oauth.Providers{
+		"google": oauth.NewProvider(oauth.Config{
+			TokenURL:    server.URL + "/token",
+			UserInfoURL: server.URL + "/userinfo",
+			Claims:      oauth.Claims{Subject: "sub"},
+		}),
+	}
+
+	mockStateRepository := new(mocks.OAuthStateRepository)
+	controller := &controller.LoginController{
+		OAuthUseCase: usecase.NewOAuthUseCase(
+			new(mocks.UserRepository), new(mocks.IdentityRepository), mockStateRepository, providers, time.Second,
+		),
+	}
+
+	state := domain.OAuthState{
+		ID:           internal.HashSecretToken("state"),
+		Provider:     "google",
+		CodeVerifier: "stolen-code-verifier",
+		ExpiresAt:    time.Now().Add(time.Minute).Unix(),
+	}
+	mockStateRepository.On("GetByID", mock.Anything, state.ID).Return(state, nil)
+	mockStateRepository.On("Update", mock.Anything, mock.Anything, mock.Anything).
+		Return(database.UpdateResult{MatchedCount: 1}, nil)
+
+	body := `{"code":"auth-code","state":"state"}`
+	req := httptest.NewRequest(http.MethodPost, "/public/oauth/google/callback", strings.NewReader(body))
+	req = withProvider(req, "google")
+	rr := httptest.NewRecorder()
+	controller.OAuthCallback(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	mockStateRepository.AssertExpectations(t)
+}
+
+func newTestKeySet(t *testing.T) domain.KeySet {
+	t.Helper()
+
+	keySet, err := internal.NewKeySet(internal.KeySetConfig{LegacySecret: "access-secret"})
+	require.NoError(t, err)
+	return keySet
+}
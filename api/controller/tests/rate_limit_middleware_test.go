@@ -0,0 +1,61 @@
+package tests_test
+
+import (
+	"main/api/middleware"
+	"main/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitMiddleware_TokenBucket(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.RateLimitMiddleware("search", 60, 2)(next)
+
+	request := func(ip string, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/collection/search", nil)
+		req.RemoteAddr = ip + ":1234"
+		if userID != "" {
+			req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := request("10.0.0.1", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Remaining"))
+
+	rr = request("10.0.0.1", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get("X-RateLimit-Remaining"))
+
+	rr = request("10.0.0.1", "")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+	assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Reset"))
+
+	// other clients have their own buckets
+	assert.Equal(t, http.StatusOK, request("10.0.0.2", "").Code)
+	assert.Equal(t, http.StatusOK, request("10.0.0.1", "user-id").Code)
+}
+
+func TestRateLimitMiddleware_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.RateLimitMiddleware("upload", 0, 0)(next)
+
+	for range 10 {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/user/picture", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+	}
+}
@@ -0,0 +1,46 @@
+package tests_test
+
+import (
+	"main/api/middleware"
+	"main/internal"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRealIPMiddleware(t *testing.T) {
+	trusted, err := internal.ParseTrustedProxies("10.0.0.1, 192.168.0.0/16")
+	require.NoError(t, err)
+
+	var ip string
+	handler := middleware.RealIPMiddleware(trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ip = internal.ClientIP(r)
+	}))
+	request := func(remoteAddr string, headers map[string]string) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		return ip
+	}
+
+	// the headers of an untrusted client are ignored
+	assert.Equal(t, "203.0.113.7", request("203.0.113.7:1234", map[string]string{"X-Forwarded-For": "1.1.1.1"}))
+	assert.Equal(t, "203.0.113.7", request("203.0.113.7:1234", map[string]string{"X-Real-Ip": "1.1.1.1"}))
+
+	// the client is the last address before the trusted proxies
+	assert.Equal(t, "198.51.100.2", request("10.0.0.1:1234", map[string]string{
+		"X-Forwarded-For": "1.1.1.1, 198.51.100.2, 192.168.1.5",
+	}))
+	assert.Equal(t, "198.51.100.2", request("192.168.3.4:1234", map[string]string{"X-Real-Ip": "198.51.100.2"}))
+	assert.Equal(t, "10.0.0.1", request("10.0.0.1:1234", map[string]string{"X-Forwarded-For": "not an address"}))
+	assert.Equal(t, "10.0.0.1", request("10.0.0.1:1234", nil))
+
+	_, err = internal.ParseTrustedProxies("10.0.0.1, proxy")
+	assert.Error(t, err)
+}
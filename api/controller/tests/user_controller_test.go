@@ -9,12 +9,16 @@ import (
 	"main/bootstrap"
 	"main/domain"
 	mocks "main/mocks/domain"
+	"main/repository"
+	"main/usecase"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/mailru/easyjson"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -49,8 +53,7 @@ func TestUserController_Get_OwnProfile(t *testing.T) {
 	}
 
 	req := httptest.NewRequest(http.MethodGet, "/user", nil)
-	//nolint:revive,staticcheck // uselless
-	ctx := context.WithValue(req.Context(), "x-user-id", userID)
+	ctx := domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID})
 	req = req.WithContext(ctx)
 
 	rr := httptest.NewRecorder()
@@ -90,8 +93,7 @@ func TestUserController_Get_InvalidUserID(t *testing.T) {
 		HistoryUseCase:    mockHistoryUseCase,
 	}
 	req := httptest.NewRequest(http.MethodGet, "/user", nil)
-	//nolint:revive,staticcheck // uselless
-	ctx := context.WithValue(req.Context(), "x-user-id", invalidUserID)
+	ctx := domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: invalidUserID})
 	req = req.WithContext(ctx)
 
 	rr := httptest.NewRecorder()
@@ -104,15 +106,19 @@ func TestUserController_Get_InvalidUserID(t *testing.T) {
 }
 
 func TestUserController_SignUp_Success(t *testing.T) {
+	keySet := newTestKeySet(t)
 	mockUseCase := new(mocks.SignupUseCase)
+	mockVerificationUseCase := new(mocks.VerificationUseCase)
 
 	controller := &controller.SignupController{
-		SignupUseCase: mockUseCase,
+		SignupUseCase:       mockUseCase,
+		VerificationUseCase: mockVerificationUseCase,
+		KeySet:              keySet,
 		Env: &bootstrap.Env{
-			AccessTokenSecret:      "access-secret",
-			RefreshTokenSecret:     "refresh-secret",
-			AccessTokenExpiryHour:  1,
-			RefreshTokenExpiryHour: 24,
+			RefreshTokenSecret:          "refresh-secret",
+			AccessTokenExpiryHour:       1,
+			RefreshTokenExpiryHour:      24,
+			VerificationTokenExpiryHour: 24,
 		},
 	}
 
@@ -129,11 +135,14 @@ func TestUserController_SignUp_Success(t *testing.T) {
 	mockUseCase.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).
 		Return("new-user-id", nil)
 
-	mockUseCase.On("CreateAccessToken", mock.AnythingOfType("*domain.User"), "access-secret", 1).
+	mockVerificationUseCase.On("SendVerification", mock.Anything, "new-user-id", 24).Return(nil)
+
+	mockUseCase.On("CreateAccessToken", mock.AnythingOfType("*domain.User"), mock.Anything, keySet, 1).
 		Return("access-token", time.Now().Add(time.Hour), nil)
 
-	mockUseCase.On("CreateRefreshToken", mock.AnythingOfType("*domain.User"), "refresh-secret", 24).
-		Return("refresh-token", time.Now().Add(24*time.Hour), nil)
+	mockUseCase.On(
+		"CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.User"), mock.Anything, "refresh-secret", 24,
+	).Return("refresh-token", time.Now().Add(24*time.Hour), nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/public/signup", strings.NewReader(string(bodyJSON)))
 	rr := httptest.NewRecorder()
@@ -155,6 +164,7 @@ func TestUserController_SignUp_Success(t *testing.T) {
 	assert.Equal(t, "refresh-token", resp.RefreshToken)
 
 	mockUseCase.AssertExpectations(t)
+	mockVerificationUseCase.AssertExpectations(t)
 }
 
 func TestUserController_SignUp_InvalidJSON(t *testing.T) {
@@ -186,6 +196,19 @@ func TestUserController_SignUp_MissingField(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
 }
 
+func TestUserController_SignUp_InvalidEmail(t *testing.T) {
+	controller := &controller.SignupController{}
+	for _, email := range []string{"john", "john@", "John <john@email.com>", " john@email.com"} {
+		bodyJSON, _ := easyjson.Marshal(domain.SignupRequest{Username: "theUser", Email: email, Password: "qwerty123"})
+		req := httptest.NewRequest(http.MethodPost, "/public/signup", strings.NewReader(string(bodyJSON)))
+		rr := httptest.NewRecorder()
+		controller.Signup(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, email)
+		assert.Contains(t, rr.Body.String(), `"field":"email"`, email)
+	}
+}
+
 func TestUserController_SignUp_UserAlreadyExists(t *testing.T) {
 	mockUseCase := new(mocks.SignupUseCase)
 	controller := &controller.SignupController{
@@ -203,16 +226,18 @@ func TestUserController_SignUp_UserAlreadyExists(t *testing.T) {
 	controller.Signup(rr, req)
 	res := rr.Result()
 	defer res.Body.Close()
-	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
-	assert.Contains(t, rr.Body.String(), "User with this email already exists")
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+	assert.Contains(t, rr.Body.String(), `"code":"user_exists"`)
 }
 
 func TestUserController_Login_Success(t *testing.T) {
+	keySet := newTestKeySet(t)
 	mockUseCase := new(mocks.LoginUseCase)
 	controller := &controller.LoginController{
-		LoginUseCase: mockUseCase,
+		LoginUseCase:        mockUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{}),
+		KeySet:              keySet,
 		Env: &bootstrap.Env{
-			AccessTokenSecret:      "access-secret",
 			RefreshTokenSecret:     "refresh-secret",
 			AccessTokenExpiryHour:  1,
 			RefreshTokenExpiryHour: 24,
@@ -234,9 +259,9 @@ func TestUserController_Login_Success(t *testing.T) {
 
 	mockUseCase.On("GetUserByEmail", mock.Anything, reqBody.Email).
 		Return(user, nil)
-	mockUseCase.On("CreateAccessToken", &user, "access-secret", 1).
+	mockUseCase.On("CreateAccessToken", &user, mock.Anything, keySet, 1).
 		Return("access-token", time.Now().Add(time.Hour), nil)
-	mockUseCase.On("CreateRefreshToken", &user, "refresh-secret", 24).
+	mockUseCase.On("CreateRefreshToken", mock.Anything, &user, mock.Anything, "refresh-secret", 24).
 		Return("refresh-token", time.Now().Add(24*time.Hour), nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/public/login", strings.NewReader(string(bodyJSON)))
@@ -273,7 +298,8 @@ func TestUserController_Login_InvalidJSON(t *testing.T) {
 func TestUserController_Login_UserNotFound(t *testing.T) {
 	mockUseCase := new(mocks.LoginUseCase)
 	controller := &controller.LoginController{
-		LoginUseCase: mockUseCase,
+		LoginUseCase:        mockUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{}),
 	}
 	reqBody := domain.LoginRequest{
 		Email:    "notfound@example.com",
@@ -294,7 +320,8 @@ func TestUserController_Login_UserNotFound(t *testing.T) {
 func TestUserController_Login_WrongPassword(t *testing.T) {
 	mockUseCase := new(mocks.LoginUseCase)
 	controller := &controller.LoginController{
-		LoginUseCase: mockUseCase,
+		LoginUseCase:        mockUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{}),
 	}
 
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.DefaultCost)
@@ -318,6 +345,150 @@ func TestUserController_Login_WrongPassword(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
 }
 
+func newTestLoginAttemptUseCase(policy domain.LoginAttemptPolicy) domain.LoginAttemptUseCase {
+	mockLoginAuditRepository := new(mocks.LoginAuditRepository)
+	mockLoginAuditRepository.On("Create", mock.Anything, mock.Anything).Return("", nil)
+	return usecase.NewLoginAttemptUseCase(
+		repository.NewMemoryLoginAttemptRepository(), mockLoginAuditRepository, policy, time.Second,
+	)
+}
+
+func TestUserController_Login_BruteForce(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	user := domain.User{
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+	}
+	mockUseCase := new(mocks.LoginUseCase)
+	mockUseCase.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+
+	login := func(controller *controller.LoginController) *http.Response {
+		bodyJSON, _ := easyjson.Marshal(domain.LoginRequest{Email: user.Email, Password: "wrong-password"})
+		req := httptest.NewRequest(http.MethodPost, "/public/login", strings.NewReader(string(bodyJSON)))
+		rr := httptest.NewRecorder()
+		controller.Login(rr, req)
+		return rr.Result()
+	}
+
+	backoff := &controller.LoginController{
+		LoginUseCase: mockUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{
+			FreeAttempts:   1,
+			IPFreeAttempts: 100,
+			BackoffBase:    time.Minute,
+			BackoffMax:     time.Hour,
+			Window:         time.Hour,
+		}),
+	}
+	for _, status := range []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusTooManyRequests} {
+		res := login(backoff)
+		res.Body.Close()
+		assert.Equal(t, status, res.StatusCode)
+		if status == http.StatusTooManyRequests {
+			assert.Equal(t, "60", res.Header.Get("Retry-After"))
+		}
+	}
+
+	lockout := &controller.LoginController{
+		LoginUseCase: mockUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{
+			FreeAttempts:     10,
+			IPFreeAttempts:   100,
+			BackoffBase:      time.Minute,
+			BackoffMax:       time.Hour,
+			LockoutThreshold: 2,
+			LockoutDuration:  15 * time.Minute,
+			Window:           time.Hour,
+		}),
+	}
+	for _, status := range []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusLocked} {
+		res := login(lockout)
+		res.Body.Close()
+		assert.Equal(t, status, res.StatusCode)
+		if status == http.StatusLocked {
+			assert.Equal(t, "900", res.Header.Get("Retry-After"))
+		}
+	}
+}
+
+func TestUserController_Login_ConcurrentAttempts(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	user := domain.User{
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+	}
+	mockUseCase := new(mocks.LoginUseCase)
+	mockUseCase.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	controller := &controller.LoginController{
+		LoginUseCase: mockUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{
+			FreeAttempts:   1,
+			IPFreeAttempts: 100,
+			BackoffBase:    time.Minute,
+			BackoffMax:     time.Hour,
+			Window:         time.Hour,
+		}),
+	}
+
+	// the attempts are reserved before the password is compared, so only the allowed ones get to it
+	statuses := make([]int, 10)
+	var wg sync.WaitGroup
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bodyJSON, _ := easyjson.Marshal(domain.LoginRequest{Email: user.Email, Password: "wrong-password"})
+			req := httptest.NewRequest(http.MethodPost, "/public/login", strings.NewReader(string(bodyJSON)))
+			rr := httptest.NewRecorder()
+			controller.Login(rr, req)
+			statuses[i] = rr.Code
+		}()
+	}
+	wg.Wait()
+
+	counts := map[int]int{}
+	for _, status := range statuses {
+		counts[status]++
+	}
+	assert.Equal(t, map[int]int{http.StatusBadRequest: 2, http.StatusTooManyRequests: 8}, counts)
+}
+
+func TestUserController_Login_AuditFailureStillCounted(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	user := domain.User{
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+	}
+	mockUseCase := new(mocks.LoginUseCase)
+	mockUseCase.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockLoginAuditRepository := new(mocks.LoginAuditRepository)
+	mockLoginAuditRepository.On("Create", mock.Anything, mock.Anything).Return("", errors.New("audit is down"))
+
+	controller := &controller.LoginController{
+		LoginUseCase: mockUseCase,
+		LoginAttemptUseCase: usecase.NewLoginAttemptUseCase(
+			repository.NewMemoryLoginAttemptRepository(),
+			mockLoginAuditRepository,
+			domain.LoginAttemptPolicy{
+				FreeAttempts:   1,
+				IPFreeAttempts: 100,
+				BackoffBase:    time.Minute,
+				BackoffMax:     time.Hour,
+				Window:         time.Hour,
+			},
+			time.Second,
+		),
+	}
+	for _, status := range []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusTooManyRequests} {
+		bodyJSON, _ := easyjson.Marshal(domain.LoginRequest{Email: user.Email, Password: "wrong-password"})
+		req := httptest.NewRequest(http.MethodPost, "/public/login", strings.NewReader(string(bodyJSON)))
+		rr := httptest.NewRecorder()
+		controller.Login(rr, req)
+		assert.Equal(t, status, rr.Code)
+	}
+	mockLoginAuditRepository.AssertNumberOfCalls(t, "Create", 2)
+}
+
 func TestUserController_Update_Success(t *testing.T) {
 	mockUseCase := new(mocks.UserUseCase)
 	controller := &controller.UserController{
@@ -329,11 +500,10 @@ func TestUserController_Update_Success(t *testing.T) {
 		Email:    "UPDemail@example.com",
 	}
 	mockUseCase.On("PutByID", mock.Anything, userID, &updatedUser).
-		Return(nil)
+		Return(false, nil)
 	bodyJSON, _ := easyjson.Marshal(updatedUser)
 	req := httptest.NewRequest(http.MethodPut, "/user/{id}", strings.NewReader(string(bodyJSON)))
-	//nolint:revive,staticcheck // uselless
-	ctx := context.WithValue(req.Context(), "x-user-id", userID)
+	ctx := domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID})
 	req = req.WithContext(ctx)
 	rr := httptest.NewRecorder()
 	controller.Update(rr, req)
@@ -387,3 +557,493 @@ func TestUserController_Update_EmptyFields(t *testing.T) {
 		})
 	}
 }
+
+func TestUserController_RefreshToken_Success(t *testing.T) {
+	keySet := newTestKeySet(t)
+	mockUseCase := new(mocks.RefreshTokenUseCase)
+	controller := &controller.RefreshTokenController{
+		RefreshTokenUseCase: mockUseCase,
+		KeySet:              keySet,
+		Env: &bootstrap.Env{
+			RefreshTokenSecret:     "refresh-secret",
+			AccessTokenExpiryHour:  1,
+			RefreshTokenExpiryHour: 24,
+		},
+	}
+
+	user := domain.User{ID: "user-id"}
+	claims := &domain.JwtCustomRefreshClaims{ID: user.ID, SessionID: "session-id", TokenID: "token-id"}
+	bodyJSON, _ := easyjson.Marshal(domain.RefreshTokenRequest{RefreshToken: "old-refresh-token"})
+
+	mockUseCase.On("ParseRefreshToken", "old-refresh-token", "refresh-secret").Return(claims, nil)
+	mockUseCase.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockUseCase.On("RotateRefreshToken", mock.Anything, &user, claims, "refresh-secret", 24).
+		Return("new-refresh-token", time.Now().Add(24*time.Hour), nil)
+	mockUseCase.On("CreateAccessToken", &user, "session-id", keySet, 1).
+		Return("access-token", time.Now().Add(time.Hour), nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/public/refreshToken", strings.NewReader(string(bodyJSON)))
+	rr := httptest.NewRecorder()
+	controller.RefreshToken(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp domain.RefreshTokenResponse
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", resp.AccessToken)
+	assert.Equal(t, "new-refresh-token", resp.RefreshToken)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserController_RefreshToken_Reused(t *testing.T) {
+	mockUseCase := new(mocks.RefreshTokenUseCase)
+	controller := &controller.RefreshTokenController{
+		RefreshTokenUseCase: mockUseCase,
+		Env: &bootstrap.Env{
+			RefreshTokenSecret:     "refresh-secret",
+			RefreshTokenExpiryHour: 24,
+		},
+	}
+
+	user := domain.User{ID: "user-id"}
+	claims := &domain.JwtCustomRefreshClaims{ID: user.ID, SessionID: "session-id", TokenID: "rotated-token-id"}
+	bodyJSON, _ := easyjson.Marshal(domain.RefreshTokenRequest{RefreshToken: "rotated-refresh-token"})
+
+	mockUseCase.On("ParseRefreshToken", "rotated-refresh-token", "refresh-secret").Return(claims, nil)
+	mockUseCase.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockUseCase.On("RotateRefreshToken", mock.Anything, &user, claims, "refresh-secret", 24).
+		Return("", time.Time{}, domain.ErrRefreshTokenReused)
+
+	req := httptest.NewRequest(http.MethodPost, "/public/refreshToken", strings.NewReader(string(bodyJSON)))
+	rr := httptest.NewRecorder()
+	controller.RefreshToken(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.Contains(t, rr.Body.String(), "reuse detected")
+	mockUseCase.AssertNotCalled(t, "CreateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserController_Logout_Success(t *testing.T) {
+	mockUseCase := new(mocks.SessionUseCase)
+	controller := &controller.LogoutController{
+		SessionUseCase: mockUseCase,
+		Env:            &bootstrap.Env{RefreshTokenSecret: "refresh-secret"},
+	}
+
+	userID := "user-id"
+	claims := &domain.JwtCustomRefreshClaims{ID: userID, SessionID: "session-id", TokenID: "token-id"}
+	bodyJSON, _ := easyjson.Marshal(domain.LogoutRequest{RefreshToken: "refresh-token"})
+
+	mockUseCase.On("ParseRefreshToken", "refresh-token", "refresh-secret").Return(claims, nil)
+	mockUseCase.On("Revoke", mock.Anything, userID, "session-id").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", strings.NewReader(string(bodyJSON)))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
+	rr := httptest.NewRecorder()
+	controller.Logout(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserController_LogoutAll_Success(t *testing.T) {
+	mockUseCase := new(mocks.SessionUseCase)
+	controller := &controller.LogoutController{
+		SessionUseCase: mockUseCase,
+	}
+
+	userID := "user-id"
+	mockUseCase.On("RevokeAll", mock.Anything, userID).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout-all", nil)
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
+	rr := httptest.NewRecorder()
+	controller.LogoutAll(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserController_GetSessions_MarksCurrent(t *testing.T) {
+	mockSessionUseCase := new(mocks.SessionUseCase)
+	controller := &controller.UserController{
+		SessionUseCase: mockSessionUseCase,
+	}
+
+	userID := "user-id"
+	sessions := []domain.Session{
+		{ID: "phone-session", UserID: userID, DeviceName: "Pixel 8", IP: "10.0.0.1"},
+		{ID: "laptop-session", UserID: userID, UserAgent: "Mozilla/5.0"},
+	}
+	mockSessionUseCase.On("GetActiveByUserID", mock.Anything, userID).Return(sessions, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/user/sessions", nil)
+	ctx := domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID, SessionID: "laptop-session"})
+	req = req.WithContext(ctx)
+	rr := httptest.NewRecorder()
+	controller.GetSessions(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp domain.SessionInfoArray
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.Count)
+	assert.Equal(t, "Pixel 8", resp.Items[0].DeviceName)
+	assert.False(t, resp.Items[0].Current)
+	assert.True(t, resp.Items[1].Current)
+	mockSessionUseCase.AssertExpectations(t)
+}
+
+func TestUserController_RevokeSession_NotFound(t *testing.T) {
+	mockSessionUseCase := new(mocks.SessionUseCase)
+	controller := &controller.UserController{
+		SessionUseCase: mockSessionUseCase,
+	}
+
+	userID := "user-id"
+	mockSessionUseCase.On("Revoke", mock.Anything, userID, "foreign-session").
+		Return(domain.ErrSessionNotFound)
+
+	req := httptest.NewRequest(http.MethodDelete, "/user/sessions/foreign-session", nil)
+	chiCtx := chi.NewRouteContext()
+	chiCtx.URLParams.Add("id", "foreign-session")
+	ctx := domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID})
+	req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, chiCtx))
+	rr := httptest.NewRecorder()
+	controller.RevokeSession(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	mockSessionUseCase.AssertExpectations(t)
+}
+
+func TestUserController_ChangePassword_Success(t *testing.T) {
+	mockPasswordUseCase := new(mocks.PasswordUseCase)
+	mockSessionUseCase := new(mocks.SessionUseCase)
+	controller := &controller.UserController{
+		PasswordUseCase: mockPasswordUseCase,
+		SessionUseCase:  mockSessionUseCase,
+	}
+
+	userID := "user-id"
+	hashed, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	mockPasswordUseCase.On("GetUserByID", mock.Anything, userID).
+		Return(domain.User{ID: userID, Password: string(hashed)}, nil)
+	mockPasswordUseCase.On("UpdatePassword", mock.Anything, userID, mock.MatchedBy(func(h string) bool {
+		return bcrypt.CompareHashAndPassword([]byte(h), []byte("new-password")) == nil
+	})).Return(nil)
+	// the other sessions are revoked, the current one is kept
+	mockSessionUseCase.On("RevokeOthers", mock.Anything, userID, "current-session").Return(nil)
+
+	body := `{"old_password":"old-password","new_password":"new-password"}`
+	req := httptest.NewRequest(http.MethodPut, "/user/password", strings.NewReader(body))
+	principal := domain.Principal{UserID: userID, SessionID: "current-session"}
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), principal))
+	rr := httptest.NewRecorder()
+	controller.ChangePassword(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	mockPasswordUseCase.AssertExpectations(t)
+	mockSessionUseCase.AssertExpectations(t)
+}
+
+func TestUserController_ChangePassword_WrongOldPassword(t *testing.T) {
+	mockPasswordUseCase := new(mocks.PasswordUseCase)
+	controller := &controller.UserController{
+		PasswordUseCase: mockPasswordUseCase,
+	}
+
+	userID := "user-id"
+	hashed, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+	mockPasswordUseCase.On("GetUserByID", mock.Anything, userID).
+		Return(domain.User{ID: userID, Password: string(hashed)}, nil)
+
+	body := `{"old_password":"wrong","new_password":"new-password"}`
+	req := httptest.NewRequest(http.MethodPut, "/user/password", strings.NewReader(body))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
+	rr := httptest.NewRecorder()
+	controller.ChangePassword(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	mockPasswordUseCase.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPasswordController_ResetPassword_InvalidToken(t *testing.T) {
+	mockPasswordUseCase := new(mocks.PasswordUseCase)
+	controller := &controller.PasswordController{
+		PasswordUseCase: mockPasswordUseCase,
+		Env:             &bootstrap.Env{},
+	}
+
+	mockPasswordUseCase.On("Reset", mock.Anything, "stale-token", mock.Anything).
+		Return(domain.ErrInvalidOrExpiredToken)
+
+	body := `{"token":"stale-token","new_password":"new-password"}`
+	req := httptest.NewRequest(http.MethodPost, "/public/password/reset", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	controller.ResetPassword(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	mockPasswordUseCase.AssertExpectations(t)
+}
+
+func TestPasswordController_ForgotPassword_InvalidEmail(t *testing.T) {
+	mockPasswordUseCase := new(mocks.PasswordUseCase)
+	controller := &controller.PasswordController{
+		PasswordUseCase: mockPasswordUseCase,
+		Env:             &bootstrap.Env{},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/public/password/forgot", strings.NewReader(`{"email":"john"}`))
+	rr := httptest.NewRecorder()
+	controller.ForgotPassword(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockPasswordUseCase.AssertNotCalled(t, "RequestReset", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserController_Update_EmailChanged(t *testing.T) {
+	mockUseCase := new(mocks.UserUseCase)
+	mockVerificationUseCase := new(mocks.VerificationUseCase)
+	controller := &controller.UserController{
+		UserUseCase:         mockUseCase,
+		VerificationUseCase: mockVerificationUseCase,
+		Env:                 &bootstrap.Env{VerificationTokenExpiryHour: 24},
+	}
+
+	userID := "user-id"
+	updatedUser := domain.User{
+		Username: "username",
+		Email:    "new@example.com",
+	}
+	mockUseCase.On("PutByID", mock.Anything, userID, &updatedUser).Return(true, nil)
+	mockVerificationUseCase.On("SendVerification", mock.Anything, userID, 24).Return(nil)
+
+	bodyJSON, _ := easyjson.Marshal(updatedUser)
+	req := httptest.NewRequest(http.MethodPut, "/user", strings.NewReader(string(bodyJSON)))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
+	rr := httptest.NewRecorder()
+	controller.Update(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	mockUseCase.AssertExpectations(t)
+	mockVerificationUseCase.AssertExpectations(t)
+}
+
+func TestUserController_ResendVerification_Throttled(t *testing.T) {
+	mockVerificationUseCase := new(mocks.VerificationUseCase)
+	controller := &controller.UserController{
+		VerificationUseCase: mockVerificationUseCase,
+		Env: &bootstrap.Env{
+			VerificationTokenExpiryHour: 24,
+			VerificationCooldownSecond:  60,
+		},
+	}
+
+	userID := "user-id"
+	mockVerificationUseCase.On("ResendVerification", mock.Anything, userID, 24, 60).
+		Return(domain.ErrVerificationSent.WithRetryAfter(60 * time.Second))
+
+	req := httptest.NewRequest(http.MethodPost, "/user/verify-email", nil)
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
+	rr := httptest.NewRecorder()
+	controller.ResendVerification(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "60", res.Header.Get("Retry-After"))
+	mockVerificationUseCase.AssertExpectations(t)
+}
+
+func TestVerificationController_VerifyEmail_Success(t *testing.T) {
+	mockVerificationUseCase := new(mocks.VerificationUseCase)
+	controller := &controller.VerificationController{
+		VerificationUseCase: mockVerificationUseCase,
+	}
+
+	mockVerificationUseCase.On("Verify", mock.Anything, "mail-token").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/public/verify-email", strings.NewReader(`{"token":"mail-token"}`))
+	rr := httptest.NewRecorder()
+	controller.VerifyEmail(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	mockVerificationUseCase.AssertExpectations(t)
+}
+
+func TestUserController_Login_TwoFactorChallenge(t *testing.T) {
+	mockUseCase := new(mocks.LoginUseCase)
+	mockTwoFactorUseCase := new(mocks.TwoFactorUseCase)
+	controller := &controller.LoginController{
+		LoginUseCase:        mockUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{}),
+		TwoFactorUseCase:    mockTwoFactorUseCase,
+		Env:                 &bootstrap.Env{MFAChallengeExpiryMinute: 5},
+	}
+
+	password := "qwerty123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	user := domain.User{
+		ID:        "user-id",
+		Email:     "test@example.com",
+		Password:  string(hashedPassword),
+		TwoFactor: domain.TwoFactor{Enabled: true},
+	}
+	mockUseCase.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockTwoFactorUseCase.On("CreateChallenge", mock.Anything, &user, 5).Return("mfa-token", nil)
+
+	bodyJSON, _ := easyjson.Marshal(domain.LoginRequest{Email: user.Email, Password: password})
+	req := httptest.NewRequest(http.MethodPost, "/public/login", strings.NewReader(string(bodyJSON)))
+	rr := httptest.NewRecorder()
+	controller.Login(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp domain.MFAChallengeResponse
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	require.NoError(t, err)
+	assert.True(t, resp.MFARequired)
+	assert.Equal(t, "mfa-token", resp.MFAToken)
+	mockUseCase.AssertNotCalled(t, "CreateRefreshToken",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockTwoFactorUseCase.AssertExpectations(t)
+}
+
+func TestUserController_LoginMFA_Success(t *testing.T) {
+	keySet := newTestKeySet(t)
+	mockUseCase := new(mocks.LoginUseCase)
+	mockTwoFactorUseCase := new(mocks.TwoFactorUseCase)
+	controller := &controller.LoginController{
+		LoginUseCase:     mockUseCase,
+		TwoFactorUseCase: mockTwoFactorUseCase,
+		KeySet:           keySet,
+		Env: &bootstrap.Env{
+			RefreshTokenSecret:     "refresh-secret",
+			AccessTokenExpiryHour:  1,
+			RefreshTokenExpiryHour: 24,
+		},
+	}
+
+	user := domain.User{ID: "user-id", TwoFactor: domain.TwoFactor{Enabled: true}}
+	mockTwoFactorUseCase.On("VerifyChallenge", mock.Anything, "mfa-token", "123456").Return(user, nil)
+	mockUseCase.On("CreateRefreshToken", mock.Anything, &user, mock.Anything, "refresh-secret", 24).
+		Return("refresh-token", time.Now().Add(24*time.Hour), nil)
+	mockUseCase.On("CreateAccessToken", &user, mock.Anything, keySet, 1).
+		Return("access-token", time.Now().Add(time.Hour), nil)
+
+	body := `{"mfa_token":"mfa-token","code":"123456"}`
+	req := httptest.NewRequest(http.MethodPost, "/public/login/mfa", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	controller.LoginMFA(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp domain.LoginResponse
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", resp.AccessToken)
+	mockUseCase.AssertExpectations(t)
+	mockTwoFactorUseCase.AssertExpectations(t)
+}
+
+func TestUserController_LoginMFA_InvalidCode(t *testing.T) {
+	mockTwoFactorUseCase := new(mocks.TwoFactorUseCase)
+	controller := &controller.LoginController{
+		TwoFactorUseCase: mockTwoFactorUseCase,
+	}
+
+	mockTwoFactorUseCase.On("VerifyChallenge", mock.Anything, "mfa-token", "000000").
+		Return(domain.User{}, domain.ErrInvalidCode)
+
+	body := `{"mfa_token":"mfa-token","code":"000000"}`
+	req := httptest.NewRequest(http.MethodPost, "/public/login/mfa", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	controller.LoginMFA(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Contains(t, rr.Body.String(), `"code":"invalid_code"`)
+	mockTwoFactorUseCase.AssertExpectations(t)
+}
+
+func TestTwoFactorController_Confirm_ReturnsRecoveryCodes(t *testing.T) {
+	mockTwoFactorUseCase := new(mocks.TwoFactorUseCase)
+	controller := &controller.TwoFactorController{
+		TwoFactorUseCase:    mockTwoFactorUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{}),
+	}
+
+	userID := "user-id"
+	codes := []string{"aaaaaaaa-bbbbbbbb", "cccccccc-dddddddd"}
+	mockTwoFactorUseCase.On("Confirm", mock.Anything, userID, "123456").Return(codes, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/user/2fa/confirm", strings.NewReader(`{"code":"123456"}`))
+	req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
+	rr := httptest.NewRecorder()
+	controller.Confirm(rr, req)
+	res := rr.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp domain.RecoveryCodesResponse
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	require.NoError(t, err)
+	assert.Equal(t, codes, resp.RecoveryCodes)
+	mockTwoFactorUseCase.AssertExpectations(t)
+}
+
+func TestTwoFactorController_Disable_CodeAttemptsLimited(t *testing.T) {
+	mockTwoFactorUseCase := new(mocks.TwoFactorUseCase)
+	controller := &controller.TwoFactorController{
+		TwoFactorUseCase: mockTwoFactorUseCase,
+		LoginAttemptUseCase: newTestLoginAttemptUseCase(domain.LoginAttemptPolicy{
+			FreeAttempts: 1,
+			BackoffBase:  time.Minute,
+			BackoffMax:   time.Hour,
+			Window:       time.Hour,
+		}),
+	}
+
+	userID := "user-id"
+	mockTwoFactorUseCase.On("Disable", mock.Anything, userID, "000000").Return(domain.ErrInvalidCode)
+	for _, status := range []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodPost, "/user/2fa/disable", strings.NewReader(`{"code":"000000"}`))
+		req = req.WithContext(domain.ContextWithPrincipal(req.Context(), domain.Principal{UserID: userID}))
+		rr := httptest.NewRecorder()
+		controller.Disable(rr, req)
+		assert.Equal(t, status, rr.Code)
+	}
+	mockTwoFactorUseCase.AssertNumberOfCalls(t, "Disable", 2)
+}
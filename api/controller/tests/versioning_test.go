@@ -0,0 +1,144 @@
+package tests_test
+
+import (
+	"main/api/middleware"
+	"main/api/route"
+	"main/bootstrap"
+	"main/domain"
+	mocks "main/mocks/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetup_LegacyAliases(t *testing.T) {
+	r := chi.NewRouter()
+	env := &bootstrap.Env{LegacyAPIDeprecatedAt: "2026-11-01", LegacyAPISunsetAt: "2027-11-01"}
+	route.Setup(env, time.Second, nil, nil, nil, nil, newTestKeySet(t), loadTestContract(t), r)
+
+	// without a token both trees answer the same, only the legacy one is deprecated
+	req := httptest.NewRequest(http.MethodGet, "/v1/collection/search?count=10&offset=0", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Empty(t, rr.Header().Get("Deprecation"))
+	assert.Empty(t, rr.Header().Get("Sunset"))
+
+	req = httptest.NewRequest(http.MethodGet, "/collection/search?count=10&offset=0", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, "@1793491200", rr.Header().Get("Deprecation"))
+	assert.Equal(t, "Mon, 01 Nov 2027 00:00:00 GMT", rr.Header().Get("Sunset"))
+	assert.Equal(t, `</v1/collection/search>; rel="successor-version"`, rr.Header().Get("Link"))
+
+	// the contract validation works with the paths of both trees
+	for _, path := range []string{"/v1/public/login", "/public/login"} {
+		req = httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("Content-Type", "application/json")
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, path)
+		assert.Contains(t, rr.Body.String(), `"code":"invalid_data"`, path)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Deprecation"))
+}
+
+func TestHandlers_For(t *testing.T) {
+	legacy := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	v1 := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusCreated) }
+
+	handlers := route.Handlers{route.Legacy: legacy}
+	assert.NotNil(t, handlers.For(route.V1), "an unchanged route falls back to the older handler")
+
+	handlers = route.Handlers{route.Legacy: legacy, route.V1: v1}
+	rr := httptest.NewRecorder()
+	handlers.For(route.V1)(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	rr = httptest.NewRecorder()
+	handlers.For(route.Legacy)(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	assert.Nil(t, route.Handlers{route.V1: v1}.For(route.Legacy))
+}
+
+func TestJwtAuthMiddleware_ApiKeyScopesUnderVersion(t *testing.T) {
+	mockApiKeyUseCase := new(mocks.ApiKeyUseCase)
+	mockApiKeyUseCase.On("Authenticate", mock.Anything, "tp_secret").Return(domain.ApiKey{
+		UserID: "user-id",
+		Scopes: []string{domain.ScopeCollectionsRead},
+	}, nil)
+
+	r := chi.NewRouter()
+	r.Route("/v1", func(r chi.Router) {
+		r.Use(middleware.JwtAuthMiddleware(newTestKeySet(t), new(mocks.SessionUseCase), mockApiKeyUseCase))
+		r.Get("/collection/{id}", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+		r.Get("/user/sessions", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	})
+
+	tests := map[string]int{
+		"/v1/collection/collection-id": http.StatusOK,
+		"/v1/user/sessions":            http.StatusForbidden,
+	}
+	for path, status := range tests {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "ApiKey tp_secret")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, status, rr.Code, path)
+	}
+}
+
+func TestJwtAuthMiddleware_PreSeriesToken(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(middleware.JwtAuthMiddleware(newTestKeySet(t), new(mocks.SessionUseCase), new(mocks.ApiKeyUseCase)))
+	r.Get("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(domain.UserIDFromContext(r.Context())))
+	})
+
+	// sign makes a token of the legacy secret without the type and the session, the way they were issued
+	sign := func(claims jwt.MapClaims) string {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		signed, err := token.SignedString([]byte("access-secret"))
+		require.NoError(t, err)
+		return signed
+	}
+
+	tests := map[string]struct {
+		token  string
+		status int
+	}{
+		"pre-series": {sign(jwt.MapClaims{"id": "user-id", "username": "user"}), http.StatusOK},
+		"refresh with the same secret": {
+			sign(jwt.MapClaims{"typ": domain.TokenTypeRefresh, "id": "user-id", "sid": "session-id"}),
+			http.StatusUnauthorized,
+		},
+		"untyped with a session": {
+			sign(jwt.MapClaims{"id": "user-id", "sid": "session-id"}),
+			http.StatusUnauthorized,
+		},
+	}
+	for name, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/user", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, tt.status, rr.Code, name)
+		if tt.status == http.StatusOK {
+			assert.Equal(t, "user-id", rr.Body.String(), name)
+		}
+	}
+}
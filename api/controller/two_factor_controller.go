@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"main/bootstrap"
+	"main/domain"
+	"main/internal"
+	"net/http"
+
+	"github.com/gookit/slog"
+)
+
+type TwoFactorController struct {
+	TwoFactorUseCase    domain.TwoFactorUseCase
+	LoginAttemptUseCase domain.LoginAttemptUseCase
+	Env                 *bootstrap.Env
+}
+
+func (tc *TwoFactorController) Enroll(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	enrollment, err := tc.TwoFactorUseCase.Enroll(r.Context(), id, tc.Env.TOTPIssuer)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(enrollment)
+}
+
+func (tc *TwoFactorController) Confirm(w http.ResponseWriter, r *http.Request) {
+	var request domain.TOTPCodeRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	if request.Code == "" {
+		internal.WriteError(w, r, domain.RequiredFields("code"))
+		return
+	}
+
+	id := domain.UserIDFromContext(r.Context())
+	err = tc.LoginAttemptUseCase.ReserveCode(r.Context(), id)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	codes, err := tc.TwoFactorUseCase.Confirm(r.Context(), id, request.Code)
+	tc.codeChecked(r, id, err)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+func (tc *TwoFactorController) Disable(w http.ResponseWriter, r *http.Request) {
+	var request domain.TOTPCodeRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	if request.Code == "" {
+		internal.WriteError(w, r, domain.RequiredFields("code"))
+		return
+	}
+
+	id := domain.UserIDFromContext(r.Context())
+	err = tc.LoginAttemptUseCase.ReserveCode(r.Context(), id)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = tc.TwoFactorUseCase.Disable(r.Context(), id, request.Code)
+	tc.codeChecked(r, id, err)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Two-factor authentication disabled",
+	})
+}
+
+// codeChecked forgets the code attempts of the user once a code is accepted and starts the backoff
+// when it is wrong; the attempt that failed for another reason stays counted.
+func (tc *TwoFactorController) codeChecked(r *http.Request, userID string, err error) {
+	switch {
+	case err == nil:
+		err = tc.LoginAttemptUseCase.SucceedCode(r.Context(), userID)
+	case errors.Is(err, domain.ErrInvalidCode):
+		err = tc.LoginAttemptUseCase.FailCode(r.Context(), userID)
+	default:
+		return
+	}
+	if err != nil {
+		slog.Errorf("Can't record the code attempt of %s: %v", userID, err)
+	}
+}
@@ -1,22 +1,34 @@
 package controller
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"main/bootstrap"
 	"main/domain"
 	"main/internal"
 	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gookit/slog"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type UserController struct {
-	UserUseCase       domain.UserUseCase
-	CollectionUseCase domain.CollectionUseCase
-	HistoryUseCase    domain.HistoryUseCase
+	UserUseCase         domain.UserUseCase
+	CollectionUseCase   domain.CollectionUseCase
+	HistoryUseCase      domain.HistoryUseCase
+	SessionUseCase      domain.SessionUseCase
+	PasswordUseCase     domain.PasswordUseCase
+	VerificationUseCase domain.VerificationUseCase
+	UploadUseCase       domain.UploadUseCase
+	Env                 *bootstrap.Env
 }
 
 func (uc *UserController) Get(w http.ResponseWriter, r *http.Request) {
-	authID := r.Context().Value("x-user-id").(string)
+	authID := domain.UserIDFromContext(r.Context())
 
 	queryParams := r.URL.Query()
 	id := queryParams.Get("id")
@@ -25,18 +37,20 @@ func (uc *UserController) Get(w http.ResponseWriter, r *http.Request) {
 	case id == "": // получение своего профиля
 		user, err := uc.UserUseCase.GetByID(r.Context(), authID)
 		if err != nil {
-			http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+			internal.WriteError(w, r, domain.ErrUserNotFound)
 			return
 		}
 
 		userInfo := domain.UserInfo{
-			ID:          user.ID,
-			Username:    user.Username,
-			Email:       user.Email,
-			HasPicture:  user.HasPicture,
-			Collections: user.Collections,
-			Statistics:  user.Statistics,
-			Favourite:   user.Favourite,
+			ID:            user.ID,
+			Username:      user.Username,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified,
+			TwoFactor:     user.TwoFactor.Enabled,
+			HasPicture:    user.HasPicture,
+			Collections:   user.Collections,
+			Statistics:    user.Statistics,
+			Favourite:     user.Favourite,
 		}
 		if user.Collections == nil {
 			userInfo.Collections = make([]string, 0)
@@ -49,12 +63,12 @@ func (uc *UserController) Get(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(userInfo)
 		return
 	case internal.ValidateUUID(id) != nil:
-		http.Error(w, jsonError("Invalid id"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("id"))
 		return
 	default: // получение чужого профиля
 		user, err := uc.UserUseCase.GetByID(r.Context(), id)
 		if err != nil {
-			http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+			internal.WriteError(w, r, domain.ErrUserNotFound)
 			return
 		}
 
@@ -62,7 +76,7 @@ func (uc *UserController) Get(w http.ResponseWriter, r *http.Request) {
 
 		collections, err := uc.CollectionUseCase.SearchPublicByAuthor(r.Context(), id)
 		if err != nil {
-			http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+			internal.WriteError(w, r, err)
 			return
 		}
 		for _, coll := range collections {
@@ -87,22 +101,33 @@ func (uc *UserController) Update(w http.ResponseWriter, r *http.Request) {
 	var user domain.User
 	err := json.NewDecoder(r.Body).Decode(&user)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.ErrInvalidBody)
 		return
 	}
 
 	if user.Username == "" || user.Email == "" {
-		http.Error(w, jsonError("Invalid data"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.RequiredFields("username", "email"))
+		return
+	}
+	if !internal.ValidateEmail(user.Email) {
+		internal.WriteError(w, r, domain.InvalidFields("email"))
 		return
 	}
 
-	id := r.Context().Value("x-user-id").(string)
-	err = uc.UserUseCase.PutByID(r.Context(), id, &user)
+	id := domain.UserIDFromContext(r.Context())
+	emailChanged, err := uc.UserUseCase.PutByID(r.Context(), id, &user)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
+	if emailChanged {
+		err = uc.VerificationUseCase.SendVerification(r.Context(), id, uc.Env.VerificationTokenExpiryHour)
+		if err != nil {
+			slog.Errorf("Can't send verification email to %s: %v", id, err)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(domain.SuccessResponse{
@@ -110,8 +135,83 @@ func (uc *UserController) Update(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (uc *UserController) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	err := uc.VerificationUseCase.ResendVerification(
+		r.Context(),
+		id,
+		uc.Env.VerificationTokenExpiryHour,
+		uc.Env.VerificationCooldownSecond,
+	)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Verification email sent",
+	})
+}
+
+func (uc *UserController) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var request domain.ChangePasswordRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	if request.OldPassword == "" || request.NewPassword == "" {
+		internal.WriteError(w, r, domain.RequiredFields("old_password", "new_password"))
+		return
+	}
+
+	id := domain.UserIDFromContext(r.Context())
+	user, err := uc.PasswordUseCase.GetUserByID(r.Context(), id)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrUserNotFound)
+		return
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.OldPassword))
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidCredentials)
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword(
+		[]byte(request.NewPassword),
+		bcrypt.DefaultCost,
+	)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	err = uc.PasswordUseCase.UpdatePassword(r.Context(), id, string(hashedPassword))
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	// a stolen refresh token is of no use after the change, the current session goes on
+	err = uc.SessionUseCase.RevokeOthers(r.Context(), id, domain.SessionIDFromContext(r.Context()))
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Password updated",
+	})
+}
+
 func (uc *UserController) GetProfilePicture(w http.ResponseWriter, r *http.Request) {
-	authID := r.Context().Value("x-user-id").(string)
+	authID := domain.UserIDFromContext(r.Context())
 
 	queryParams := r.URL.Query()
 	id := queryParams.Get("id")
@@ -119,50 +219,113 @@ func (uc *UserController) GetProfilePicture(w http.ResponseWriter, r *http.Reque
 	if id == "" {
 		id = authID // получим свою аватарку
 	} else if internal.ValidateUUID(id) != nil {
-		http.Error(w, jsonError("Invalid id"), http.StatusBadRequest)
+		internal.WriteError(w, r, domain.InvalidFields("id"))
 		return
 	}
 
-	fileBytes, err := uc.UserUseCase.GetProfilePicture(r.Context(), id)
+	size, err := domain.ParsePictureSize(queryParams.Get("size"))
 	if err != nil {
-		http.Error(w, jsonError("User picture not found"), http.StatusNotFound)
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "image/jpeg")
-	w.WriteHeader(http.StatusOK)
-	w.Write(fileBytes)
+	picture, err := uc.UserUseCase.GetProfilePicture(r.Context(), id, size)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrUserPictureNotFound)
+		return
+	}
+
+	internal.ServeObject(w, r, picture)
 }
 
-//nolint:mnd // 5 MB
-func (uc *UserController) UploadProfilePicture(w http.ResponseWriter, r *http.Request) {
-	err := r.ParseMultipartForm(5 << 20)
+// GetProfilePictureURL returns the short-lived URL the client gets the picture from the storage by.
+// If the storage can't presign, it is the URL of GetProfilePicture.
+func (uc *UserController) GetProfilePictureURL(w http.ResponseWriter, r *http.Request) {
+	authID := domain.UserIDFromContext(r.Context())
+
+	queryParams := r.URL.Query()
+	id := queryParams.Get("id")
+
+	if id == "" {
+		id = authID
+	} else if internal.ValidateUUID(id) != nil {
+		internal.WriteError(w, r, domain.InvalidFields("id"))
+		return
+	}
+
+	size, err := domain.ParsePictureSize(queryParams.Get("size"))
 	if err != nil {
-		http.Error(w, jsonError("Error with file or its max size"), http.StatusBadRequest)
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	file, handler, err := r.FormFile("image")
+	presigned, err := uc.UserUseCase.GetProfilePictureURL(r.Context(), id, size)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrUserPictureNotFound)
+		return
+	}
+	if presigned.Proxy {
+		presigned.URL = proxyURL(r, strings.TrimSuffix(r.URL.Path, "/url"))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(presigned)
 	if err != nil {
-		http.Error(w, "Error retrieving the file", http.StatusBadRequest)
+		internal.WriteError(w, r, err)
 		return
 	}
-	defer file.Close()
+}
+
+// StartProfilePictureUpload starts the upload of a picture the client puts to the storage by itself,
+// the picture replaces the current one once the upload is completed.
+func (uc *UserController) StartProfilePictureUpload(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	startUpload(w, r, uc.UploadUseCase, &domain.Upload{
+		UserID: id,
+		Target: domain.UploadTargetProfilePicture,
+	})
+}
+
+// PutProfilePictureUpload takes the picture of the upload when the storage can't presign.
+func (uc *UserController) PutProfilePictureUpload(w http.ResponseWriter, r *http.Request) {
+	putUpload(w, r, uc.UploadUseCase, domain.UploadTargetProfilePicture)
+}
 
-	if handler.Size > (5 << 20) {
-		http.Error(w, jsonError("Image's size should be less than 5 MB"), http.StatusBadRequest)
+// CompleteProfilePictureUpload checks the uploaded picture and stores it as UploadProfilePicture does.
+func (uc *UserController) CompleteProfilePictureUpload(w http.ResponseWriter, r *http.Request) {
+	id := domain.UserIDFromContext(r.Context())
+	err := completeUpload(r, uc.UploadUseCase, domain.UploadTargetProfilePicture,
+		func(c context.Context, _ domain.Upload, picture []byte, contentType string) error {
+			return uc.UserUseCase.UploadProfilePicture(
+				c, id, bytes.NewReader(picture), int64(len(picture)), contentType,
+			)
+		})
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
-	//nolint:staticcheck // business logic
-	if !(strings.HasSuffix(handler.Filename, ".jpg") || strings.HasSuffix(handler.Filename, ".jpeg")) {
-		http.Error(w, jsonError("Image's extension should be JPG/JPEG"), http.StatusBadRequest)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Picture uploaded successfully",
+	})
+}
+
+func (uc *UserController) UploadProfilePicture(w http.ResponseWriter, r *http.Request) {
+	picture, contentType, err := readPicture(w, r)
+	if err != nil {
+		internal.WriteError(w, r, err)
 		return
 	}
 
-	id := r.Context().Value("x-user-id").(string)
-	err = uc.UserUseCase.UploadProfilePicture(r.Context(), id, file, handler.Size)
+	id := domain.UserIDFromContext(r.Context())
+	err = uc.UserUseCase.UploadProfilePicture(
+		r.Context(), id, bytes.NewReader(picture), int64(len(picture)), contentType,
+	)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -174,10 +337,10 @@ func (uc *UserController) UploadProfilePicture(w http.ResponseWriter, r *http.Re
 }
 
 func (uc *UserController) RemoveProfilePicture(w http.ResponseWriter, r *http.Request) {
-	id := r.Context().Value("x-user-id").(string)
+	id := domain.UserIDFromContext(r.Context())
 	err := uc.UserUseCase.RemoveProfilePicture(r.Context(), id)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusInternalServerError)
+		internal.WriteError(w, r, err)
 		return
 	}
 
@@ -193,7 +356,7 @@ func (uc *UserController) GetHistory(w http.ResponseWriter, r *http.Request) {
 	var fromTime = 0
 	var err error
 
-	userID := r.Context().Value("x-user-id").(string)
+	userID := domain.UserIDFromContext(r.Context())
 
 	queryParams := r.URL.Query()
 	fromTimeStr := queryParams.Get("from_time")
@@ -202,14 +365,14 @@ func (uc *UserController) GetHistory(w http.ResponseWriter, r *http.Request) {
 		fromTime, err = strconv.Atoi(fromTimeStr)
 
 		if err != nil || fromTime < 0 {
-			http.Error(w, jsonError("Invalid from_time"), http.StatusBadRequest)
+			internal.WriteError(w, r, domain.InvalidFields("from_time"))
 			return
 		}
 	}
 
 	userHistory, err := uc.HistoryUseCase.GetUserHistoryFromTime(r.Context(), userID, fromTime)
 	if err != nil {
-		http.Error(w, jsonError(err.Error()), http.StatusNotFound)
+		internal.WriteError(w, r, domain.ErrUserNotFound)
 		return
 	}
 
@@ -220,3 +383,51 @@ func (uc *UserController) GetHistory(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(result)
 }
+
+func (uc *UserController) GetSessions(w http.ResponseWriter, r *http.Request) {
+	var result domain.SessionInfoArray
+
+	userID := domain.UserIDFromContext(r.Context())
+	currentSessionID := domain.SessionIDFromContext(r.Context())
+
+	sessions, err := uc.SessionUseCase.GetActiveByUserID(r.Context(), userID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	result.Items = make([]domain.SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		result.Items = append(result.Items, domain.SessionInfo{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	result.Count = len(result.Items)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+func (uc *UserController) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := domain.UserIDFromContext(r.Context())
+	sessionID := chi.URLParam(r, "id")
+
+	err := uc.SessionUseCase.Revoke(r.Context(), userID, sessionID)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Session revoked",
+	})
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"encoding/json"
+	"main/domain"
+	"main/internal"
+	"net/http"
+)
+
+type VerificationController struct {
+	VerificationUseCase domain.VerificationUseCase
+}
+
+func (vc *VerificationController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var request domain.VerifyEmailRequest
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		internal.WriteError(w, r, domain.ErrInvalidBody)
+		return
+	}
+
+	if request.Token == "" {
+		internal.WriteError(w, r, domain.RequiredFields("token"))
+		return
+	}
+
+	err = vc.VerificationUseCase.Verify(r.Context(), request.Token)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(domain.SuccessResponse{
+		Message: "Email verified",
+	})
+}
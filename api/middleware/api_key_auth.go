@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"main/domain"
+	"main/internal"
+	"net/http"
+	"strings"
+)
+
+const apiKeyScheme = "ApiKey"
+
+// apiKeyRoute grants access to a part of the API: safe methods need
+// the read scope, everything else the write one. Routes that are not listed
+// (profile, sessions, keys themselves) can't be used with an API key.
+type apiKeyRoute struct {
+	prefix     string
+	readScope  string
+	writeScope string
+}
+
+var apiKeyRoutes = []apiKeyRoute{
+	{prefix: "/collection", readScope: domain.ScopeCollectionsRead, writeScope: domain.ScopeCollectionsWrite},
+	{prefix: "/user/history", readScope: domain.ScopeHistoryRead},
+}
+
+func apiKeyScope(r *http.Request) string {
+	path := strings.TrimSuffix(internal.RoutePath(r), "/")
+	for _, route := range apiKeyRoutes {
+		if path != route.prefix && !strings.HasPrefix(path, route.prefix+"/") {
+			continue
+		}
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			return route.readScope
+		}
+		return route.writeScope
+	}
+	return ""
+}
+
+func apiKeyAuth(
+	w http.ResponseWriter,
+	r *http.Request,
+	next http.Handler,
+	apiKeyUseCase domain.ApiKeyUseCase,
+	key string,
+) {
+	apiKey, err := apiKeyUseCase.Authenticate(r.Context(), key)
+	if err != nil {
+		internal.WriteError(w, r, err)
+		return
+	}
+
+	principal := domain.Principal{
+		UserID:     apiKey.UserID,
+		Scopes:     apiKey.Scopes,
+		AuthMethod: domain.AuthMethodApiKey,
+	}
+	scope := apiKeyScope(r)
+	if scope == "" || !principal.HasScope(scope) {
+		internal.WriteError(w, r, domain.ErrApiKeyScope)
+		return
+	}
+
+	addPrometheusUser(apiKey.UserID)
+	next.ServeHTTP(w, r.WithContext(domain.ContextWithPrincipal(r.Context(), principal)))
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"main/domain"
+	"main/internal"
+	"net/http"
+)
+
+// bufferedResponseWriter keeps the response until it is checked against the contract.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (bw *bufferedResponseWriter) Header() http.Header {
+	return bw.header
+}
+
+func (bw *bufferedResponseWriter) WriteHeader(status int) {
+	if bw.status == 0 {
+		bw.status = status
+	}
+}
+
+func (bw *bufferedResponseWriter) Write(b []byte) (int, error) {
+	bw.WriteHeader(http.StatusOK)
+	return bw.body.Write(b)
+}
+
+// ContractValidationMiddleware rejects the requests whose parameters or body
+// don't match api/contract.yaml with a validation error listing the fields.
+// A JSON body is read whole for that, so it is limited to domain.MaxJSONBodySize.
+// Requests to the paths that are not in the contract are passed as is.
+// With validateResponses (test mode) the responses are checked too and
+// a response that violates the contract is replaced by an internal error.
+func ContractValidationMiddleware(
+	contract *internal.Contract,
+	validateResponses bool,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, ok := contract.FindRoute(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if internal.IsJSON(r) {
+				r.Body = http.MaxBytesReader(w, r.Body, domain.MaxJSONBodySize)
+			}
+			err := contract.ValidateRequest(r, route, pathParams)
+			if err != nil {
+				internal.WriteError(w, r, err)
+				return
+			}
+
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bw := &bufferedResponseWriter{header: make(http.Header)}
+			next.ServeHTTP(bw, r)
+			if bw.status == 0 {
+				bw.status = http.StatusOK
+			}
+
+			err = contract.ValidateResponse(r, route, pathParams, bw.status, bw.header, bw.body.Bytes())
+			if err != nil {
+				internal.WriteError(w, r, fmt.Errorf("response violates the contract: %w", err))
+				return
+			}
+
+			for key, values := range bw.header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(bw.status)
+			w.Write(bw.body.Bytes())
+		})
+	}
+}
@@ -1,45 +1,62 @@
 package middleware
 
 import (
-	"context"
+	"main/domain"
 	"main/internal"
 	"net/http"
 	"strings"
 )
 
-const (
-	userIDKey string = "x-user-id"
-)
-
 //nolint:mnd // business logic
-func JwtAuthMiddleware(secret string) func(http.Handler) http.Handler {
+func JwtAuthMiddleware(
+	keySet domain.KeySet,
+	sessionUseCase domain.SessionUseCase,
+	apiKeyUseCase domain.ApiKeyUseCase,
+) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			t := strings.Split(authHeader, " ")
-			if len(t) == 2 {
-				authToken := t[1]
-				authorized, err := internal.IsAuthorized(authToken, secret)
-				if authorized {
-					var userID string
-					userID, err = internal.ExtractIDFromToken(authToken, secret)
-					if err != nil {
-						http.Error(w, jsonError(err.Error()), http.StatusUnauthorized)
-						return
-					}
-					ctx := context.WithValue(r.Context(), userIDKey, userID)
-					addPrometheusUser(userID)
-					next.ServeHTTP(w, r.WithContext(ctx))
+			if len(t) != 2 {
+				internal.WriteError(w, r, domain.ErrNotAuthorized)
+				return
+			}
+
+			if t[0] == apiKeyScheme {
+				apiKeyAuth(w, r, next, apiKeyUseCase, t[1])
+				return
+			}
+
+			claims, err := internal.ParseAccessToken(t[1], keySet)
+			if err != nil {
+				internal.WriteError(w, r, domain.ErrInvalidToken)
+				return
+			}
+
+			// tokens issued before sessions were introduced carry no session ID,
+			// ParseAccessToken lets them through only from the legacy key
+			if claims.SessionID != "" {
+				var active bool
+				active, err = sessionUseCase.IsActive(r.Context(), claims.SessionID)
+				if err != nil {
+					internal.WriteError(w, r, err)
+					return
+				}
+				if !active {
+					internal.WriteError(w, r, domain.ErrSessionRevoked)
 					return
 				}
-				http.Error(w, jsonError(err.Error()), http.StatusUnauthorized)
-				return
 			}
-			http.Error(w, jsonError("Not authorized"), http.StatusUnauthorized)
+
+			ctx := domain.ContextWithPrincipal(r.Context(), domain.Principal{
+				UserID:     claims.ID,
+				Username:   claims.Username,
+				Roles:      claims.Roles,
+				SessionID:  claims.SessionID,
+				AuthMethod: domain.AuthMethodJwt,
+			})
+			addPrometheusUser(claims.ID)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
-
-func jsonError(message string) string {
-	return `{"message": "` + message + `"}`
-}
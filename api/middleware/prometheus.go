@@ -16,7 +16,7 @@ type ResponseWriter struct {
 	statusCode int
 }
 
-var uniqueUsers mapset.Set
+var uniqueUsers = mapset.NewSet()
 var lastSync time.Time
 
 func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
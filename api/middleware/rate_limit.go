@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"main/domain"
+	"main/internal"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var rateLimitRejections = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "rate_limit_rejections_total",
+		Help: "Number of requests rejected by the rate limiter, by route group",
+	},
+	[]string{"group"},
+)
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateLimiter is a set of token buckets, one per user or client IP:
+// a bucket holds up to burst tokens and is refilled with rate tokens per second.
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	rate      float64
+	burst     float64
+	lastPurge time.Time
+}
+
+// take spends a token of the key if there is one. It returns the tokens left,
+// the time until the bucket is full again and, if the request is rejected,
+// the time until the next token.
+func (rl *rateLimiter) take(key string, now time.Time) (remaining int, reset time.Duration, wait time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.purge(now)
+
+	bucket, ok := rl.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: rl.burst, updated: now}
+		rl.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(rl.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*rl.rate)
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		wait = rl.duration(1 - bucket.tokens)
+	} else {
+		bucket.tokens--
+	}
+	return int(bucket.tokens), rl.duration(rl.burst - bucket.tokens), wait
+}
+
+func (rl *rateLimiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / rl.rate * float64(time.Second))
+}
+
+// purge drops the buckets that have been refilled completely, at most once a minute.
+func (rl *rateLimiter) purge(now time.Time) {
+	if now.Sub(rl.lastPurge) < time.Minute {
+		return
+	}
+	rl.lastPurge = now
+	full := rl.duration(rl.burst)
+	for key, bucket := range rl.buckets {
+		if now.Sub(bucket.updated) >= full {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// RateLimitMiddleware limits the requests of a route group to perMinute,
+// allowing bursts of up to burst requests. Authenticated requests are counted
+// per user, the others per client IP, so private groups have to be limited
+// after JwtAuthMiddleware. A zero perMinute turns the limit off.
+func RateLimitMiddleware(group string, perMinute int, burst int) func(http.Handler) http.Handler {
+	if perMinute <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	limiter := &rateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		rate:    float64(perMinute) / 60,
+		burst:   float64(max(burst, 1)),
+	}
+	limit := strconv.Itoa(max(burst, 1))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := "ip:" + internal.ClientIP(r)
+			if userID := domain.UserIDFromContext(r.Context()); userID != "" {
+				key = "user:" + userID
+			}
+
+			remaining, reset, wait := limiter.take(key, time.Now())
+			w.Header().Set("X-RateLimit-Limit", limit)
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			w.Header().Set("X-RateLimit-Reset", seconds(reset))
+			if wait > 0 {
+				rateLimitRejections.WithLabelValues(group).Inc()
+				internal.WriteError(w, r, domain.ErrTooManyRequests.WithRetryAfter(wait))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// RealIPMiddleware replaces RemoteAddr with the client address told by
+// X-Forwarded-For or X-Real-Ip, but only if the request came from one of the
+// trusted proxies; anyone else could rotate the headers to dodge the limits
+// kept per address. X-Forwarded-For is read from the right, the first address
+// that isn't a trusted proxy is the client.
+func RealIPMiddleware(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	isTrusted := func(value string) bool {
+		addr, err := netip.ParseAddr(strings.TrimSpace(value))
+		if err != nil {
+			return false
+		}
+		addr = addr.Unmap()
+		for _, prefix := range trusted {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host, port, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil || !isTrusted(host) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client := ""
+			if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+				hops := strings.Split(strings.Join(forwarded, ","), ",")
+				for i := len(hops) - 1; i >= 0; i-- {
+					client = strings.TrimSpace(hops[i])
+					if !isTrusted(client) {
+						break
+					}
+				}
+			} else {
+				client = strings.TrimSpace(r.Header.Get("X-Real-Ip"))
+			}
+
+			if addr, err := netip.ParseAddr(client); err == nil {
+				r.RemoteAddr = net.JoinHostPort(addr.Unmap().String(), port)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -12,7 +12,14 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func NewCollectionRouter(timeout time.Duration, db database.Database, s storage.Client, r chi.Router) {
+func NewCollectionRouter(
+	timeout time.Duration,
+	db database.Database,
+	s storage.Client,
+	l RateLimiters,
+	v Version,
+	r chi.Router,
+) {
 	ur := repository.NewUserRepository(db, domain.UserCollection)
 	us := storage.NewUserStorage(s, domain.UserBucket)
 	cs := storage.NewCollectionStorage(s, domain.CollectionBucket)
@@ -20,17 +27,23 @@ func NewCollectionRouter(timeout time.Duration, db database.Database, s storage.
 	uhr := repository.NewUserHistoryRepository(db, domain.UserHistoryCollection)
 	chr := repository.NewCollectionHistoryRepository(db, domain.CollectionHistoryCollection)
 
+	upr := repository.NewUploadRepository(db, domain.UploadCollection)
+	ups := storage.NewUploadStorage(s, domain.UploadBucket)
+
 	cr := repository.NewCollectionRepository(db, domain.CollectionCollection)
+	mr := repository.NewMediaRepository(db, domain.MediaCollection)
 	cc := &controller.CollectionController{
-		CollectionUseCase: usecase.NewCollectionUseCase(cr, cs, ur, timeout),
+		CollectionUseCase: usecase.NewCollectionUseCase(cr, cs, mr, ur, timeout),
 		UserUseCase:       usecase.NewUserUseCase(ur, us, timeout),
 		HistoryUseCase:    usecase.NewHistoryUseCase(uhr, chr, cr, ur, timeout),
+		UploadUseCase:     usecase.NewUploadUseCase(upr, ups, timeout),
 	}
 	r.Route("/collection", func(r chi.Router) {
 		r.Post("/", cc.Create)
-		r.Get("/search", cc.Search)
+		r.With(l.Search).Get("/search", cc.Search)
 		r.Route("/{id}", func(r chi.Router) {
-			r.Get("/", cc.Get)
+			// CollectionInfo is versioned, register the handler of a new shape here
+			r.Get("/", Handlers{Legacy: cc.Get, V1: cc.GetV1}.For(v))
 			r.Put("/", cc.Update)
 			r.Delete("/", cc.Delete)
 			r.Put("/like", cc.AddLike)
@@ -42,14 +55,33 @@ func NewCollectionRouter(timeout time.Duration, db database.Database, s storage.
 					r.Delete("/", cc.DeleteCard)
 					r.Route("/picture", func(r chi.Router) {
 						r.Get("/", cc.GetCardPicture)
-						r.Put("/", cc.UploadCardPicture)
+						r.With(l.Upload).Put("/", cc.UploadCardPicture)
 						r.Delete("/", cc.RemoveCardPicture)
 					})
+					r.Route("/attachment", func(r chi.Router) {
+						r.With(l.Upload).Post("/", cc.AddCardAttachment)
+						r.Put("/order", cc.ReorderCardAttachments)
+						r.Route("/upload", func(r chi.Router) {
+							r.With(l.Upload).Post("/", cc.StartCardAttachmentUpload)
+							r.With(l.Upload).Put("/{uploadID}", cc.PutCardAttachmentUpload)
+							r.Post("/{uploadID}/complete", cc.CompleteCardAttachmentUpload)
+						})
+						r.Route("/{attachmentID}", func(r chi.Router) {
+							r.Get("/", cc.GetCardAttachment)
+							r.Get("/url", cc.GetCardAttachmentURL)
+							r.Delete("/", cc.RemoveCardAttachment)
+						})
+					})
+					r.Route("/audio", func(r chi.Router) {
+						r.Get("/", cc.GetCardAudio)
+						r.With(l.Upload).Put("/", cc.UploadCardAudio)
+						r.Delete("/", cc.RemoveCardAudio)
+					})
 				})
 			})
 		})
 		r.Route("/training", func(r chi.Router) {
-			r.Post("/", cc.AddTraining)
+			r.With(l.Training).Post("/", cc.AddTraining)
 		})
 	})
 }
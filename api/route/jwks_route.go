@@ -0,0 +1,16 @@
+package route
+
+import (
+	"main/api/controller"
+	"main/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func NewJWKSRouter(k domain.KeySet, r chi.Router) {
+	jc := &controller.JWKSController{
+		KeySet: k,
+	}
+
+	r.Get("/.well-known/jwks.json", jc.GetJWKS)
+}
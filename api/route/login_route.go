@@ -5,6 +5,7 @@ import (
 	"main/bootstrap"
 	"main/database"
 	"main/domain"
+	"main/oauth"
 	"main/repository"
 	"main/usecase"
 	"time"
@@ -12,12 +13,46 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func NewLoginRouter(env *bootstrap.Env, timeout time.Duration, db database.Database, r chi.Router) {
+func NewLoginRouter(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db database.Database,
+	p oauth.Providers,
+	k domain.KeySet,
+	la domain.LoginAttemptRepository,
+	v Version,
+	r chi.Router,
+) {
 	ur := repository.NewUserRepository(db, domain.UserCollection)
+	sr := repository.NewSessionRepository(db, domain.SessionCollection)
+	utr := repository.NewUserTokenRepository(db, domain.UserTokenCollection)
+	ir := repository.NewIdentityRepository(db, domain.IdentityCollection)
+	osr := repository.NewOAuthStateRepository(db, domain.OAuthStateCollection)
+	lar := repository.NewLoginAuditRepository(db, domain.LoginAuditCollection)
 	lc := &controller.LoginController{
-		LoginUseCase: usecase.NewLoginUseCase(ur, timeout),
-		Env:          env,
+		LoginUseCase:        usecase.NewLoginUseCase(ur, sr, timeout),
+		LoginAttemptUseCase: usecase.NewLoginAttemptUseCase(la, lar, loginAttemptPolicy(env), timeout),
+		TwoFactorUseCase:    usecase.NewTwoFactorUseCase(ur, utr, timeout),
+		OAuthUseCase:        usecase.NewOAuthUseCase(ur, ir, osr, p, timeout),
+		KeySet:              k,
+		Env:                 env,
+		LegacyHeaders:       v == Legacy,
 	}
 
 	r.Post("/public/login", lc.Login)
+	r.Post("/public/login/mfa", lc.LoginMFA)
+	r.Post("/public/oauth/{provider}/start", lc.OAuthStart)
+	r.Post("/public/oauth/{provider}/callback", lc.OAuthCallback)
+}
+
+func loginAttemptPolicy(env *bootstrap.Env) domain.LoginAttemptPolicy {
+	return domain.LoginAttemptPolicy{
+		FreeAttempts:     env.LoginFreeAttempts,
+		IPFreeAttempts:   env.LoginIPFreeAttempts,
+		BackoffBase:      time.Duration(env.LoginBackoffBaseSecond) * time.Second,
+		BackoffMax:       time.Duration(env.LoginBackoffMaxSecond) * time.Second,
+		LockoutThreshold: env.LoginLockoutThreshold,
+		LockoutDuration:  time.Duration(env.LoginLockoutMinute) * time.Minute,
+		Window:           time.Duration(env.LoginAttemptWindowMinute) * time.Minute,
+	}
 }
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"main/api/controller"
+	"main/bootstrap"
+	"main/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func NewLogoutRouter(env *bootstrap.Env, su domain.SessionUseCase, r chi.Router) {
+	lc := &controller.LogoutController{
+		SessionUseCase: su,
+		Env:            env,
+	}
+
+	r.Post("/auth/logout", lc.Logout)
+	r.Post("/auth/logout-all", lc.LogoutAll)
+}
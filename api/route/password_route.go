@@ -0,0 +1,27 @@
+package route
+
+import (
+	"main/api/controller"
+	"main/bootstrap"
+	"main/database"
+	"main/domain"
+	"main/mail"
+	"main/repository"
+	"main/usecase"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func NewPasswordRouter(env *bootstrap.Env, timeout time.Duration, db database.Database, m mail.Sender, r chi.Router) {
+	ur := repository.NewUserRepository(db, domain.UserCollection)
+	utr := repository.NewUserTokenRepository(db, domain.UserTokenCollection)
+	sr := repository.NewSessionRepository(db, domain.SessionCollection)
+	pc := &controller.PasswordController{
+		PasswordUseCase: usecase.NewPasswordUseCase(ur, utr, sr, m, timeout),
+		Env:             env,
+	}
+
+	r.Post("/public/password/forgot", pc.ForgotPassword)
+	r.Post("/public/password/reset", pc.ResetPassword)
+}
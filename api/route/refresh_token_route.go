@@ -12,11 +12,21 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func NewRefreshTokenRouter(env *bootstrap.Env, timeout time.Duration, db database.Database, r chi.Router) {
+func NewRefreshTokenRouter(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db database.Database,
+	k domain.KeySet,
+	v Version,
+	r chi.Router,
+) {
 	ur := repository.NewUserRepository(db, domain.UserCollection)
+	sr := repository.NewSessionRepository(db, domain.SessionCollection)
 	rtc := &controller.RefreshTokenController{
-		RefreshTokenUseCase: usecase.NewRefreshTokenUseCase(ur, timeout),
+		RefreshTokenUseCase: usecase.NewRefreshTokenUseCase(ur, sr, timeout),
+		KeySet:              k,
 		Env:                 env,
+		LegacyHeaders:       v == Legacy,
 	}
 
 	r.Post("/public/refreshToken", rtc.RefreshToken)
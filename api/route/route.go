@@ -4,33 +4,134 @@ import (
 	"main/api/middleware"
 	"main/bootstrap"
 	"main/database"
+	"main/domain"
+	"main/internal"
+	"main/mail"
+	"main/oauth"
+	"main/repository"
 	"main/storage"
+	"main/usecase"
 	"net/http"
+	"net/netip"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gookit/slog"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func Setup(env *bootstrap.Env, timeout time.Duration, db database.Database, s storage.Client, r *chi.Mux) {
+// RateLimiters are the limits of the route groups,
+// shared by all the routes of a group.
+type RateLimiters struct {
+	Auth     func(http.Handler) http.Handler
+	Search   func(http.Handler) http.Handler
+	Upload   func(http.Handler) http.Handler
+	Training func(http.Handler) http.Handler
+}
+
+func NewRateLimiters(env *bootstrap.Env) RateLimiters {
+	return RateLimiters{
+		Auth:     middleware.RateLimitMiddleware("auth", env.RateLimitAuthPerMinute, env.RateLimitAuthBurst),
+		Search:   middleware.RateLimitMiddleware("search", env.RateLimitSearchPerMinute, env.RateLimitSearchBurst),
+		Upload:   middleware.RateLimitMiddleware("upload", env.RateLimitUploadPerMinute, env.RateLimitUploadBurst),
+		Training: middleware.RateLimitMiddleware("training", env.RateLimitTrainingPerMinute, env.RateLimitTrainingBurst),
+	}
+}
+
+func Setup(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db database.Database,
+	s storage.Client,
+	m mail.Sender,
+	p oauth.Providers,
+	k domain.KeySet,
+	c *internal.Contract,
+	r *chi.Mux,
+) {
+	r.Use(middleware.RealIPMiddleware(parseProxies(env.TrustedProxies)))
 	r.Use(middleware.LoggingMiddleware)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.CORSHandler)
 	r.Use(middleware.PrometheusMiddleware)
-	// public methods
+	l := NewRateLimiters(env)
+	validation := middleware.ContractValidationMiddleware(c, env.ContractValidateResponses)
+
+	// the state kept in memory is shared by the versions, so that a client can't double its limits
+	// or outlive a revoked session by switching between the paths
+	la := repository.NewMemoryLoginAttemptRepository()
+	sr := repository.NewSessionRepository(db, domain.SessionCollection)
+	ar := repository.NewApiKeyRepository(db, domain.ApiKeyCollection)
+	su := usecase.NewSessionUseCase(sr, timeout)
+	auth := middleware.JwtAuthMiddleware(k, su, usecase.NewApiKeyUseCase(ar, timeout))
+
+	// unversioned methods
 	r.Group(func(r chi.Router) {
-		NewSignupRouter(env, timeout, db, r)
-		NewLoginRouter(env, timeout, db, r)
-		NewRefreshTokenRouter(env, timeout, db, r)
+		r.Use(validation)
+		NewJWKSRouter(k, r)
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ping")) })
 		r.Handle("/metrics", promhttp.Handler())
 	})
 
-	// private methods
+	api := func(v Version, r chi.Router) {
+		// the contract describes the responses of /v1, the legacy tree keeps the older shapes
+		validation := middleware.ContractValidationMiddleware(c, env.ContractValidateResponses && v != Legacy)
+
+		// public methods
+		r.Group(func(r chi.Router) {
+			r.Use(validation)
+			r.Group(func(r chi.Router) {
+				r.Use(l.Auth)
+				NewSignupRouter(env, timeout, db, m, k, v, r)
+				NewLoginRouter(env, timeout, db, p, k, la, v, r)
+				NewRefreshTokenRouter(env, timeout, db, k, v, r)
+				NewPasswordRouter(env, timeout, db, m, r)
+				NewVerificationRouter(timeout, db, m, r)
+			})
+		})
+
+		// private methods
+		r.Group(func(r chi.Router) {
+			r.Use(auth)
+			r.Use(validation)
+			NewCollectionRouter(timeout, db, s, l, v, r)
+			NewUserRouter(env, timeout, db, s, m, p, l, su, la, r)
+			NewGlobalRouter(env, timeout, r)
+			NewLogoutRouter(env, su, r)
+		})
+	}
+
+	r.Route("/v1", func(r chi.Router) {
+		api(V1, r)
+	})
+	// the paths without a version are aliases of /v1 for the clients released before it
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.JwtAuthMiddleware(env.AccessTokenSecret))
-		NewCollectionRouter(timeout, db, s, r)
-		NewUserRouter(timeout, db, s, r)
-		NewGlobalRouter(env, timeout, r)
+		r.Use(middleware.DeprecationMiddleware(
+			parseDate("LEGACY_API_DEPRECATED_AT", env.LegacyAPIDeprecatedAt),
+			parseDate("LEGACY_API_SUNSET_AT", env.LegacyAPISunsetAt),
+			"/v1",
+		))
+		api(Legacy, r)
 	})
 }
+
+func parseProxies(value string) []netip.Prefix {
+	proxies, err := internal.ParseTrustedProxies(value)
+	if err != nil {
+		slog.Errorf("TRUSTED_PROXIES should be a list of addresses and CIDR ranges: %v", err)
+		return nil
+	}
+	return proxies
+}
+
+func parseDate(name string, value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		slog.Errorf("%s should be a YYYY-MM-DD date: %v", name, err)
+		return time.Time{}
+	}
+	return date
+}
@@ -5,6 +5,7 @@ import (
 	"main/bootstrap"
 	"main/database"
 	"main/domain"
+	"main/mail"
 	"main/repository"
 	"main/usecase"
 	"time"
@@ -12,11 +13,24 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func NewSignupRouter(env *bootstrap.Env, timeout time.Duration, db database.Database, r chi.Router) {
+func NewSignupRouter(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db database.Database,
+	m mail.Sender,
+	k domain.KeySet,
+	v Version,
+	r chi.Router,
+) {
 	ur := repository.NewUserRepository(db, domain.UserCollection)
+	sr := repository.NewSessionRepository(db, domain.SessionCollection)
+	utr := repository.NewUserTokenRepository(db, domain.UserTokenCollection)
 	sc := &controller.SignupController{
-		SignupUseCase: usecase.NewSignupUseCase(ur, timeout),
-		Env:           env,
+		SignupUseCase:       usecase.NewSignupUseCase(ur, sr, timeout),
+		VerificationUseCase: usecase.NewVerificationUseCase(ur, utr, m, timeout),
+		KeySet:              k,
+		Env:                 env,
+		LegacyHeaders:       v == Legacy,
 	}
 
 	r.Post("/public/signup", sc.Signup)
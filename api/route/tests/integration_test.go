@@ -0,0 +1,1034 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"main/api/route"
+	"main/bootstrap"
+	"main/database"
+	"main/domain"
+	"main/internal"
+	"main/mail"
+	"main/oauth"
+	"main/repository"
+	"main/storage"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contractPath = "../../contract.yaml"
+
+// testJPEG is a small picture as the clients upload it
+var testJPEG = encodeJPEG(image.NewGray(image.Rect(0, 0, 8, 8)))
+
+// grayJPEG is a picture like testJPEG of another content.
+func grayJPEG(level uint8) []byte {
+	img := image.NewGray(image.Rect(0, 0, 8, 8))
+	for i := range img.Pix {
+		img.Pix[i] = level
+	}
+	return encodeJPEG(img)
+}
+
+func encodeJPEG(img image.Image) []byte {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, nil)
+	if err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+// stored is the picture as the server keeps it, re-encoded without the metadata.
+func stored(t *testing.T, data []byte) []byte {
+	t.Helper()
+	picture, _, err := internal.CleanImage(data)
+	require.NoError(t, err)
+	return picture
+}
+
+// mediaID is the object name the picture is stored under, the SHA-256 of what the server keeps.
+func mediaID(t *testing.T, data []byte) string {
+	t.Helper()
+	sum := sha256.Sum256(stored(t, data))
+	return hex.EncodeToString(sum[:])
+}
+
+type testServer struct {
+	URL     string
+	Storage storage.Client
+}
+
+// newTestServer boots the whole API on the in-memory database and storage,
+// the responses are validated against the contract.
+func newTestServer(t *testing.T, configure func(env *bootstrap.Env)) testServer {
+	t.Helper()
+	return newTestServerOn(t, storage.NewMemoryClient(), configure)
+}
+
+// newTestServerOn is newTestServer on the storage s.
+func newTestServerOn(t *testing.T, s storage.Client, configure func(env *bootstrap.Env)) testServer {
+	t.Helper()
+
+	db := database.NewMemoryClient()
+	repository.SetClient(db)
+
+	for _, bucket := range []string{domain.UserBucket, domain.CollectionBucket, domain.UploadBucket} {
+		require.NoError(t, s.MakeBucket(context.Background(), bucket))
+	}
+
+	keySet, err := internal.NewKeySet(internal.KeySetConfig{LegacySecret: "access-secret"})
+	require.NoError(t, err)
+	contract, err := internal.LoadContract(contractPath)
+	require.NoError(t, err)
+
+	env := &bootstrap.Env{
+		AccessTokenExpiryHour:       2,
+		RefreshTokenSecret:          "refresh-secret",
+		RefreshTokenExpiryHour:      168,
+		VerificationTokenExpiryHour: 24,
+		LoginFreeAttempts:           3,
+		LoginIPFreeAttempts:         20,
+		LoginBackoffBaseSecond:      1,
+		LoginBackoffMaxSecond:       60,
+		LoginLockoutThreshold:       10,
+		LoginLockoutMinute:          15,
+		LoginAttemptWindowMinute:    60,
+		ContractValidateResponses:   true,
+	}
+	if configure != nil {
+		configure(env)
+	}
+
+	r := chi.NewRouter()
+	route.Setup(env, 5*time.Second, db.Database("tprep"), s, mail.NewLogSender(), oauth.Providers{}, keySet, contract, r)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return testServer{URL: server.URL + "/v1", Storage: s}
+}
+
+// apiUser sends the requests of one user, signed in after signUp or login.
+type apiUser struct {
+	t           *testing.T
+	baseURL     string
+	accessToken string
+}
+
+func (u *apiUser) send(method string, path string, contentType string, body []byte) (int, []byte) {
+	u.t.Helper()
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, respBody := u.do(method, path, header, body)
+	return resp.StatusCode, respBody
+}
+
+// do sends the request with header and returns the response with the read body.
+func (u *apiUser) do(method string, path string, header http.Header, body []byte) (*http.Response, []byte) {
+	u.t.Helper()
+	req, err := http.NewRequest(method, u.baseURL+path, bytes.NewReader(body))
+	require.NoError(u.t, err)
+	req.Header = header
+	if u.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+u.accessToken)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(u.t, err)
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(u.t, err)
+	return resp, respBody
+}
+
+// json sends in as the JSON body and decodes the successful response into out.
+func (u *apiUser) json(method string, path string, in interface{}, out interface{}) int {
+	u.t.Helper()
+	var body []byte
+	var contentType string
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		require.NoError(u.t, err)
+		contentType = "application/json"
+	}
+	status, respBody := u.send(method, path, contentType, body)
+	if out != nil && status < http.StatusBadRequest {
+		require.NoError(u.t, json.Unmarshal(respBody, out), string(respBody))
+	}
+	return status
+}
+
+// fails asserts that the request fails with status and the error code of want.
+func (u *apiUser) fails(method string, path string, in interface{}, status int, want *domain.Error) {
+	u.t.Helper()
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		require.NoError(u.t, err)
+	}
+	got, respBody := u.send(method, path, "application/json", body)
+	assert.Equal(u.t, status, got, "%s %s", method, path)
+	var resp domain.ErrorResponse
+	require.NoError(u.t, json.Unmarshal(respBody, &resp), string(respBody))
+	assert.Equal(u.t, want.Code, resp.Code, "%s %s", method, path)
+}
+
+func (u *apiUser) upload(path string, filename string, data []byte) (int, []byte) {
+	u.t.Helper()
+	return u.uploadFile(http.MethodPut, path, "image", filename, data)
+}
+
+// uploadFile sends data as the file field of the multipart form.
+func (u *apiUser) uploadFile(method string, path string, field string, filename string, data []byte) (int, []byte) {
+	u.t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile(field, filename)
+	require.NoError(u.t, err)
+	_, err = part.Write(data)
+	require.NoError(u.t, err)
+	require.NoError(u.t, mw.Close())
+	return u.send(method, path, mw.FormDataContentType(), body.Bytes())
+}
+
+func (u *apiUser) uploadCardPicture(collectionID string, cardID int) (domain.UploadCardPhotoResult, int) {
+	u.t.Helper()
+	var result domain.UploadCardPhotoResult
+	status, body := u.upload(cardPath(collectionID, cardID)+"/picture", "picture.jpg", testJPEG)
+	if status == http.StatusOK {
+		require.NoError(u.t, json.Unmarshal(body, &result))
+	}
+	return result, status
+}
+
+func signUp(t *testing.T, server testServer, name string) (*apiUser, domain.SignupResponse) {
+	t.Helper()
+	u := &apiUser{t: t, baseURL: server.URL}
+	var resp domain.SignupResponse
+	status := u.json(http.MethodPost, "/public/signup", domain.SignupRequest{
+		Username: name,
+		Email:    name + "@t-prep.test",
+		Password: "qwerty123",
+	}, &resp)
+	require.Equal(t, http.StatusCreated, status)
+	u.accessToken = resp.AccessToken
+	return u, resp
+}
+
+func createCollection(t *testing.T, u *apiUser, name string, public bool) string {
+	t.Helper()
+	var info domain.CollectionInfo
+	status := u.json(http.MethodPost, "/collection", domain.Collection{Name: name, IsPublic: public}, &info)
+	require.Equal(t, http.StatusCreated, status)
+	return info.ID
+}
+
+func addCard(t *testing.T, u *apiUser, collectionID string, question string, answer string) domain.Card {
+	t.Helper()
+	var card domain.Card
+	status := u.json(http.MethodPost, "/collection/"+collectionID+"/card", domain.Card{
+		Question:     question,
+		Answer:       answer,
+		OtherAnswers: domain.OtherAnswers{Items: make([]string, 0)},
+	}, &card)
+	require.Equal(t, http.StatusCreated, status)
+	return card
+}
+
+func cardPath(collectionID string, cardID int) string {
+	return "/collection/" + collectionID + "/card/" + strconv.Itoa(cardID)
+}
+
+func TestIntegration_LoginAttemptsSharedByVersions(t *testing.T) {
+	server := newTestServer(t, nil)
+	signUp(t, server, "author")
+
+	v1 := &apiUser{t: t, baseURL: server.URL}
+	legacy := &apiUser{t: t, baseURL: strings.TrimSuffix(server.URL, "/v1")}
+	wrong := domain.LoginRequest{Email: "author@t-prep.test", Password: "wrong-password"}
+	// the free attempts are counted over both paths
+	for _, u := range []*apiUser{v1, legacy, v1, legacy} {
+		u.fails(http.MethodPost, "/public/login", wrong, http.StatusBadRequest, domain.ErrInvalidCredentials)
+	}
+	legacy.fails(http.MethodPost, "/public/login", wrong, http.StatusTooManyRequests, domain.ErrTooManyLoginAttempts)
+}
+
+func TestIntegration_TokenExpiryHeadersByVersion(t *testing.T) {
+	server := newTestServer(t, nil)
+	signUp(t, server, "author")
+	body, err := json.Marshal(domain.LoginRequest{Email: "author@t-prep.test", Password: "qwerty123"})
+	require.NoError(t, err)
+	header := http.Header{"Content-Type": {"application/json"}}
+
+	v1 := &apiUser{t: t, baseURL: server.URL}
+	resp, _ := v1.do(http.MethodPost, "/public/login", header, body)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = time.Parse(time.RFC3339, resp.Header.Get("X-Access-Expires-After"))
+	require.NoError(t, err)
+
+	// the legacy clients parse the format of time.Time.String
+	legacy := &apiUser{t: t, baseURL: strings.TrimSuffix(server.URL, "/v1")}
+	resp, _ = legacy.do(http.MethodPost, "/public/login", header, body)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	expiry, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", resp.Header.Get("X-Refresh-Expires-After"))
+	require.NoError(t, err)
+	assert.Equal(t, expiry.UTC().String(), resp.Header.Get("X-Refresh-Expires-After"))
+}
+
+func TestIntegration_LegacyCollectionShape(t *testing.T) {
+	server := newTestServer(t, nil)
+	author, _ := signUp(t, server, "author")
+	collectionID := createCollection(t, author, "Birds", false)
+	card := addCard(t, author, collectionID, "Robin", "Erithacus rubecula")
+	picture, status := author.uploadCardPicture(collectionID, card.LocalID)
+	require.Equal(t, http.StatusOK, status)
+
+	var info domain.CollectionInfo
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/collection/"+collectionID, nil, &info))
+	require.Len(t, info.Cards[0].Attachments, 1)
+	assert.Equal(t, picture.ObjectName, info.Cards[0].Attachments[0].ID)
+
+	// the clients released before the attachments list get the first picture of the question side
+	legacy := &apiUser{t: t, baseURL: strings.TrimSuffix(server.URL, "/v1"), accessToken: author.accessToken}
+	var cards struct {
+		Cards []map[string]interface{} `json:"cards"`
+	}
+	require.Equal(t, http.StatusOK, legacy.json(http.MethodGet, "/collection/"+collectionID, nil, &cards))
+	require.Len(t, cards.Cards, 1)
+	assert.Equal(t, picture.ObjectName, cards.Cards[0]["attachment"])
+	assert.NotContains(t, cards.Cards[0], "attachments")
+	resp, _ := legacy.do(http.MethodGet,
+		cardPath(collectionID, card.LocalID)+"/picture?object_name="+picture.ObjectName, http.Header{}, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestIntegration_RevokedSessionRejectedAtOnce(t *testing.T) {
+	server := newTestServer(t, nil)
+	author, _ := signUp(t, server, "author")
+	second := &apiUser{t: t, baseURL: server.URL}
+	var login domain.LoginResponse
+	require.Equal(t, http.StatusOK, second.json(http.MethodPost, "/public/login", domain.LoginRequest{
+		Email:    "author@t-prep.test",
+		Password: "qwerty123",
+	}, &login))
+	second.accessToken = login.AccessToken
+
+	// the sessions are checked and cached before they are revoked
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/user", nil, nil))
+	require.Equal(t, http.StatusOK, second.json(http.MethodGet, "/user", nil, nil))
+
+	require.Equal(t, http.StatusOK, author.json(http.MethodPost, "/auth/logout", nil, nil))
+	author.fails(http.MethodGet, "/user", nil, http.StatusUnauthorized, domain.ErrSessionRevoked)
+	require.Equal(t, http.StatusOK, second.json(http.MethodGet, "/user", nil, nil))
+
+	// the legacy paths share the sessions
+	legacy := &apiUser{t: t, baseURL: strings.TrimSuffix(server.URL, "/v1"), accessToken: second.accessToken}
+	require.Equal(t, http.StatusOK, legacy.json(http.MethodPost, "/auth/logout-all", nil, nil))
+	second.fails(http.MethodGet, "/user", nil, http.StatusUnauthorized, domain.ErrSessionRevoked)
+}
+
+func TestIntegration_CollectionLifecycle(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	author, signup := signUp(t, server, "author")
+	author.accessToken = ""
+	var login domain.LoginResponse
+	status := author.json(http.MethodPost, "/public/login", domain.LoginRequest{
+		Email:    "author@t-prep.test",
+		Password: "qwerty123",
+	}, &login)
+	require.Equal(t, http.StatusOK, status)
+	author.accessToken = login.AccessToken
+
+	collectionID := createCollection(t, author, "Capitals", false)
+	france := addCard(t, author, collectionID, "Capital of France", "Paris")
+	italy := addCard(t, author, collectionID, "Capital of Italy", "Rome")
+
+	picture, status := author.uploadCardPicture(collectionID, france.LocalID)
+	require.Equal(t, http.StatusOK, status)
+	status, body := author.send(http.MethodGet,
+		cardPath(collectionID, france.LocalID)+"/picture?object_name="+picture.ObjectName, "", nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, stored(t, testJPEG), body)
+
+	status = author.json(http.MethodPost, "/collection/training", domain.HistoryItem{
+		CollectionID:   collectionID,
+		CollectionName: "Capitals",
+		Time:           1700000000,
+		AllCardsCount:  2,
+		CorrectCards:   []int{france.LocalID},
+		IncorrectCards: []int{italy.LocalID},
+		Errors:         make([]domain.ErrorItem, 0),
+		RightAnswers:   make([]domain.RightAnswerItem, 0),
+	}, nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	var history domain.UserHistoryArray
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/user/history", nil, &history))
+	require.Equal(t, 1, history.Count)
+	assert.Equal(t, collectionID, history.Items[0].CollectionID)
+
+	var me domain.UserInfo
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/user", nil, &me))
+	assert.Equal(t, signup.UserID, me.ID)
+	assert.Equal(t, 1, me.Statistics.TotalTrainings)
+	var collection domain.CollectionInfo
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/collection/"+collectionID, nil, &collection))
+	assert.Equal(t, 1, collection.Trainings)
+	assert.Len(t, collection.Cards, 2)
+
+	// the private collection is closed to the other users and anonymous requests
+	reader, _ := signUp(t, server, "reader")
+	reader.fails(http.MethodGet, "/collection/"+collectionID, nil, http.StatusForbidden, domain.ErrNotCollectionOwner)
+	reader.fails(http.MethodDelete, "/collection/"+collectionID, nil, http.StatusForbidden, domain.ErrNotCollectionOwner)
+	reader.fails(http.MethodGet, cardPath(collectionID, france.LocalID)+"/picture?object_name="+picture.ObjectName,
+		nil, http.StatusForbidden, domain.ErrAccessDenied)
+	anonymous := &apiUser{t: t, baseURL: server.URL}
+	anonymous.fails(http.MethodGet, "/collection/"+collectionID, nil, http.StatusUnauthorized, domain.ErrNotAuthorized)
+
+	require.Equal(t, http.StatusOK, author.json(http.MethodDelete, "/collection/"+collectionID, nil, nil))
+	author.fails(http.MethodGet, "/collection/"+collectionID, nil, http.StatusNotFound, domain.ErrCollectionNotFound)
+	_, err := server.Storage.GetObject(context.Background(), domain.CollectionBucket, mediaID(t, testJPEG))
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound, "the pictures are deleted with the collection")
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/user", nil, &me))
+	assert.Empty(t, me.Collections)
+}
+
+func TestIntegration_FileSizeQuota(t *testing.T) {
+	white, gray := grayJPEG(255), grayJPEG(128)
+	quota := domain.MAX_TOTAL_FILE_SIZE
+	domain.MAX_TOTAL_FILE_SIZE = len(stored(t, testJPEG)) + len(stored(t, white))
+	t.Cleanup(func() { domain.MAX_TOTAL_FILE_SIZE = quota })
+
+	server := newTestServer(t, nil)
+	author, _ := signUp(t, server, "author")
+	collectionID := createCollection(t, author, "Paintings", true)
+	cards := []domain.Card{
+		addCard(t, author, collectionID, "Mona Lisa", "Leonardo"),
+		addCard(t, author, collectionID, "Sunflowers", "Van Gogh"),
+		addCard(t, author, collectionID, "The Scream", "Munch"),
+	}
+	upload := func(cardID int, picture []byte) (int, []byte) {
+		return author.upload(cardPath(collectionID, cardID)+"/picture", "picture.jpg", picture)
+	}
+
+	status, _ := upload(cards[0].LocalID, gray)
+	require.Equal(t, http.StatusOK, status)
+	// replacing a picture frees the space of the previous one
+	status, _ = upload(cards[0].LocalID, white)
+	require.Equal(t, http.StatusOK, status)
+	second, status := author.uploadCardPicture(collectionID, cards[1].LocalID)
+	require.Equal(t, http.StatusOK, status)
+	// the same picture is stored once and is charged once
+	status, _ = upload(cards[2].LocalID, white)
+	require.Equal(t, http.StatusOK, status)
+
+	status, body := upload(cards[2].LocalID, gray)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Contains(t, string(body), domain.ErrFileSizeLimit.Code)
+
+	status = author.json(http.MethodDelete,
+		cardPath(collectionID, cards[1].LocalID)+"/picture?object_name="+second.ObjectName, nil, nil)
+	require.Equal(t, http.StatusOK, status)
+	status, _ = upload(cards[2].LocalID, gray)
+	assert.Equal(t, http.StatusOK, status)
+
+	// only the author uploads to the collection, even a public one
+	reader, _ := signUp(t, server, "reader")
+	status, body = reader.upload(cardPath(collectionID, cards[1].LocalID)+"/picture", "picture.jpg", testJPEG)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Contains(t, string(body), domain.ErrAccessDenied.Code)
+}
+
+func TestIntegration_UploadRateLimit(t *testing.T) {
+	server := newTestServer(t, func(env *bootstrap.Env) {
+		env.RateLimitUploadPerMinute = 1
+		env.RateLimitUploadBurst = 1
+	})
+	author, _ := signUp(t, server, "author")
+	collectionID := createCollection(t, author, "Paintings", false)
+	card := addCard(t, author, collectionID, "Mona Lisa", "Leonardo")
+
+	_, status := author.uploadCardPicture(collectionID, card.LocalID)
+	require.Equal(t, http.StatusOK, status)
+	_, status = author.uploadCardPicture(collectionID, card.LocalID)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+
+	// the other users have their own limit
+	other, _ := signUp(t, server, "other")
+	otherCollectionID := createCollection(t, other, "Sculptures", false)
+	otherCard := addCard(t, other, otherCollectionID, "David", "Michelangelo")
+	_, status = other.uploadCardPicture(otherCollectionID, otherCard.LocalID)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestIntegration_PictureStreaming(t *testing.T) {
+	server := newTestServer(t, nil)
+	user, _ := signUp(t, server, "painter")
+	status, _ := user.upload("/user/picture", "me.jpg", testJPEG)
+	require.Equal(t, http.StatusOK, status)
+
+	picture := stored(t, testJPEG)
+	resp, body := user.do(http.MethodGet, "/user/picture", http.Header{}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, picture, body)
+	assert.Equal(t, "image/jpeg", resp.Header.Get("Content-Type"))
+	assert.Equal(t, strconv.Itoa(len(picture)), resp.Header.Get("Content-Length"))
+	assert.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	require.NotEmpty(t, etag)
+	require.NotEmpty(t, lastModified)
+
+	resp, body = user.do(http.MethodGet, "/user/picture", http.Header{"If-None-Match": {etag}}, nil)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Empty(t, body)
+	resp, _ = user.do(http.MethodGet, "/user/picture", http.Header{"If-Modified-Since": {lastModified}}, nil)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	resp, body = user.do(http.MethodGet, "/user/picture", http.Header{"Range": {"bytes=0-3"}}, nil)
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, picture[:4], body)
+	assert.Equal(t, "bytes 0-3/"+strconv.Itoa(len(picture)), resp.Header.Get("Content-Range"))
+	resp, _ = user.do(http.MethodGet, "/user/picture", http.Header{"Range": {"bytes=100000-200000"}}, nil)
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+
+	// a new picture doesn't match the old ETag
+	other := encodeJPEG(image.NewGray(image.Rect(0, 0, 16, 16)))
+	status, _ = user.upload("/user/picture", "me.jpg", other)
+	require.Equal(t, http.StatusOK, status)
+	resp, body = user.do(http.MethodGet, "/user/picture", http.Header{"If-None-Match": {etag}}, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, stored(t, other), body)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+}
+
+func TestIntegration_PictureTypes(t *testing.T) {
+	server := newTestServer(t, nil)
+	user, _ := signUp(t, server, "painter")
+
+	// the type is told by the content, not by the name
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, image.NewGray(image.Rect(0, 0, 8, 8))))
+	status, _ := user.upload("/user/picture", "me.jpg", pngData.Bytes())
+	require.Equal(t, http.StatusOK, status)
+	resp, body := user.do(http.MethodGet, "/user/picture", http.Header{}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	_, err := png.Decode(bytes.NewReader(body))
+	assert.NoError(t, err)
+
+	collectionID := createCollection(t, user, "Paintings", false)
+	card := addCard(t, user, collectionID, "Mona Lisa", "Leonardo")
+	var gifData bytes.Buffer
+	require.NoError(t, gif.Encode(&gifData, image.NewGray(image.Rect(0, 0, 8, 8)), nil))
+	status, body = user.upload(cardPath(collectionID, card.LocalID)+"/picture", "mona.jpg", gifData.Bytes())
+	require.Equal(t, http.StatusOK, status)
+	var picture domain.UploadCardPhotoResult
+	require.NoError(t, json.Unmarshal(body, &picture))
+	resp, _ = user.do(http.MethodGet,
+		cardPath(collectionID, card.LocalID)+"/picture?object_name="+picture.ObjectName, http.Header{}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/gif", resp.Header.Get("Content-Type"))
+
+	status, body = user.upload("/user/picture", "me.jpg", []byte("definitely not a picture"))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), domain.ErrInvalidImageType.Code)
+	status, body = user.upload("/user/picture", "me.heic", []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic"))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), domain.ErrUnsupportedImageType.Code)
+
+	// the profile and the cards share the limit
+	for _, size := range []int{domain.MaxPictureSize + 1, 2 * domain.MaxPictureSize} {
+		large := make([]byte, size)
+		status, body = user.upload("/user/picture", "me.jpg", large)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, string(body), domain.ErrImageTooLarge.Code)
+		status, body = user.upload(cardPath(collectionID, card.LocalID)+"/picture", "mona.jpg", large)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, string(body), domain.ErrImageTooLarge.Code)
+	}
+}
+
+func TestIntegration_PictureVariants(t *testing.T) {
+	painting := encodeJPEG(image.NewGray(image.Rect(0, 0, 1200, 600)))
+	quota := domain.MAX_TOTAL_FILE_SIZE
+	// the variants don't count, the quota is the original only
+	domain.MAX_TOTAL_FILE_SIZE = len(stored(t, painting))
+	t.Cleanup(func() { domain.MAX_TOTAL_FILE_SIZE = quota })
+
+	server := newTestServer(t, nil)
+	author, _ := signUp(t, server, "author")
+	collectionID := createCollection(t, author, "Paintings", false)
+	card := addCard(t, author, collectionID, "Mona Lisa", "Leonardo")
+
+	status, body := author.upload(cardPath(collectionID, card.LocalID)+"/picture", "mona.jpg", painting)
+	require.Equal(t, http.StatusOK, status)
+	var picture domain.UploadCardPhotoResult
+	require.NoError(t, json.Unmarshal(body, &picture))
+	path := cardPath(collectionID, card.LocalID) + "/picture?object_name=" + picture.ObjectName
+
+	for size, width := range map[string]int{"thumb": 256, "medium": 1024, "original": 1200} {
+		resp, body := author.do(http.MethodGet, path+"&size="+size, http.Header{}, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, size)
+		assert.Equal(t, "image/jpeg", resp.Header.Get("Content-Type"), size)
+		config, err := jpeg.DecodeConfig(bytes.NewReader(body))
+		require.NoError(t, err, size)
+		assert.Equal(t, width, config.Width, size)
+	}
+	resp, _ := author.do(http.MethodGet, path+"&size=huge", http.Header{}, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	status, _ = author.upload(cardPath(collectionID, card.LocalID)+"/picture", "mona.jpg", painting)
+	assert.Equal(t, http.StatusOK, status, "the replaced original frees all the quota")
+
+	status, _ = author.upload("/user/picture", "me.jpg", testJPEG)
+	require.Equal(t, http.StatusOK, status)
+	resp, body = author.do(http.MethodGet, "/user/picture?size=thumb", http.Header{}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, stored(t, testJPEG), body, "the small picture is served as it is")
+}
+
+// encodeWAV is mono 8-bit PCM at 8 kHz of silence, a byte a sample
+func encodeWAV(samples int) []byte {
+	format := binary.LittleEndian.AppendUint16(nil, 1)
+	format = binary.LittleEndian.AppendUint16(format, 1)
+	format = binary.LittleEndian.AppendUint32(format, 8000)
+	format = binary.LittleEndian.AppendUint32(format, 8000)
+	format = binary.LittleEndian.AppendUint16(format, 1)
+	format = binary.LittleEndian.AppendUint16(format, 8)
+
+	body := append([]byte("WAVEfmt "), binary.LittleEndian.AppendUint32(nil, uint32(len(format)))...)
+	body = append(body, format...)
+	body = append(body, "data"...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(samples))
+	body = append(body, bytes.Repeat([]byte{0x80}, samples)...)
+	return append(binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body))), body...)
+}
+
+func TestIntegration_CardAudio(t *testing.T) {
+	question, answer := encodeWAV(8000), encodeWAV(4000)
+	quota := domain.MAX_TOTAL_FILE_SIZE
+	domain.MAX_TOTAL_FILE_SIZE = len(question) + len(answer)
+	t.Cleanup(func() { domain.MAX_TOTAL_FILE_SIZE = quota })
+
+	server := newTestServer(t, nil)
+	author, _ := signUp(t, server, "author")
+	collectionID := createCollection(t, author, "Birds", true)
+	card := addCard(t, author, collectionID, "Nightingale", "Luscinia megarhynchos")
+	path := cardPath(collectionID, card.LocalID) + "/audio?side="
+
+	status, body := author.uploadFile(http.MethodPut, path+"question", "audio", "song.mp3", question)
+	require.Equal(t, http.StatusOK, status, string(body))
+	var uploaded domain.UploadCardAudioResult
+	require.NoError(t, json.Unmarshal(body, &uploaded))
+	assert.Equal(t, int64(1000), uploaded.DurationMs)
+	status, _ = author.uploadFile(http.MethodPut, path+"answer", "audio", "name.wav", answer)
+	require.Equal(t, http.StatusOK, status)
+
+	var got domain.Collection
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/collection/"+collectionID, nil, &got))
+	require.Len(t, got.Cards, 1)
+	assert.Equal(t, uploaded.ObjectName, got.Cards[0].QuestionAudio)
+	assert.NotEmpty(t, got.Cards[0].AnswerAudio)
+
+	// the readers of a public collection listen to it too
+	reader, _ := signUp(t, server, "reader")
+	resp, body := reader.do(http.MethodGet, path+"question", http.Header{}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, question, body)
+	assert.Equal(t, "audio/wav", resp.Header.Get("Content-Type"))
+	resp, body = reader.do(http.MethodGet, path+"answer", http.Header{"Range": {"bytes=0-11"}}, nil)
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, answer[:12], body)
+
+	status, body = reader.uploadFile(http.MethodPut, path+"question", "audio", "song.wav", answer)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Contains(t, string(body), domain.ErrAccessDenied.Code)
+	reader.fails(http.MethodDelete, path+"answer", nil, http.StatusForbidden, domain.ErrAccessDenied)
+
+	// the quota is shared with the pictures, replacing the audio frees the space of the previous one
+	status, _ = author.uploadFile(http.MethodPut, path+"question", "audio", "song.wav", question)
+	require.Equal(t, http.StatusOK, status)
+	status, body = author.upload(cardPath(collectionID, card.LocalID)+"/picture", "bird.jpg", testJPEG)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Contains(t, string(body), domain.ErrFileSizeLimit.Code)
+
+	status, body = author.uploadFile(http.MethodPut, path+"question", "audio", "song.mp3",
+		[]byte("definitely not a sound"))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), domain.ErrInvalidAudioType.Code)
+	status, body = author.uploadFile(http.MethodPut, path+"question", "audio", "song.wav", encodeWAV(6*60*8000))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), domain.ErrAudioTooLarge.Code)
+	status, body = author.uploadFile(http.MethodPut, path+"question", "audio", "song.wav",
+		make([]byte, 2*domain.MaxAudioSize))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), domain.ErrAudioTooLarge.Code)
+	resp, _ = author.do(http.MethodGet, cardPath(collectionID, card.LocalID)+"/audio?side=both", http.Header{}, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var deleted map[string]string
+	require.Equal(t, http.StatusOK, author.json(http.MethodDelete, path+"answer", nil, &deleted))
+	author.fails(http.MethodGet, path+"answer", nil, http.StatusNotFound, domain.ErrCardAudioNotFound)
+	author.fails(http.MethodDelete, path+"answer", nil, http.StatusNotFound, domain.ErrCardAudioNotFound)
+	_, status = author.uploadCardPicture(collectionID, card.LocalID)
+	assert.Equal(t, http.StatusOK, status, "the deleted audio frees its space")
+
+	// the audio goes with its card
+	other := addCard(t, author, collectionID, "Robin", "Erithacus rubecula")
+	require.Equal(t, http.StatusOK, author.json(http.MethodDelete, cardPath(collectionID, card.LocalID), nil, nil))
+	// the whole quota is free again
+	whole := encodeWAV(len(question) + len(answer) - 44)
+	status, _ = author.uploadFile(http.MethodPut, cardPath(collectionID, other.LocalID)+"/audio?side=answer",
+		"audio", "song.wav", whole)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestIntegration_CardAttachments(t *testing.T) {
+	server := newTestServer(t, nil)
+	author, _ := signUp(t, server, "author")
+	collectionID := createCollection(t, author, "Paintings", true)
+	card := addCard(t, author, collectionID, "Mona Lisa", "Leonardo")
+	other := addCard(t, author, collectionID, "Sunflowers", "Van Gogh")
+	path := cardPath(collectionID, card.LocalID) + "/attachment"
+
+	add := func(side string, data []byte) domain.CardAttachment {
+		t.Helper()
+		status, body := author.uploadFile(http.MethodPost, path+"?side="+side, "image", "picture.jpg", data)
+		require.Equal(t, http.StatusCreated, status, string(body))
+		var attachment domain.CardAttachment
+		require.NoError(t, json.Unmarshal(body, &attachment))
+		return attachment
+	}
+	painting := encodeJPEG(image.NewGray(image.Rect(0, 0, 600, 300)))
+	question := add("question", testJPEG)
+	answer := add("answer", painting)
+	assert.Equal(t, domain.CardAttachment{
+		ID:        answer.ID,
+		Side:      domain.CardSideAnswer,
+		MediaType: "image/jpeg",
+		Size:      int64(len(stored(t, painting))),
+		Order:     1,
+	}, answer)
+
+	var got domain.Collection
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/collection/"+collectionID, nil, &got))
+	assert.Equal(t, []domain.CardAttachment{question, answer}, got.Cards[0].Attachments)
+
+	// the readers of a public collection see the pictures
+	reader, _ := signUp(t, server, "reader")
+	resp, body := reader.do(http.MethodGet, path+"/"+question.ID, http.Header{}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, stored(t, testJPEG), body)
+	resp, body = reader.do(http.MethodGet, path+"/"+answer.ID+"?size=thumb", http.Header{}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	config, err := jpeg.DecodeConfig(bytes.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, 256, config.Width)
+	// the old path serves them by the ID
+	resp, _ = reader.do(http.MethodGet,
+		cardPath(collectionID, card.LocalID)+"/picture?object_name="+answer.ID, http.Header{}, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	// an attachment is served with its own card only
+	reader.fails(http.MethodGet, cardPath(collectionID, other.LocalID)+"/attachment/"+answer.ID, nil,
+		http.StatusNotFound, domain.ErrCardPictureNotFound)
+
+	status, body := reader.uploadFile(http.MethodPost, path+"?side=question", "image", "picture.jpg", testJPEG)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Contains(t, string(body), domain.ErrAccessDenied.Code)
+	reader.fails(http.MethodPut, path+"/order", domain.ReorderAttachmentsRequest{IDs: []string{answer.ID, question.ID}},
+		http.StatusForbidden, domain.ErrAccessDenied)
+	reader.fails(http.MethodDelete, path+"/"+answer.ID, nil, http.StatusForbidden, domain.ErrAccessDenied)
+
+	var reordered []domain.CardAttachment
+	status = author.json(http.MethodPut, path+"/order",
+		domain.ReorderAttachmentsRequest{IDs: []string{answer.ID, question.ID}}, &reordered)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{answer.ID, question.ID}, []string{reordered[0].ID, reordered[1].ID})
+	assert.Equal(t, 0, reordered[0].Order)
+	author.fails(http.MethodPut, path+"/order", domain.ReorderAttachmentsRequest{IDs: []string{answer.ID}},
+		http.StatusBadRequest, domain.ErrInvalidData)
+
+	// the old upload replaces the first picture of the question side only
+	legacy, status := author.uploadCardPicture(collectionID, card.LocalID)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/collection/"+collectionID, nil, &got))
+	require.Len(t, got.Cards[0].Attachments, 2)
+	assert.Equal(t, answer.ID, got.Cards[0].Attachments[0].ID)
+	assert.Equal(t, legacy.ObjectName, got.Cards[0].Attachments[1].ID)
+
+	var deleted domain.SuccessResponse
+	require.Equal(t, http.StatusOK, author.json(http.MethodDelete, path+"/"+answer.ID, nil, &deleted))
+	assert.Equal(t, "Card attachment deleted", deleted.Message)
+	author.fails(http.MethodGet, path+"/"+answer.ID, nil, http.StatusNotFound, domain.ErrCardPictureNotFound)
+	author.fails(http.MethodDelete, path+"/"+answer.ID, nil, http.StatusNotFound, domain.ErrCardPictureNotFound)
+	status, body = author.uploadFile(http.MethodPost, path+"?side=both", "image", "picture.jpg", testJPEG)
+	assert.Equal(t, http.StatusBadRequest, status, string(body))
+}
+
+// presigningStorage is the in-memory storage with its own server the presigned URLs lead to, as MinIO has.
+// The URLs are not signed, anyone can use them, but the posted objects are limited in size as by the policy.
+type presigningStorage struct {
+	storage.Client
+	url string
+	// maxSizes are the limits of the presigned posts by the bucket and the name of the object
+	maxSizes sync.Map
+}
+
+func newPresigningStorage(t *testing.T) *presigningStorage {
+	t.Helper()
+	ps := &presigningStorage{Client: storage.NewMemoryClient()}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucket, objectName, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		switch r.Method {
+		case http.MethodPost:
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			defer file.Close()
+			objectName = r.FormValue("key")
+			maxSize, ok := ps.maxSizes.Load(bucket + "/" + objectName)
+			if !ok || header.Size > maxSize.(int64) {
+				http.Error(w, "EntityTooLarge", http.StatusBadRequest)
+				return
+			}
+			err = ps.PutObject(r.Context(), bucket, objectName, file, header.Size, "")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			object, err := ps.GetObject(r.Context(), bucket, objectName)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			defer object.Close()
+			w.Header().Set("Content-Type", object.ContentType)
+			io.Copy(w, object)
+		}
+	}))
+	t.Cleanup(server.Close)
+	ps.url = server.URL
+	return ps
+}
+
+func (ps *presigningStorage) PresignPost(
+	_ context.Context,
+	bucket string,
+	objectName string,
+	_ time.Duration,
+	maxSize int64,
+) (string, map[string]string, error) {
+	ps.maxSizes.Store(bucket+"/"+objectName, maxSize)
+	return ps.url + "/" + bucket, map[string]string{"key": objectName}, nil
+}
+
+func (ps *presigningStorage) PresignGet(
+	_ context.Context,
+	bucket string,
+	objectName string,
+	_ time.Duration,
+) (string, error) {
+	return ps.url + "/" + bucket + "/" + objectName, nil
+}
+
+// direct sends the request to the storage by the presigned URL, without the credentials of the API.
+func direct(t *testing.T, method string, url string, body []byte) []byte {
+	t.Helper()
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return data
+}
+
+// post sends the picture to the storage by the presigned form of the ticket, it returns the status.
+func post(t *testing.T, ticket domain.UploadTicket, picture []byte) int {
+	t.Helper()
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	for name, value := range ticket.Fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	part, err := writer.CreateFormFile("file", "picture")
+	require.NoError(t, err)
+	_, err = part.Write(picture)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	resp, err := http.Post(ticket.URL, writer.FormDataContentType(), &form)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestIntegration_DirectUpload(t *testing.T) {
+	painting := encodeJPEG(image.NewGray(image.Rect(0, 0, 600, 300)))
+	quota := domain.MAX_TOTAL_FILE_SIZE
+	domain.MAX_TOTAL_FILE_SIZE = len(stored(t, painting))
+	t.Cleanup(func() { domain.MAX_TOTAL_FILE_SIZE = quota })
+
+	presigning := newPresigningStorage(t)
+	server := newTestServerOn(t, presigning, nil)
+	author, _ := signUp(t, server, "author")
+	collectionID := createCollection(t, author, "Paintings", false)
+	card := addCard(t, author, collectionID, "Mona Lisa", "Leonardo")
+	other := addCard(t, author, collectionID, "Sunflowers", "Van Gogh")
+	path := cardPath(collectionID, card.LocalID) + "/attachment/upload"
+
+	var ticket domain.UploadTicket
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, path+"?side=answer", nil, &ticket))
+	assert.False(t, ticket.Proxy)
+	assert.Equal(t, http.MethodPost, ticket.Method)
+	assert.Equal(t, presigning.url+"/"+domain.UploadBucket, ticket.URL)
+	assert.Equal(t, map[string]string{"key": ticket.ID}, ticket.Fields)
+	assert.InDelta(t, time.Now().Add(domain.PresignExpiry).Unix(), ticket.ExpiresAt, 5)
+	completePath := path + "/" + ticket.ID + "/complete"
+	author.fails(http.MethodPost, completePath, nil, http.StatusConflict, domain.ErrUploadIncomplete)
+
+	// the storage refuses a picture larger than the limit itself
+	assert.Equal(t, http.StatusBadRequest, post(t, ticket, make([]byte, domain.MaxPictureSize+1)))
+	author.fails(http.MethodPost, completePath, nil, http.StatusConflict, domain.ErrUploadIncomplete)
+	require.Equal(t, http.StatusNoContent, post(t, ticket, painting))
+	// the upload is completed for its card by its user only
+	author.fails(http.MethodPost, cardPath(collectionID, other.LocalID)+"/attachment/upload/"+ticket.ID+"/complete",
+		nil, http.StatusNotFound, domain.ErrUploadNotFound)
+	stranger, _ := signUp(t, server, "stranger")
+	stranger.fails(http.MethodPost, completePath, nil, http.StatusForbidden, domain.ErrAccessDenied)
+
+	var attachment domain.CardAttachment
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, completePath, nil, &attachment))
+	assert.Equal(t, domain.CardSideAnswer, attachment.Side)
+	assert.Equal(t, int64(len(stored(t, painting))), attachment.Size, "the picture is cleaned as any other")
+	author.fails(http.MethodPost, completePath, nil, http.StatusNotFound, domain.ErrUploadNotFound)
+	_, err := presigning.StatObject(context.Background(), domain.UploadBucket, ticket.ID)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound, "the upload is removed")
+
+	// the picture is got from the storage directly too, the variant is made for the URL
+	var presigned domain.PresignedURL
+	status := author.json(http.MethodGet, cardPath(collectionID, card.LocalID)+"/attachment/"+attachment.ID+
+		"/url?size=thumb", nil, &presigned)
+	require.Equal(t, http.StatusOK, status)
+	assert.False(t, presigned.Proxy)
+	assert.Equal(t, http.MethodGet, presigned.Method)
+	config, err := jpeg.DecodeConfig(bytes.NewReader(direct(t, http.MethodGet, presigned.URL, nil)))
+	require.NoError(t, err)
+	assert.Equal(t, 256, config.Width)
+
+	// the completed upload counts toward the quota, the upload that is not stored is kept to be completed again
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, path+"?side=question", nil, &ticket))
+	require.Equal(t, http.StatusNoContent, post(t, ticket, testJPEG))
+	author.fails(http.MethodPost, path+"/"+ticket.ID+"/complete", nil,
+		http.StatusRequestEntityTooLarge, domain.ErrFileSizeLimit)
+	domain.MAX_TOTAL_FILE_SIZE = quota
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, path+"/"+ticket.ID+"/complete", nil, &attachment))
+	assert.Equal(t, domain.CardSideQuestion, attachment.Side)
+
+	// the uploaded file is checked as the one sent to the server
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, "/user/picture/upload", nil, &ticket))
+	require.Equal(t, http.StatusNoContent, post(t, ticket, []byte("definitely not a picture")))
+	author.fails(http.MethodPost, "/user/picture/upload/"+ticket.ID+"/complete", nil,
+		http.StatusBadRequest, domain.ErrInvalidImageType)
+	// the picture larger than the limit is refused by the server too, if the storage took it
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, "/user/picture/upload", nil, &ticket))
+	tooLarge := make([]byte, domain.MaxPictureSize+1)
+	require.NoError(t, presigning.PutObject(context.Background(), domain.UploadBucket, ticket.ID,
+		bytes.NewReader(tooLarge), int64(len(tooLarge)), ""))
+	author.fails(http.MethodPost, "/user/picture/upload/"+ticket.ID+"/complete", nil,
+		http.StatusBadRequest, domain.ErrImageTooLarge)
+	author.fails(http.MethodPost, "/user/picture/upload/"+ticket.ID+"/complete", nil,
+		http.StatusNotFound, domain.ErrUploadNotFound)
+
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, "/user/picture/upload", nil, &ticket))
+	require.Equal(t, http.StatusNoContent, post(t, ticket, testJPEG))
+	var done domain.SuccessResponse
+	status = author.json(http.MethodPost, "/user/picture/upload/"+ticket.ID+"/complete", nil, &done)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/user/picture/url", nil, &presigned))
+	assert.Equal(t, stored(t, testJPEG), direct(t, http.MethodGet, presigned.URL, nil))
+}
+
+func TestIntegration_ProxyUpload(t *testing.T) {
+	server := newTestServer(t, nil)
+	author, _ := signUp(t, server, "author")
+	collectionID := createCollection(t, author, "Paintings", false)
+	card := addCard(t, author, collectionID, "Mona Lisa", "Leonardo")
+	path := cardPath(collectionID, card.LocalID) + "/attachment/upload"
+	// the URLs are the paths of the API with its version
+	root := &apiUser{t: t, baseURL: strings.TrimSuffix(server.URL, "/v1"), accessToken: author.accessToken}
+
+	var ticket domain.UploadTicket
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, path+"?side=question", nil, &ticket))
+	assert.True(t, ticket.Proxy)
+	assert.Equal(t, "/v1"+path+"/"+ticket.ID, ticket.URL)
+
+	tooLarge := make([]byte, domain.MaxPictureSize+1)
+	status, body := root.send(http.MethodPut, ticket.URL, "application/octet-stream", tooLarge)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, string(body), domain.ErrImageTooLarge.Code)
+	status, body = root.send(http.MethodPut, ticket.URL, "application/octet-stream", testJPEG)
+	require.Equal(t, http.StatusOK, status, string(body))
+	stranger, _ := signUp(t, server, "stranger")
+	stranger.baseURL = root.baseURL
+	status, body = stranger.send(http.MethodPut, ticket.URL, "image/jpeg", testJPEG)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, string(body), domain.ErrUploadNotFound.Code)
+
+	var attachment domain.CardAttachment
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, path+"/"+ticket.ID+"/complete", nil, &attachment))
+
+	var presigned domain.PresignedURL
+	attachmentPath := cardPath(collectionID, card.LocalID) + "/attachment/" + attachment.ID
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, attachmentPath+"/url?size=thumb", nil, &presigned))
+	assert.Equal(t, domain.PresignedURL{
+		URL:    "/v1" + attachmentPath + "?size=thumb",
+		Method: http.MethodGet,
+		Proxy:  true,
+	}, presigned)
+	resp, body := root.do(http.MethodGet, presigned.URL, http.Header{}, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, stored(t, testJPEG), body)
+	author.fails(http.MethodGet, cardPath(collectionID, card.LocalID)+"/attachment/"+ticket.ID+"/url", nil,
+		http.StatusNotFound, domain.ErrCardPictureNotFound)
+
+	require.Equal(t, http.StatusCreated, author.json(http.MethodPost, "/user/picture/upload", nil, &ticket))
+	assert.Equal(t, "/v1/user/picture/upload/"+ticket.ID, ticket.URL)
+	status, _ = root.send(http.MethodPut, ticket.URL, "image/jpeg", testJPEG)
+	require.Equal(t, http.StatusOK, status)
+	// an upload is completed as what it was started for
+	author.fails(http.MethodPost, path+"/"+ticket.ID+"/complete", nil, http.StatusNotFound, domain.ErrUploadNotFound)
+	status = author.json(http.MethodPost, "/user/picture/upload/"+ticket.ID+"/complete", nil, nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, http.StatusOK, author.json(http.MethodGet, "/user/picture/url", nil, &presigned))
+	assert.Equal(t, "/v1/user/picture", presigned.URL)
+}
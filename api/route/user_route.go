@@ -2,8 +2,11 @@ package route
 
 import (
 	"main/api/controller"
+	"main/bootstrap"
 	"main/database"
 	"main/domain"
+	"main/mail"
+	"main/oauth"
 	"main/repository"
 	"main/storage"
 	"main/usecase"
@@ -12,7 +15,18 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func NewUserRouter(timeout time.Duration, db database.Database, s storage.Client, r chi.Router) {
+func NewUserRouter(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db database.Database,
+	s storage.Client,
+	m mail.Sender,
+	p oauth.Providers,
+	l RateLimiters,
+	su domain.SessionUseCase,
+	la domain.LoginAttemptRepository,
+	r chi.Router,
+) {
 	ur := repository.NewUserRepository(db, domain.UserCollection)
 	us := storage.NewUserStorage(s, domain.UserBucket)
 	cs := storage.NewCollectionStorage(s, domain.CollectionBucket)
@@ -21,22 +35,75 @@ func NewUserRouter(timeout time.Duration, db database.Database, s storage.Client
 	chr := repository.NewCollectionHistoryRepository(db, domain.CollectionHistoryCollection)
 
 	cr := repository.NewCollectionRepository(db, domain.CollectionCollection)
+	mr := repository.NewMediaRepository(db, domain.MediaCollection)
+	sr := repository.NewSessionRepository(db, domain.SessionCollection)
+	upr := repository.NewUploadRepository(db, domain.UploadCollection)
+	ups := storage.NewUploadStorage(s, domain.UploadBucket)
+	utr := repository.NewUserTokenRepository(db, domain.UserTokenCollection)
 
 	uc := &controller.UserController{
-		UserUseCase:       usecase.NewUserUseCase(ur, us, timeout),
-		CollectionUseCase: usecase.NewCollectionUseCase(cr, cs, ur, timeout),
-		HistoryUseCase:    usecase.NewHistoryUseCase(uhr, chr, cr, ur, timeout),
+		UserUseCase:         usecase.NewUserUseCase(ur, us, timeout),
+		CollectionUseCase:   usecase.NewCollectionUseCase(cr, cs, mr, ur, timeout),
+		HistoryUseCase:      usecase.NewHistoryUseCase(uhr, chr, cr, ur, timeout),
+		SessionUseCase:      su,
+		PasswordUseCase:     usecase.NewPasswordUseCase(ur, utr, sr, m, timeout),
+		VerificationUseCase: usecase.NewVerificationUseCase(ur, utr, m, timeout),
+		UploadUseCase:       usecase.NewUploadUseCase(upr, ups, timeout),
+		Env:                 env,
+	}
+	ir := repository.NewIdentityRepository(db, domain.IdentityCollection)
+	osr := repository.NewOAuthStateRepository(db, domain.OAuthStateCollection)
+	ic := &controller.IdentityController{
+		OAuthUseCase: usecase.NewOAuthUseCase(ur, ir, osr, p, timeout),
+		Env:          env,
+	}
+	akr := repository.NewApiKeyRepository(db, domain.ApiKeyCollection)
+	akc := &controller.ApiKeyController{
+		ApiKeyUseCase: usecase.NewApiKeyUseCase(akr, timeout),
+	}
+	lar := repository.NewLoginAuditRepository(db, domain.LoginAuditCollection)
+	tfc := &controller.TwoFactorController{
+		TwoFactorUseCase:    usecase.NewTwoFactorUseCase(ur, utr, timeout),
+		LoginAttemptUseCase: usecase.NewLoginAttemptUseCase(la, lar, loginAttemptPolicy(env), timeout),
+		Env:                 env,
 	}
 	r.Route("/user", func(r chi.Router) {
 		r.Get("/", uc.Get)
 		r.Put("/", uc.Update)
+		r.Put("/password", uc.ChangePassword)
+		r.Post("/verify-email", uc.ResendVerification)
 		r.Route("/picture", func(r chi.Router) {
 			r.Get("/", uc.GetProfilePicture)
-			r.Put("/", uc.UploadProfilePicture)
+			r.Get("/url", uc.GetProfilePictureURL)
+			r.With(l.Upload).Put("/", uc.UploadProfilePicture)
 			r.Delete("/", uc.RemoveProfilePicture)
+			r.Route("/upload", func(r chi.Router) {
+				r.With(l.Upload).Post("/", uc.StartProfilePictureUpload)
+				r.With(l.Upload).Put("/{uploadID}", uc.PutProfilePictureUpload)
+				r.Post("/{uploadID}/complete", uc.CompleteProfilePictureUpload)
+			})
 		})
 		r.Route("/history", func(r chi.Router) {
 			r.Get("/", uc.GetHistory)
 		})
+		r.Route("/sessions", func(r chi.Router) {
+			r.Get("/", uc.GetSessions)
+			r.Delete("/{id}", uc.RevokeSession)
+		})
+		r.Route("/2fa", func(r chi.Router) {
+			r.Post("/enroll", tfc.Enroll)
+			r.Post("/confirm", tfc.Confirm)
+			r.Post("/disable", tfc.Disable)
+		})
+		r.Route("/api-keys", func(r chi.Router) {
+			r.Get("/", akc.GetApiKeys)
+			r.Post("/", akc.CreateApiKey)
+			r.Delete("/{id}", akc.DeleteApiKey)
+		})
+		r.Route("/identities", func(r chi.Router) {
+			r.Get("/", ic.GetIdentities)
+			r.Post("/{provider}", ic.Link)
+			r.Delete("/{provider}", ic.Unlink)
+		})
 	})
 }
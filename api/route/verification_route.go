@@ -0,0 +1,23 @@
+package route
+
+import (
+	"main/api/controller"
+	"main/database"
+	"main/domain"
+	"main/mail"
+	"main/repository"
+	"main/usecase"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func NewVerificationRouter(timeout time.Duration, db database.Database, m mail.Sender, r chi.Router) {
+	ur := repository.NewUserRepository(db, domain.UserCollection)
+	utr := repository.NewUserTokenRepository(db, domain.UserTokenCollection)
+	vc := &controller.VerificationController{
+		VerificationUseCase: usecase.NewVerificationUseCase(ur, utr, m, timeout),
+	}
+
+	r.Post("/public/verify-email", vc.VerifyEmail)
+}
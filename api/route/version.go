@@ -0,0 +1,30 @@
+package route
+
+import "net/http"
+
+// Version is the version of an API route tree. Legacy is the unversioned tree
+// that is kept for the mobile clients released before /v1.
+type Version int
+
+const (
+	Legacy Version = iota
+	V1
+)
+
+// Handlers are the handlers of a route by the version they were introduced in.
+// When a response shape changes, the new handler is registered for the next
+// version and the older trees keep serving the old shape, e.g.
+//
+//	r.Get("/", Handlers{Legacy: cc.Get, V2: cc.GetV2}.For(v))
+type Handlers map[Version]http.HandlerFunc
+
+// For returns the handler of v or, if the route didn't change in v,
+// of the closest older version.
+func (h Handlers) For(v Version) http.HandlerFunc {
+	for ; v >= Legacy; v-- {
+		if handler, ok := h[v]; ok {
+			return handler
+		}
+	}
+	return nil
+}
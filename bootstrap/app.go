@@ -2,13 +2,21 @@ package bootstrap
 
 import (
 	"main/database"
+	"main/domain"
+	"main/internal"
+	"main/mail"
+	"main/oauth"
 	"main/storage"
 )
 
 type Application struct {
-	Env     *Env
-	Mongo   database.Client
-	Storage storage.Client
+	Env      *Env
+	Mongo    database.Client
+	Storage  storage.Client
+	Mail     mail.Sender
+	OAuth    oauth.Providers
+	Keys     domain.KeySet
+	Contract *internal.Contract
 }
 
 func App() Application {
@@ -16,6 +24,10 @@ func App() Application {
 	app.Env = NewEnv()
 	app.Mongo = NewMongoDatabase(app.Env)
 	app.Storage = NewStorage(app.Env)
+	app.Mail = NewMailSender(app.Env)
+	app.OAuth = NewOAuthProviders(app.Env)
+	app.Keys = NewKeySet(app.Env)
+	app.Contract = NewContract(app.Env)
 	return *app
 }
 
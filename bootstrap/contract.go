@@ -0,0 +1,19 @@
+package bootstrap
+
+import (
+	"main/internal"
+
+	"github.com/gookit/slog"
+)
+
+// NewContract loads the OpenAPI contract the requests are validated against.
+func NewContract(env *Env) *internal.Contract {
+	contract, err := internal.LoadContract(env.ContractPath)
+	if err != nil {
+		slog.Fatal("Can't load the API contract:", err)
+	}
+	if env.ContractValidateResponses {
+		slog.Warn("Responses are validated against the API contract, don't use it in production")
+	}
+	return contract
+}
@@ -8,6 +8,16 @@ import (
 	"github.com/spf13/viper"
 )
 
+// The defaults are used when the settings are missing: a zero expiry would make every token expired at once,
+// a zero cooldown would let the mails be resent without a pause.
+const (
+	defaultResetTokenExpiryMinute      = 15
+	defaultVerificationTokenExpiryHour = 24
+	defaultVerificationCooldownSecond  = 60
+	defaultMFAChallengeExpiryMinute    = 5
+	defaultOAuthStateExpiryMinute      = 10
+)
+
 type Env struct {
 	Port int `mapstructure:"PORT"`
 
@@ -15,6 +25,9 @@ type Env struct {
 	DBPort   int    `mapstructure:"DB_PORT"`
 	MongoDir string `mapstructure:"MONGO_DIR"`
 
+	StorageBackend string `mapstructure:"STORAGE_BACKEND"`
+	StorageDir     string `mapstructure:"STORAGE_DIR"`
+
 	MinioPort         int    `mapstructure:"MINIO_PORT"`
 	MinioDir          string `mapstructure:"MINIO_DIR"`
 	MinioRootUser     string `mapstructure:"MINIO_ROOT_USER"`
@@ -23,8 +36,62 @@ type Env struct {
 	ContextTimeout         int    `mapstructure:"CONTEXT_TIMEOUT"`
 	AccessTokenSecret      string `mapstructure:"ACCESS_TOKEN_SECRET"`
 	AccessTokenExpiryHour  int    `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
+	JWTKeysDir             string `mapstructure:"JWT_KEYS_DIR"`
+	JWTSigningKeyID        string `mapstructure:"JWT_SIGNING_KEY_ID"`
+	JWTAcceptHS256         bool   `mapstructure:"JWT_ACCEPT_HS256"`
 	RefreshTokenSecret     string `mapstructure:"REFRESH_TOKEN_SECRET"`
 	RefreshTokenExpiryHour int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
+	ResetTokenExpiryMinute int    `mapstructure:"RESET_TOKEN_EXPIRY_MINUTE"`
+
+	VerificationTokenExpiryHour int `mapstructure:"VERIFICATION_TOKEN_EXPIRY_HOUR"`
+	VerificationCooldownSecond  int `mapstructure:"VERIFICATION_COOLDOWN_SECOND"`
+
+	LoginFreeAttempts        int `mapstructure:"LOGIN_FREE_ATTEMPTS"`
+	LoginIPFreeAttempts      int `mapstructure:"LOGIN_IP_FREE_ATTEMPTS"`
+	LoginBackoffBaseSecond   int `mapstructure:"LOGIN_BACKOFF_BASE_SECOND"`
+	LoginBackoffMaxSecond    int `mapstructure:"LOGIN_BACKOFF_MAX_SECOND"`
+	LoginLockoutThreshold    int `mapstructure:"LOGIN_LOCKOUT_THRESHOLD"`
+	LoginLockoutMinute       int `mapstructure:"LOGIN_LOCKOUT_MINUTE"`
+	LoginAttemptWindowMinute int `mapstructure:"LOGIN_ATTEMPT_WINDOW_MINUTE"`
+
+	MFAChallengeExpiryMinute int    `mapstructure:"MFA_CHALLENGE_EXPIRY_MINUTE"`
+	TOTPIssuer               string `mapstructure:"TOTP_ISSUER"`
+
+	OAuthRedirectURL       string `mapstructure:"OAUTH_REDIRECT_URL"`
+	OAuthStateExpiryMinute int    `mapstructure:"OAUTH_STATE_EXPIRY_MINUTE"`
+	GoogleClientID         string `mapstructure:"GOOGLE_CLIENT_ID"`
+	GoogleClientSecret     string `mapstructure:"GOOGLE_CLIENT_SECRET"`
+	YandexClientID         string `mapstructure:"YANDEX_CLIENT_ID"`
+	YandexClientSecret     string `mapstructure:"YANDEX_CLIENT_SECRET"`
+	VKClientID             string `mapstructure:"VK_CLIENT_ID"`
+	VKClientSecret         string `mapstructure:"VK_CLIENT_SECRET"`
+
+	RateLimitAuthPerMinute     int `mapstructure:"RATE_LIMIT_AUTH_PER_MINUTE"`
+	RateLimitAuthBurst         int `mapstructure:"RATE_LIMIT_AUTH_BURST"`
+	RateLimitSearchPerMinute   int `mapstructure:"RATE_LIMIT_SEARCH_PER_MINUTE"`
+	RateLimitSearchBurst       int `mapstructure:"RATE_LIMIT_SEARCH_BURST"`
+	RateLimitUploadPerMinute   int `mapstructure:"RATE_LIMIT_UPLOAD_PER_MINUTE"`
+	RateLimitUploadBurst       int `mapstructure:"RATE_LIMIT_UPLOAD_BURST"`
+	RateLimitTrainingPerMinute int `mapstructure:"RATE_LIMIT_TRAINING_PER_MINUTE"`
+	RateLimitTrainingBurst     int `mapstructure:"RATE_LIMIT_TRAINING_BURST"`
+
+	MailSender   string `mapstructure:"MAIL_SENDER"`
+	MailDir      string `mapstructure:"MAIL_DIR"`
+	MailFrom     string `mapstructure:"MAIL_FROM"`
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+
+	ContractPath              string `mapstructure:"CONTRACT_PATH"`
+	ContractValidateResponses bool   `mapstructure:"CONTRACT_VALIDATE_RESPONSES"`
+
+	LegacyAPIDeprecatedAt string `mapstructure:"LEGACY_API_DEPRECATED_AT"`
+	LegacyAPISunsetAt     string `mapstructure:"LEGACY_API_SUNSET_AT"`
+
+	// TrustedProxies are the addresses and CIDR ranges of the reverse proxies,
+	// separated by commas. The client address is taken from their headers only.
+	TrustedProxies string `mapstructure:"TRUSTED_PROXIES"`
 }
 
 func NewEnv() *Env {
@@ -38,6 +105,21 @@ func NewEnv() *Env {
 	if err != nil {
 		slog.Fatal("Environment can't be loaded", err)
 	}
+	if env.ResetTokenExpiryMinute <= 0 {
+		env.ResetTokenExpiryMinute = defaultResetTokenExpiryMinute
+	}
+	if env.VerificationTokenExpiryHour <= 0 {
+		env.VerificationTokenExpiryHour = defaultVerificationTokenExpiryHour
+	}
+	if env.VerificationCooldownSecond <= 0 {
+		env.VerificationCooldownSecond = defaultVerificationCooldownSecond
+	}
+	if env.MFAChallengeExpiryMinute <= 0 {
+		env.MFAChallengeExpiryMinute = defaultMFAChallengeExpiryMinute
+	}
+	if env.OAuthStateExpiryMinute <= 0 {
+		env.OAuthStateExpiryMinute = defaultOAuthStateExpiryMinute
+	}
 	slog.Info(fmt.Sprintf("The T-prep is running in %s env", os.Getenv("APP_ENV")))
 
 	return &env
@@ -0,0 +1,23 @@
+package bootstrap
+
+import (
+	"main/domain"
+	"main/internal"
+
+	"github.com/gookit/slog"
+)
+
+// NewKeySet loads the access token keys. Without JWT_SIGNING_KEY_ID tokens
+// are still signed with ACCESS_TOKEN_SECRET; to rotate, put the new key into
+// JWT_KEYS_DIR, switch JWT_SIGNING_KEY_ID to it and remove the old key once
+// ACCESS_TOKEN_EXPIRY_HOUR has passed.
+func NewKeySet(env *Env) domain.KeySet {
+	keySet, err := internal.LoadKeySet(env.JWTKeysDir, env.JWTSigningKeyID, env.AccessTokenSecret, env.JWTAcceptHS256)
+	if err != nil {
+		slog.Fatal("Can't load JWT keys:", err)
+	}
+	if env.JWTSigningKeyID != "" {
+		slog.Infof("Access tokens are signed with key %s", env.JWTSigningKeyID)
+	}
+	return keySet
+}
@@ -0,0 +1,25 @@
+package bootstrap
+
+import (
+	"main/mail"
+
+	"github.com/gookit/slog"
+)
+
+func NewMailSender(env *Env) mail.Sender {
+	switch env.MailSender {
+	case "smtp":
+		slog.Infof("Mail is sent through %s:%d", env.SMTPHost, env.SMTPPort)
+		return mail.NewSMTPSender(env.SMTPHost, env.SMTPPort, env.SMTPUsername, env.SMTPPassword, env.MailFrom)
+	case "file":
+		sender, err := mail.NewFileSender(env.MailDir)
+		if err != nil {
+			slog.Fatal("Can't create mail directory:", err)
+		}
+		slog.Infof("Mail is written to %s", env.MailDir)
+		return sender
+	default:
+		slog.Println("Mail is written to the log")
+		return mail.NewLogSender()
+	}
+}
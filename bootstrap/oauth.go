@@ -0,0 +1,28 @@
+package bootstrap
+
+import (
+	"main/oauth"
+
+	"github.com/gookit/slog"
+)
+
+// NewOAuthProviders enables every provider that has a client id configured.
+func NewOAuthProviders(env *Env) oauth.Providers {
+	providers := oauth.Providers{}
+	redirect := env.OAuthRedirectURL
+
+	if env.GoogleClientID != "" {
+		providers["google"] = oauth.NewProvider(oauth.Google(env.GoogleClientID, env.GoogleClientSecret, redirect))
+	}
+	if env.YandexClientID != "" {
+		providers["yandex"] = oauth.NewProvider(oauth.Yandex(env.YandexClientID, env.YandexClientSecret, redirect))
+	}
+	if env.VKClientID != "" {
+		providers["vk"] = oauth.NewProvider(oauth.VK(env.VKClientID, env.VKClientSecret, redirect))
+	}
+
+	for name := range providers {
+		slog.Infof("Sign in with %s is enabled", name)
+	}
+	return providers
+}
@@ -15,6 +15,39 @@ func NewStorage(env *Env) storage.Client {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	var client storage.Client
+	switch env.StorageBackend {
+	case "fs":
+		var err error
+		client, err = storage.NewFSClient(env.StorageDir)
+		if err != nil {
+			slog.Fatal("Can't create storage directory:", err)
+		}
+		slog.Infof("Files are stored in %s", env.StorageDir)
+	case "memory":
+		client = storage.NewMemoryClient()
+		slog.Println("Files are stored in memory and are lost on restart")
+	default:
+		client = newMinioStorage(env)
+	}
+
+	for _, bucket := range []string{domain.UserBucket, domain.CollectionBucket, domain.UploadBucket} {
+		found, err := client.BucketExists(ctx, bucket)
+		if err != nil {
+			slog.Fatal(err)
+		}
+		if !found {
+			err = client.MakeBucket(ctx, bucket)
+			if err != nil {
+				slog.Fatal("Can't create bucket:", err)
+			}
+			slog.Infof("Created bucket %q\n", bucket)
+		}
+	}
+	return client
+}
+
+func newMinioStorage(env *Env) storage.Client {
 	var endpoint string
 
 	endpoint, exists := os.LookupEnv("MINIO_URI")
@@ -22,6 +55,9 @@ func NewStorage(env *Env) storage.Client {
 		slog.Fatal("Cannot find MINIO_URI system variable")
 	}
 
+	// the presigned URLs are signed for the endpoint the clients reach, it is the same one if unset
+	publicEndpoint := os.Getenv("MINIO_PUBLIC_URI")
+
 	accessKeyID := env.MinioRootUser
 	secretAccessKey := env.MinioRootPassword
 	useSSL := false
@@ -30,35 +66,12 @@ func NewStorage(env *Env) storage.Client {
 		AccessKeyID:     accessKeyID,
 		SecretAccessKey: secretAccessKey,
 		UseSSL:          useSSL,
+		PublicEndpoint:  publicEndpoint,
 	}
 	minioClient, err := storage.New(endpoint, options)
 	if err != nil {
 		slog.Fatal(err)
 	}
-
-	found, err := minioClient.BucketExists(ctx, domain.UserBucket)
-	if err != nil {
-		slog.Fatal(err)
-	}
-	if !found {
-		err = minioClient.MakeBucket(ctx, domain.UserBucket)
-		if err != nil {
-			slog.Fatal("Can't create bucket:", err)
-		}
-		slog.Infof("Created bucket %q\n", domain.UserBucket)
-	}
-
-	found, err = minioClient.BucketExists(ctx, domain.CollectionBucket)
-	if err != nil {
-		slog.Fatal(err)
-	}
-	if !found {
-		err = minioClient.MakeBucket(ctx, domain.CollectionBucket)
-		if err != nil {
-			slog.Fatal("Can't create bucket:", err)
-		}
-		slog.Infof("Created bucket %q\n", domain.CollectionBucket)
-	}
 	slog.Println("Connected to Minio")
 	return minioClient
 }
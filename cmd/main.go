@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"main/api/route"
 	"main/bootstrap"
+	"main/domain"
+	"main/repository"
+	"main/storage"
+	"main/usecase"
 	"net/http"
 	"os"
 	"time"
@@ -22,13 +27,53 @@ func main() {
 	defer app.CloseDBConnection()
 
 	s3 := app.Storage
+	mailer := app.Mail
+	providers := app.OAuth
+	keys := app.Keys
+	contract := app.Contract
 
 	timeout := time.Duration(env.ContextTimeout) * time.Second
 
+	// the pictures of the cards stored before the attachments list are moved into it
+	collections := usecase.NewCollectionUseCase(
+		repository.NewCollectionRepository(db, domain.CollectionCollection),
+		storage.NewCollectionStorage(s3, domain.CollectionBucket),
+		repository.NewMediaRepository(db, domain.MediaCollection),
+		repository.NewUserRepository(db, domain.UserCollection),
+		timeout,
+	)
+	migrated, err := collections.MigrateAttachments(context.Background())
+	if err != nil {
+		slog.Errorf("Can't migrate the card attachments: %v", err)
+	} else if migrated > 0 {
+		slog.Infof("Moved %d card pictures into the attachments", migrated)
+	}
+
+	// the uploads never completed are removed with their pictures, not only when their user starts another one
+	uploads := usecase.NewUploadUseCase(
+		repository.NewUploadRepository(db, domain.UploadCollection),
+		storage.NewUploadStorage(s3, domain.UploadBucket),
+		timeout,
+	)
+	go sweepUploads(uploads)
+
 	r := chi.NewRouter()
 
-	route.Setup(env, timeout, db, s3, r)
+	route.Setup(env, timeout, db, s3, mailer, providers, keys, contract, r)
 
 	slog.Infof("Listening on port %d", env.Port)
 	slog.FatalErr(http.ListenAndServe(fmt.Sprintf(":%d", env.Port), r))
 }
+
+func sweepUploads(uploads domain.UploadUseCase) {
+	ticker := time.NewTicker(domain.UploadSweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		removed, err := uploads.RemoveExpired(context.Background())
+		if err != nil {
+			slog.Errorf("Can't remove the expired uploads: %v", err)
+		} else if removed > 0 {
+			slog.Infof("Removed %d expired uploads", removed)
+		}
+	}
+}
@@ -0,0 +1,1174 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const duplicateKeyCode = 11000
+
+type memoryClient struct {
+	mu        sync.Mutex
+	databases map[string]*memoryDatabase
+	// transactions run one at a time, like the conflicting ones in MongoDB
+	transactions sync.Mutex
+}
+
+type memoryDatabase struct {
+	client      *memoryClient
+	collections map[string]*memoryCollection
+}
+
+type memoryCollection struct {
+	client *memoryClient
+	docs   []bson.M
+}
+
+type memorySingleResult struct {
+	doc bson.M
+	err error
+}
+
+type memoryCursor struct {
+	docs    []bson.M
+	current int
+}
+
+type memorySession struct {
+	client *memoryClient
+}
+
+type transactionKey struct{}
+
+// memoryTransaction keeps the functions that undo the writes of a transaction.
+type memoryTransaction struct {
+	undo []func()
+}
+
+// NewMemoryClient keeps the documents in the memory of the process. It supports
+// the filter and update operators the repositories use and transactions, which
+// are rolled back on error but are not isolated from the reads outside them.
+// It is meant for tests and local runs, not for production.
+func NewMemoryClient() Client {
+	return &memoryClient{
+		databases: make(map[string]*memoryDatabase),
+	}
+}
+
+func (mc *memoryClient) Database(dbName string) Database {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	db, ok := mc.databases[dbName]
+	if !ok {
+		db = &memoryDatabase{client: mc, collections: make(map[string]*memoryCollection)}
+		mc.databases[dbName] = db
+	}
+	return db
+}
+
+func (mc *memoryClient) Ping(_ context.Context) error {
+	return nil
+}
+
+func (mc *memoryClient) Disconnect(_ context.Context) error {
+	return nil
+}
+
+func (mc *memoryClient) StartSession() (Session, error) {
+	return &memorySession{client: mc}, nil
+}
+
+func (md *memoryDatabase) Collection(colName string) Collection {
+	md.client.mu.Lock()
+	defer md.client.mu.Unlock()
+
+	collection, ok := md.collections[colName]
+	if !ok {
+		collection = &memoryCollection{client: md.client}
+		md.collections[colName] = collection
+	}
+	return collection
+}
+
+func (md *memoryDatabase) Client() Client {
+	return md.client
+}
+
+func (mc *memoryCollection) FindOne(_ context.Context, filter interface{}) SingleResult {
+	mc.client.mu.Lock()
+	defer mc.client.mu.Unlock()
+
+	f, err := normalize(filter)
+	if err != nil {
+		return &memorySingleResult{err: err}
+	}
+	for _, doc := range mc.docs {
+		ok, _, err := matchDocument(doc, f)
+		if err != nil {
+			return &memorySingleResult{err: err}
+		}
+		if ok {
+			return &memorySingleResult{doc: doc}
+		}
+	}
+	return &memorySingleResult{err: mongo.ErrNoDocuments}
+}
+
+func (mc *memoryCollection) Find(
+	_ context.Context,
+	filter interface{},
+	opts ...options.Lister[options.FindOptions],
+) (Cursor, error) {
+	mc.client.mu.Lock()
+	defer mc.client.mu.Unlock()
+
+	f, err := normalize(filter)
+	if err != nil {
+		return nil, err
+	}
+	var docs []bson.M
+	for _, doc := range mc.docs {
+		ok, _, err := matchDocument(doc, f)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			docs = append(docs, doc)
+		}
+	}
+
+	findOptions := &options.FindOptions{}
+	for _, opt := range opts {
+		for _, set := range opt.List() {
+			if err = set(findOptions); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if findOptions.Sort != nil {
+		if err = sortDocuments(docs, findOptions.Sort); err != nil {
+			return nil, err
+		}
+	}
+	if findOptions.Skip != nil {
+		skip := min(max(*findOptions.Skip, 0), int64(len(docs)))
+		docs = docs[skip:]
+	}
+	if findOptions.Limit != nil && *findOptions.Limit != 0 {
+		limit := *findOptions.Limit
+		if limit < 0 {
+			limit = -limit
+		}
+		docs = docs[:min(limit, int64(len(docs)))]
+	}
+	return &memoryCursor{docs: docs, current: -1}, nil
+}
+
+func (mc *memoryCollection) InsertOne(ctx context.Context, document interface{}) (string, error) {
+	mc.client.mu.Lock()
+	defer mc.client.mu.Unlock()
+
+	doc, err := normalize(document)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = bson.NewObjectID().Hex()
+	}
+	for _, d := range mc.docs {
+		if equalValues(d["_id"], doc["_id"]) {
+			return "", mongo.WriteException{WriteErrors: []mongo.WriteError{{
+				Code:    duplicateKeyCode,
+				Message: fmt.Sprintf("E11000 duplicate key error, _id: %v", doc["_id"]),
+			}}}
+		}
+	}
+	mc.docs = append(mc.docs, doc)
+	mc.journal(ctx, func() { mc.remove(doc["_id"]) })
+
+	id, _ := doc["_id"].(string)
+	return id, nil
+}
+
+func (mc *memoryCollection) DeleteOne(ctx context.Context, filter interface{}) (int64, error) {
+	mc.client.mu.Lock()
+	defer mc.client.mu.Unlock()
+
+	f, err := normalize(filter)
+	if err != nil {
+		return 0, err
+	}
+	for i, doc := range mc.docs {
+		ok, _, err := matchDocument(doc, f)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			mc.docs = append(mc.docs[:i:i], mc.docs[i+1:]...)
+			mc.journal(ctx, func() {
+				i = min(i, len(mc.docs))
+				mc.docs = append(mc.docs[:i:i], append([]bson.M{doc}, mc.docs[i:]...)...)
+			})
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (mc *memoryCollection) UpdateOne(
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	_ ...options.Lister[options.UpdateOptions],
+) (UpdateResult, error) {
+	return mc.update(ctx, filter, update, false)
+}
+
+func (mc *memoryCollection) UpdateMany(
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	_ ...options.Lister[options.UpdateOptions],
+) (UpdateResult, error) {
+	return mc.update(ctx, filter, update, true)
+}
+
+func (mc *memoryCollection) ReplaceOne(
+	ctx context.Context,
+	filter interface{},
+	replacement interface{},
+	_ ...options.Lister[options.ReplaceOptions],
+) (UpdateResult, error) {
+	mc.client.mu.Lock()
+	defer mc.client.mu.Unlock()
+
+	f, err := normalize(filter)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+	doc, err := normalize(replacement)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+	for i, d := range mc.docs {
+		ok, _, err := matchDocument(d, f)
+		if err != nil {
+			return UpdateResult{}, err
+		}
+		if !ok {
+			continue
+		}
+		doc["_id"] = d["_id"]
+		if reflect.DeepEqual(d, doc) {
+			return UpdateResult{MatchedCount: 1}, nil
+		}
+		mc.docs[i] = doc
+		mc.journal(ctx, func() { mc.restore(d) })
+		return UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+	}
+	return UpdateResult{}, nil
+}
+
+func (mc *memoryCollection) update(
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	many bool,
+) (UpdateResult, error) {
+	mc.client.mu.Lock()
+	defer mc.client.mu.Unlock()
+
+	f, err := normalize(filter)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+	u, err := normalize(update)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+
+	var res UpdateResult
+	for i, doc := range mc.docs {
+		ok, position, err := matchDocument(doc, f)
+		if err != nil {
+			return res, err
+		}
+		if !ok {
+			continue
+		}
+
+		// the update is applied to a copy, so a failed update leaves the document as is
+		updated, err := normalize(doc)
+		if err != nil {
+			return res, err
+		}
+		err = applyUpdate(updated, u, position)
+		if err != nil {
+			return res, err
+		}
+		res.MatchedCount++
+		if !reflect.DeepEqual(doc, updated) {
+			mc.docs[i] = updated
+			mc.journal(ctx, func() { mc.restore(doc) })
+			res.ModifiedCount++
+		}
+		if !many {
+			break
+		}
+	}
+	return res, nil
+}
+
+// journal keeps the undo of a write made in a transaction.
+func (mc *memoryCollection) journal(ctx context.Context, undo func()) {
+	if tx, ok := ctx.Value(transactionKey{}).(*memoryTransaction); ok {
+		tx.undo = append(tx.undo, undo)
+	}
+}
+
+func (mc *memoryCollection) remove(id interface{}) {
+	for i, doc := range mc.docs {
+		if equalValues(doc["_id"], id) {
+			mc.docs = append(mc.docs[:i:i], mc.docs[i+1:]...)
+			return
+		}
+	}
+}
+
+// restore puts back the previous version of a document.
+func (mc *memoryCollection) restore(previous bson.M) {
+	for i, doc := range mc.docs {
+		if equalValues(doc["_id"], previous["_id"]) {
+			mc.docs[i] = previous
+			return
+		}
+	}
+}
+
+func (sr *memorySingleResult) Decode(v interface{}) error {
+	if sr.err != nil {
+		return sr.err
+	}
+	return decode(sr.doc, v)
+}
+
+func (mc *memoryCursor) Close(_ context.Context) error {
+	return nil
+}
+
+func (mc *memoryCursor) Next(_ context.Context) bool {
+	if mc.current+1 >= len(mc.docs) {
+		return false
+	}
+	mc.current++
+	return true
+}
+
+func (mc *memoryCursor) Decode(v interface{}) error {
+	if mc.current < 0 || mc.current >= len(mc.docs) {
+		return errors.New("no current document")
+	}
+	return decode(mc.docs[mc.current], v)
+}
+
+func (mc *memoryCursor) All(_ context.Context, results interface{}) error {
+	resultsVal := reflect.ValueOf(results)
+	if resultsVal.Kind() != reflect.Ptr || resultsVal.Elem().Kind() != reflect.Slice {
+		return errors.New("results argument must be a pointer to a slice")
+	}
+	sliceVal := resultsVal.Elem()
+	items := reflect.MakeSlice(sliceVal.Type(), len(mc.docs), len(mc.docs))
+	for i, doc := range mc.docs {
+		err := decode(doc, items.Index(i).Addr().Interface())
+		if err != nil {
+			return err
+		}
+	}
+	sliceVal.Set(items)
+	mc.current = len(mc.docs)
+	return nil
+}
+
+// WithTransaction undoes the writes made with the context of fn
+// if fn returns an error or panics. A transaction started inside
+// another one is a part of the outer transaction.
+func (ms *memorySession) WithTransaction(
+	ctx context.Context,
+	fn func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	if _, ok := ctx.Value(transactionKey{}).(*memoryTransaction); ok {
+		return fn(ctx)
+	}
+
+	ms.client.transactions.Lock()
+	defer ms.client.transactions.Unlock()
+
+	tx := &memoryTransaction{}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		ms.client.mu.Lock()
+		defer ms.client.mu.Unlock()
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+	}()
+
+	res, err := fn(context.WithValue(ctx, transactionKey{}, tx))
+	if err != nil {
+		return nil, err
+	}
+	committed = true
+	return res, nil
+}
+
+func (ms *memorySession) EndSession(_ context.Context) {}
+
+// normalize turns a document of any type (a struct, bson.D, bson.M) into bson.M
+// with bson.M for the nested documents and bson.A for the arrays.
+func normalize(document interface{}) (bson.M, error) {
+	if document == nil {
+		return bson.M{}, nil
+	}
+	data, err := bson.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(data)))
+	dec.DefaultDocumentM()
+	doc := bson.M{}
+	err = dec.Decode(&doc)
+	return doc, err
+}
+
+func decode(doc bson.M, v interface{}) error {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, v)
+}
+
+// matchDocument reports whether the document matches the filter. The position
+// is the index of the first array element that matched, it is used by the
+// positional operator "$" of the update.
+func matchDocument(doc bson.M, filter bson.M) (bool, int, error) {
+	position := -1
+	for key, cond := range filter {
+		var ok bool
+		var err error
+		switch key {
+		case "$and", "$or", "$nor":
+			ok, err = matchLogical(doc, key, cond, &position)
+		case "$text":
+			ok, err = matchText(doc, cond)
+		default:
+			ok, err = matchField(doc, key, cond, &position)
+		}
+		if err != nil || !ok {
+			return false, -1, err
+		}
+	}
+	return true, position, nil
+}
+
+func matchLogical(doc bson.M, operator string, cond interface{}, position *int) (bool, error) {
+	filters, ok := cond.(bson.A)
+	if !ok || len(filters) == 0 {
+		return false, fmt.Errorf("%s must be a nonempty array", operator)
+	}
+	for _, f := range filters {
+		filter, ok := f.(bson.M)
+		if !ok {
+			return false, fmt.Errorf("%s must contain documents", operator)
+		}
+		matched, pos, err := matchDocument(doc, filter)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case operator == "$and" && !matched:
+			return false, nil
+		case operator == "$or" && matched:
+			setPosition(position, pos)
+			return true, nil
+		case operator == "$nor" && matched:
+			return false, nil
+		case matched:
+			setPosition(position, pos)
+		}
+	}
+	return operator != "$or", nil
+}
+
+// matchText is a simplified $text: the document matches if any of the words
+// of the search is a word of its top level string fields, ignoring the case.
+func matchText(doc bson.M, cond interface{}) (bool, error) {
+	text, ok := cond.(bson.M)
+	if !ok {
+		return false, errors.New("$text must be a document")
+	}
+	search, ok := text["$search"].(string)
+	if !ok {
+		return false, errors.New("$text requires a $search string")
+	}
+
+	words := make(map[string]bool)
+	for _, value := range doc {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(strings.ToLower(s), isNotWordRune) {
+			words[word] = true
+		}
+	}
+	for _, word := range strings.FieldsFunc(strings.ToLower(search), isNotWordRune) {
+		if words[word] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// foundValue is a value of a dotted path and the index of the array element it was found in.
+type foundValue struct {
+	value    interface{}
+	position int
+}
+
+func lookup(value interface{}, path []string, position int, found []foundValue) []foundValue {
+	if len(path) == 0 {
+		return append(found, foundValue{value: value, position: position})
+	}
+	switch v := value.(type) {
+	case bson.M:
+		child, ok := v[path[0]]
+		if !ok {
+			return found
+		}
+		return lookup(child, path[1:], position, found)
+	case bson.A:
+		if i, err := strconv.Atoi(path[0]); err == nil {
+			if i < 0 || i >= len(v) {
+				return found
+			}
+			return lookup(v[i], path[1:], position, found)
+		}
+		for i, item := range v {
+			pos := position
+			if pos < 0 {
+				pos = i
+			}
+			found = lookup(item, path, pos, found)
+		}
+	}
+	return found
+}
+
+func matchField(doc bson.M, key string, cond interface{}, position *int) (bool, error) {
+	found := lookup(doc, strings.Split(key, "."), -1, nil)
+	ops, ok := cond.(bson.M)
+	if !ok || !isOperatorDocument(ops) {
+		return matchOperator(found, "$eq", cond, nil, position)
+	}
+	return matchOperators(found, ops, position)
+}
+
+func isOperatorDocument(doc bson.M) bool {
+	if len(doc) == 0 {
+		return false
+	}
+	for key := range doc {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+func matchOperators(found []foundValue, ops bson.M, position *int) (bool, error) {
+	for op, arg := range ops {
+		if op == "$options" {
+			continue
+		}
+		ok, err := matchOperator(found, op, arg, ops, position)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchOperator(found []foundValue, op string, arg interface{}, ops bson.M, position *int) (bool, error) {
+	switch op {
+	case "$exists":
+		return (len(found) > 0) == isTruthy(arg), nil
+	case "$ne":
+		ok, err := matchOperator(found, "$eq", arg, ops, nil)
+		return !ok, err
+	case "$nin":
+		ok, err := matchOperator(found, "$in", arg, ops, nil)
+		return !ok, err
+	case "$not":
+		not, ok := arg.(bson.M)
+		if !ok {
+			return false, errors.New("$not must be a document")
+		}
+		ok, err := matchOperators(found, not, nil)
+		return !ok, err
+	case "$size":
+		for _, f := range found {
+			if items, ok := f.value.(bson.A); ok && equalValues(len(items), arg) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "$elemMatch":
+		return matchElement(found, arg, position)
+	}
+
+	test, err := valueTest(op, arg, ops)
+	if err != nil {
+		return false, err
+	}
+	if len(found) == 0 {
+		return test(nil), nil
+	}
+	for _, f := range found {
+		if test(f.value) {
+			setPosition(position, f.position)
+			return true, nil
+		}
+		items, ok := f.value.(bson.A)
+		if !ok {
+			continue
+		}
+		for i, item := range items {
+			if test(item) {
+				pos := f.position
+				if pos < 0 {
+					pos = i
+				}
+				setPosition(position, pos)
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func matchElement(found []foundValue, arg interface{}, position *int) (bool, error) {
+	cond, ok := arg.(bson.M)
+	if !ok {
+		return false, errors.New("$elemMatch must be a document")
+	}
+	for _, f := range found {
+		items, ok := f.value.(bson.A)
+		if !ok {
+			continue
+		}
+		for i, item := range items {
+			matched, err := matchValue(item, cond)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				if f.position >= 0 {
+					i = f.position
+				}
+				setPosition(position, i)
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// matchValue matches a single value: a document by a filter, any other value by operators.
+func matchValue(value interface{}, cond bson.M) (bool, error) {
+	if isOperatorDocument(cond) {
+		return matchOperators([]foundValue{{value: value, position: -1}}, cond, nil)
+	}
+	doc, ok := value.(bson.M)
+	if !ok {
+		return false, nil
+	}
+	matched, _, err := matchDocument(doc, cond)
+	return matched, err
+}
+
+func valueTest(op string, arg interface{}, ops bson.M) (func(interface{}) bool, error) {
+	switch op {
+	case "$eq":
+		return func(v interface{}) bool { return equalOrMatch(v, arg) }, nil
+	case "$gt", "$gte", "$lt", "$lte":
+		return func(v interface{}) bool {
+			c, ok := compareValues(v, arg)
+			if !ok {
+				return false
+			}
+			switch op {
+			case "$gt":
+				return c > 0
+			case "$gte":
+				return c >= 0
+			case "$lt":
+				return c < 0
+			default:
+				return c <= 0
+			}
+		}, nil
+	case "$in":
+		items, ok := arg.(bson.A)
+		if !ok {
+			return nil, errors.New("$in needs an array")
+		}
+		return func(v interface{}) bool {
+			for _, item := range items {
+				if equalOrMatch(v, item) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "$regex":
+		re, err := compileRegex(arg, ops["$options"])
+		if err != nil {
+			return nil, err
+		}
+		return func(v interface{}) bool {
+			s, ok := v.(string)
+			return ok && re.MatchString(s)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported query operator %s", op)
+}
+
+func compileRegex(pattern interface{}, options interface{}) (*regexp.Regexp, error) {
+	var expr, opts string
+	switch p := pattern.(type) {
+	case bson.Regex:
+		expr, opts = p.Pattern, p.Options
+	case string:
+		expr = p
+	default:
+		return nil, errors.New("$regex needs a string")
+	}
+	if o, ok := options.(string); ok {
+		opts += o
+	}
+
+	var flags string
+	for _, o := range opts {
+		if strings.ContainsRune("ims", o) && !strings.ContainsRune(flags, o) {
+			flags += string(o)
+		}
+	}
+	if flags != "" {
+		expr = "(?" + flags + ")" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// equalOrMatch compares the values, a regular expression matches the strings.
+func equalOrMatch(value interface{}, arg interface{}) bool {
+	if re, ok := arg.(bson.Regex); ok {
+		compiled, err := compileRegex(re, nil)
+		s, isString := value.(string)
+		return err == nil && isString && compiled.MatchString(s)
+	}
+	return equalValues(value, arg)
+}
+
+func equalValues(a interface{}, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// compareValues compares the values of the same type, ok is false for the values
+// that are not comparable.
+func compareValues(a interface{}, b interface{}) (int, bool) {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		return strings.Compare(x, y), ok
+	case bson.DateTime:
+		y, ok := b.(bson.DateTime)
+		if !ok {
+			return 0, false
+		}
+		return compareValues(int64(x), int64(y))
+	case bool:
+		y, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x == y:
+			return 0, true
+		case y:
+			return -1, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+// typeOrder is the order of the types in the sort, like in MongoDB.
+func typeOrder(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case int, int32, int64, float64:
+		return 1
+	case string:
+		return 2
+	case bson.M:
+		return 3
+	case bson.A:
+		return 4
+	case bool:
+		return 5
+	case bson.DateTime:
+		return 6
+	}
+	return 7
+}
+
+func sortDocuments(docs []bson.M, sortBy interface{}) error {
+	data, err := bson.Marshal(sortBy)
+	if err != nil {
+		return err
+	}
+	var keys bson.D
+	err = bson.Unmarshal(data, &keys)
+	if err != nil {
+		return err
+	}
+
+	directions := make([]float64, len(keys))
+	for i, key := range keys {
+		direction, ok := toFloat(key.Value)
+		if !ok || (direction != 1 && direction != -1) {
+			return fmt.Errorf("invalid sort direction for %s", key.Key)
+		}
+		directions[i] = direction
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		for k, key := range keys {
+			a := sortValue(docs[i], key.Key)
+			b := sortValue(docs[j], key.Key)
+			c := typeOrder(a) - typeOrder(b)
+			if c == 0 {
+				c, _ = compareValues(a, b)
+			}
+			if c != 0 {
+				return float64(c)*directions[k] < 0
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+func sortValue(doc bson.M, key string) interface{} {
+	found := lookup(doc, strings.Split(key, "."), -1, nil)
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0].value
+}
+
+func setPosition(position *int, pos int) {
+	if position != nil && *position < 0 && pos >= 0 {
+		*position = pos
+	}
+}
+
+func isTruthy(v interface{}) bool {
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	if n, ok := toFloat(v); ok {
+		return n != 0
+	}
+	return v != nil
+}
+
+func applyUpdate(doc bson.M, update bson.M, position int) error {
+	if !isOperatorDocument(update) {
+		return errors.New("update document must contain only update operators")
+	}
+	for op, arg := range update {
+		fields, ok := arg.(bson.M)
+		if !ok {
+			return fmt.Errorf("%s must be a document", op)
+		}
+		for key, value := range fields {
+			path, err := resolvePath(key, position)
+			if err != nil {
+				return err
+			}
+			err = applyOperator(doc, op, path, value)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", op, key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// resolvePath replaces the positional operator "$" with the index of the matched array element.
+func resolvePath(key string, position int) ([]string, error) {
+	path := strings.Split(key, ".")
+	for i, part := range path {
+		if part != "$" {
+			continue
+		}
+		if position < 0 {
+			return nil, fmt.Errorf("the positional operator did not find the match needed from the query for %s", key)
+		}
+		path[i] = strconv.Itoa(position)
+	}
+	return path, nil
+}
+
+func applyOperator(doc bson.M, op string, path []string, value interface{}) error {
+	switch op {
+	case "$set":
+		return setPath(doc, path, value)
+	case "$unset":
+		unsetPath(doc, path)
+		return nil
+	case "$inc":
+		return setPath(doc, path, func(current interface{}, exists bool) (interface{}, error) {
+			if !exists {
+				current = int32(0)
+			}
+			return addNumbers(current, value)
+		})
+	case "$push", "$addToSet":
+		return setPath(doc, path, func(current interface{}, exists bool) (interface{}, error) {
+			items, ok := current.(bson.A)
+			if exists && !ok {
+				return nil, errors.New("the field is not an array")
+			}
+			values := bson.A{value}
+			if each, ok := value.(bson.M); ok && each["$each"] != nil {
+				if values, ok = each["$each"].(bson.A); !ok {
+					return nil, errors.New("$each must be an array")
+				}
+			}
+			for _, v := range values {
+				if op == "$addToSet" && containsValue(items, v) {
+					continue
+				}
+				items = append(items, v)
+			}
+			if items == nil {
+				items = bson.A{}
+			}
+			return items, nil
+		})
+	case "$pull":
+		return setPath(doc, path, func(current interface{}, exists bool) (interface{}, error) {
+			if !exists {
+				return nil, errSkipUpdate
+			}
+			items, ok := current.(bson.A)
+			if !ok {
+				return nil, errors.New("the field is not an array")
+			}
+			kept := bson.A{}
+			for _, item := range items {
+				remove, err := pullMatches(item, value)
+				if err != nil {
+					return nil, err
+				}
+				if !remove {
+					kept = append(kept, item)
+				}
+			}
+			return kept, nil
+		})
+	}
+	return fmt.Errorf("unsupported update operator %s", op)
+}
+
+var errSkipUpdate = errors.New("skip update")
+
+// setPath sets the value by the path creating the missing documents,
+// the value can be a function of the current value.
+func setPath(doc bson.M, path []string, value interface{}) error {
+	var parent interface{} = doc
+	for i, part := range path {
+		last := i == len(path)-1
+		switch p := parent.(type) {
+		case bson.M:
+			current, exists := p[part]
+			if !last {
+				if !exists || current == nil {
+					current = bson.M{}
+					p[part] = current
+				}
+				parent = current
+				continue
+			}
+			v, err := resolveValue(value, current, exists)
+			if errors.Is(err, errSkipUpdate) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			p[part] = v
+		case bson.A:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(p) {
+				return fmt.Errorf("cannot use the part %s to traverse the array", part)
+			}
+			if !last {
+				if p[index] == nil {
+					p[index] = bson.M{}
+				}
+				parent = p[index]
+				continue
+			}
+			v, err := resolveValue(value, p[index], true)
+			if errors.Is(err, errSkipUpdate) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			p[index] = v
+		default:
+			return fmt.Errorf("cannot create the field %s in a value of type %T", part, parent)
+		}
+	}
+	return nil
+}
+
+func resolveValue(value interface{}, current interface{}, exists bool) (interface{}, error) {
+	if update, ok := value.(func(interface{}, bool) (interface{}, error)); ok {
+		return update(current, exists)
+	}
+	return value, nil
+}
+
+func unsetPath(doc bson.M, path []string) {
+	found := lookup(doc, path[:len(path)-1], -1, nil)
+	if len(path) == 1 {
+		found = []foundValue{{value: doc}}
+	}
+	for _, f := range found {
+		if parent, ok := f.value.(bson.M); ok {
+			delete(parent, path[len(path)-1])
+		}
+	}
+}
+
+func addNumbers(a interface{}, b interface{}) (interface{}, error) {
+	x, ok := toFloat(a)
+	if !ok {
+		return nil, fmt.Errorf("cannot increment a value of type %T", a)
+	}
+	y, ok := toFloat(b)
+	if !ok {
+		return nil, fmt.Errorf("cannot increment by a value of type %T", b)
+	}
+	_, aFloat := a.(float64)
+	_, bFloat := b.(float64)
+	if aFloat || bFloat {
+		return x + y, nil
+	}
+	sum := toInt64(a) + toInt64(b)
+	_, aInt32 := a.(int32)
+	_, bInt32 := b.(int32)
+	if aInt32 && bInt32 && sum == int64(int32(sum)) {
+		return int32(sum), nil
+	}
+	return sum, nil
+}
+
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	}
+	return 0
+}
+
+func containsValue(items bson.A, value interface{}) bool {
+	for _, item := range items {
+		if equalValues(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// pullMatches reports whether $pull removes the item: a document condition is
+// a filter of the item, any other value must be equal to it.
+func pullMatches(item interface{}, cond interface{}) (bool, error) {
+	if c, ok := cond.(bson.M); ok && len(c) > 0 {
+		return matchValue(item, c)
+	}
+	return equalOrMatch(item, cond), nil
+}
@@ -0,0 +1,228 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"main/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type testCard struct {
+	LocalID  int    `bson:"local_id"`
+	Question string `bson:"question"`
+}
+
+type testDoc struct {
+	ID        string     `bson:"_id"`
+	Name      string     `bson:"name"`
+	Likes     int        `bson:"likes"`
+	Tags      []string   `bson:"tags"`
+	Cards     []testCard `bson:"cards"`
+	IsDeleted bool       `bson:"is_deleted,omitempty"`
+}
+
+func newTestCollection(t *testing.T) database.Collection {
+	t.Helper()
+	collection := database.NewMemoryClient().Database("test").Collection("docs")
+	docs := []testDoc{
+		{ID: "1", Name: "Capitals of Europe", Likes: 5, Tags: []string{"geo"},
+			Cards: []testCard{{LocalID: 0, Question: "France"}, {LocalID: 1, Question: "Italy"}}},
+		{ID: "2", Name: "Irregular verbs", Likes: 10, Tags: []string{"lang", "en"}},
+		{ID: "3", Name: "Rivers", Likes: 5, Tags: []string{"geo"}, IsDeleted: true},
+	}
+	for _, doc := range docs {
+		_, err := collection.InsertOne(context.Background(), doc)
+		require.NoError(t, err)
+	}
+	return collection
+}
+
+func findIDs(
+	t *testing.T,
+	collection database.Collection,
+	filter interface{},
+	opts ...options.Lister[options.FindOptions],
+) []string {
+	t.Helper()
+	cursor, err := collection.Find(context.Background(), filter, opts...)
+	require.NoError(t, err)
+	var docs []testDoc
+	require.NoError(t, cursor.All(context.Background(), &docs))
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestMemoryCollection_Filters(t *testing.T) {
+	collection := newTestCollection(t)
+
+	tests := []struct {
+		name   string
+		filter interface{}
+		ids    []string
+	}{
+		{"empty", bson.D{}, []string{"1", "2", "3"}},
+		{"equality", bson.M{"likes": 5}, []string{"1", "3"}},
+		{"array element", bson.M{"tags": "en"}, []string{"2"}},
+		{"nested array", bson.D{{Key: "cards.local_id", Value: 1}}, []string{"1"}},
+		{"$in", bson.M{"_id": bson.M{"$in": []string{"2", "3", "4"}}}, []string{"2", "3"}},
+		{"$in of nothing", bson.M{"_id": bson.M{"$in": []string{}}}, []string{}},
+		{"$exists", bson.M{"is_deleted": bson.M{"$exists": false}}, []string{"1", "2"}},
+		{"$gt", bson.D{{Key: "likes", Value: bson.D{{Key: "$gt", Value: 5}}}}, []string{"2"}},
+		{"$ne", bson.M{"likes": bson.M{"$ne": 5}}, []string{"2"}},
+		{"$and", bson.D{{Key: "$and", Value: []interface{}{
+			bson.M{"tags": "geo"},
+			bson.D{{Key: "is_deleted", Value: bson.D{{Key: "$exists", Value: false}}}},
+		}}}, []string{"1"}},
+		{"$or", bson.M{"$or": bson.A{bson.M{"_id": "1"}, bson.M{"likes": 10}}}, []string{"1", "2"}},
+		{"regex", bson.M{"name": bson.Regex{Pattern: ".*VERBS.*", Options: "i"}}, []string{"2"}},
+		{"$regex", bson.M{"name": bson.M{"$regex": "^Ri"}}, []string{"3"}},
+		{"$text", bson.M{"$text": bson.M{"$search": "europe rivers"}}, []string{"1", "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ids, findIDs(t, collection, tt.filter))
+		})
+	}
+
+	_, err := collection.Find(context.Background(), bson.M{"likes": bson.M{"$near": 1}})
+	assert.Error(t, err, "unsupported operators are errors")
+}
+
+func TestMemoryCollection_FindOptions(t *testing.T) {
+	collection := newTestCollection(t)
+	sort := bson.D{{Key: "likes", Value: -1}, {Key: "name", Value: 1}}
+
+	assert.Equal(t, []string{"2", "1", "3"}, findIDs(t, collection, bson.D{}, options.Find().SetSort(sort)))
+	assert.Equal(t, []string{"1"}, findIDs(t, collection, bson.D{},
+		options.Find().SetSort(sort).SetSkip(1).SetLimit(1)))
+	assert.Equal(t, []string{"1", "2", "3"}, findIDs(t, collection, bson.D{}, options.Find().SetLimit(0)))
+	assert.Empty(t, findIDs(t, collection, bson.D{}, options.Find().SetSkip(10)))
+}
+
+func TestMemoryCollection_FindOne(t *testing.T) {
+	collection := newTestCollection(t)
+
+	var doc testDoc
+	require.NoError(t, collection.FindOne(context.Background(), bson.M{"_id": "1"}).Decode(&doc))
+	assert.Equal(t, "Capitals of Europe", doc.Name)
+	assert.Len(t, doc.Cards, 2)
+
+	err := collection.FindOne(context.Background(), bson.M{"_id": "4"}).Decode(&doc)
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+	_, err = collection.InsertOne(context.Background(), testDoc{ID: "1"})
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+}
+
+func TestMemoryCollection_Updates(t *testing.T) {
+	ctx := context.Background()
+	collection := newTestCollection(t)
+	byID := bson.D{{Key: "_id", Value: "1"}}
+
+	update := func(filter interface{}, update interface{}) database.UpdateResult {
+		t.Helper()
+		res, err := collection.UpdateOne(ctx, filter, update)
+		require.NoError(t, err)
+		return res
+	}
+
+	res := update(byID, bson.D{
+		{Key: "$set", Value: bson.D{{Key: "name", Value: "Capitals"}}},
+		{Key: "$inc", Value: bson.D{{Key: "likes", Value: -2}}},
+		{Key: "$push", Value: bson.D{{Key: "cards", Value: testCard{LocalID: 2, Question: "Spain"}}}},
+	})
+	assert.Equal(t, database.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, res)
+
+	// the positional operator updates the card matched by the filter
+	res = update(
+		bson.D{{Key: "_id", Value: "1"}, {Key: "cards.local_id", Value: 1}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "cards.$.question", Value: "Italia"}}}},
+	)
+	assert.Equal(t, int64(1), res.ModifiedCount)
+
+	pullCard := bson.D{{Key: "cards", Value: bson.D{{Key: "local_id", Value: 0}}}}
+	res = update(byID, bson.D{{Key: "$pull", Value: pullCard}})
+	assert.Equal(t, int64(1), res.ModifiedCount)
+	res = update(byID, bson.D{{Key: "$pull", Value: bson.D{{Key: "tags", Value: "geo"}}}})
+	assert.Equal(t, int64(1), res.ModifiedCount)
+
+	// nothing changes: matched, but not modified
+	res = update(byID, bson.D{{Key: "$pull", Value: bson.D{{Key: "tags", Value: "geo"}}}})
+	assert.Equal(t, database.UpdateResult{MatchedCount: 1}, res)
+	res = update(bson.M{"_id": "4"}, bson.M{"$set": bson.M{"name": "none"}})
+	assert.Equal(t, database.UpdateResult{}, res)
+
+	var doc testDoc
+	require.NoError(t, collection.FindOne(ctx, byID).Decode(&doc))
+	assert.Equal(t, testDoc{
+		ID:    "1",
+		Name:  "Capitals",
+		Likes: 3,
+		Tags:  []string{},
+		Cards: []testCard{{LocalID: 1, Question: "Italia"}, {LocalID: 2, Question: "Spain"}},
+	}, doc)
+
+	many, err := collection.UpdateMany(ctx, bson.M{"tags": "geo"}, bson.M{"$set": bson.M{"likes": 0}})
+	require.NoError(t, err)
+	assert.Equal(t, database.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, many)
+
+	_, err = collection.UpdateOne(ctx, byID, bson.M{"$set": bson.M{"cards.$.question": "?"}})
+	assert.Error(t, err, "the positional operator needs an array in the filter")
+
+	deleted, err := collection.DeleteOne(ctx, byID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Equal(t, []string{"2", "3"}, findIDs(t, collection, bson.D{}))
+}
+
+func TestMemorySession_WithTransaction(t *testing.T) {
+	ctx := context.Background()
+	client := database.NewMemoryClient()
+	collection := client.Database("test").Collection("docs")
+	_, err := collection.InsertOne(ctx, testDoc{ID: "1", Likes: 1})
+	require.NoError(t, err)
+	_, err = collection.InsertOne(ctx, testDoc{ID: "2", Likes: 2})
+	require.NoError(t, err)
+
+	session, err := client.StartSession()
+	require.NoError(t, err)
+	defer session.EndSession(ctx)
+
+	failed := errors.New("failed")
+	_, err = session.WithTransaction(ctx, func(txCtx context.Context) (interface{}, error) {
+		_, err = collection.UpdateOne(txCtx, bson.M{"_id": "1"}, bson.M{"$inc": bson.M{"likes": 10}})
+		require.NoError(t, err)
+		_, err = collection.InsertOne(txCtx, testDoc{ID: "3"})
+		require.NoError(t, err)
+		_, err = collection.DeleteOne(txCtx, bson.M{"_id": "2"})
+		require.NoError(t, err)
+
+		// the transaction sees its own writes
+		assert.Equal(t, []string{"1", "3"}, findIDs(t, collection, bson.D{}))
+		return nil, failed
+	})
+	assert.ErrorIs(t, err, failed)
+
+	cursor, err := collection.Find(ctx, bson.D{})
+	require.NoError(t, err)
+	var docs []testDoc
+	require.NoError(t, cursor.All(ctx, &docs))
+	assert.Equal(t, []testDoc{{ID: "1", Likes: 1}, {ID: "2", Likes: 2}}, docs)
+
+	res, err := session.WithTransaction(ctx, func(txCtx context.Context) (interface{}, error) {
+		_, err = collection.InsertOne(txCtx, testDoc{ID: "3"})
+		return "3", err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "3", res)
+	assert.Equal(t, []string{"1", "2", "3"}, findIDs(t, collection, bson.D{}))
+}
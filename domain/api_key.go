@@ -0,0 +1,60 @@
+package domain
+
+import "context"
+
+const (
+	ApiKeyCollection = "api_keys"
+
+	ScopeCollectionsRead  = "collections:read"
+	ScopeCollectionsWrite = "collections:write"
+	ScopeHistoryRead      = "history:read"
+)
+
+// ApiKeyScopes lists the scopes a user can grant to a key.
+var ApiKeyScopes = []string{ScopeCollectionsRead, ScopeCollectionsWrite, ScopeHistoryRead}
+
+// ApiKey is a long-lived credential for scripts. Only the SHA-256 hash
+// of the key is stored; Prefix is kept to tell keys apart in the list.
+type ApiKey struct {
+	ID         string   `bson:"_id"          json:"id"`
+	UserID     string   `bson:"user_id"      json:"-"`
+	Name       string   `bson:"name"         json:"name"`
+	Prefix     string   `bson:"prefix"       json:"prefix"`
+	Hash       string   `bson:"hash"         json:"-"`
+	Scopes     []string `bson:"scopes"       json:"scopes"`
+	CreatedAt  int64    `bson:"created_at"   json:"created_at"`
+	ExpiresAt  int64    `bson:"expires_at"   json:"expires_at"`
+	LastUsedAt int64    `bson:"last_used_at" json:"last_used_at"`
+}
+
+type ApiKeyArray struct {
+	Count int      `json:"count"`
+	Items []ApiKey `json:"items"`
+}
+
+type CreateApiKeyRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// CreateApiKeyResponse is the only time the key itself is shown.
+type CreateApiKeyResponse struct {
+	Key    string `json:"key"`
+	ApiKey ApiKey `json:"api_key"`
+}
+
+type ApiKeyRepository interface {
+	Create(c context.Context, apiKey *ApiKey) (string, error)
+	GetByHash(c context.Context, hash string) (ApiKey, error)
+	GetByUserID(c context.Context, userID string) ([]ApiKey, error)
+	UpdateLastUsed(c context.Context, apiKeyID string, lastUsedAt int64) error
+	DeleteByID(c context.Context, userID string, apiKeyID string) (int64, error)
+}
+
+type ApiKeyUseCase interface {
+	Create(c context.Context, userID string, request *CreateApiKeyRequest) (CreateApiKeyResponse, error)
+	GetByUserID(c context.Context, userID string) ([]ApiKey, error)
+	Delete(c context.Context, userID string, apiKeyID string) error
+	Authenticate(c context.Context, key string) (ApiKey, error)
+}
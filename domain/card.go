@@ -5,8 +5,124 @@ type Card struct {
 	Question string `bson:"question" json:"question"`
 	Answer   string `bson:"answer"   json:"answer"`
 
-	Attachment   string       `bson:"attachment"    json:"attachment"`
-	OtherAnswers OtherAnswers `bson:"other_answers" json:"other_answers"`
+	Attachments  []CardAttachment `bson:"attachments"   json:"attachments"`
+	OtherAnswers OtherAnswers     `bson:"other_answers" json:"other_answers"`
+
+	// LegacyAttachment is the only picture of the cards stored before Attachments,
+	// MigrateAttachments moves it into Attachments
+	LegacyAttachment string `bson:"attachment,omitempty" json:"-"`
+
+	// QuestionAudio and AnswerAudio are the object names of the audio played on the sides of the card
+	QuestionAudio string `bson:"question_audio" json:"question_audio"`
+	AnswerAudio   string `bson:"answer_audio"   json:"answer_audio"`
+	// QuestionAudioOwner and AnswerAudioOwner are the users the audio is charged to, the ones who uploaded it
+	QuestionAudioOwner string `bson:"question_audio_owner,omitempty" json:"-"`
+	AnswerAudioOwner   string `bson:"answer_audio_owner,omitempty"   json:"-"`
+}
+
+// LegacyCard is the card served to the clients released before Attachments: Attachment is the ID
+// of the first picture of the question side, empty if there is none.
+type LegacyCard struct {
+	LocalID       int          `json:"local_id"`
+	Question      string       `json:"question"`
+	Answer        string       `json:"answer"`
+	Attachment    string       `json:"attachment"`
+	OtherAnswers  OtherAnswers `json:"other_answers"`
+	QuestionAudio string       `json:"question_audio"`
+	AnswerAudio   string       `json:"answer_audio"`
+}
+
+// Legacy returns the card in the shape of LegacyCard.
+func (c Card) Legacy() LegacyCard {
+	legacy := LegacyCard{
+		LocalID:       c.LocalID,
+		Question:      c.Question,
+		Answer:        c.Answer,
+		OtherAnswers:  c.OtherAnswers,
+		QuestionAudio: c.QuestionAudio,
+		AnswerAudio:   c.AnswerAudio,
+	}
+	// the attachments are sorted by their order
+	for _, attachment := range c.Attachments {
+		if attachment.Side == CardSideQuestion {
+			legacy.Attachment = attachment.ID
+			break
+		}
+	}
+	return legacy
+}
+
+// Audio returns the object name of the audio of the side, empty if there is none.
+func (c Card) Audio(side CardSide) string {
+	if side == CardSideAnswer {
+		return c.AnswerAudio
+	}
+	return c.QuestionAudio
+}
+
+// AudioOwner returns the user the audio of the side is charged to,
+// empty if there is no audio or it was uploaded before the owners were kept.
+func (c Card) AudioOwner(side CardSide) string {
+	if side == CardSideAnswer {
+		return c.AnswerAudioOwner
+	}
+	return c.QuestionAudioOwner
+}
+
+// CardAttachment is a picture of a card. The attachments of a card are sorted by Order,
+// the pictures of a side are shown in this order.
+type CardAttachment struct {
+	ID        string   `bson:"id"         json:"id"`
+	Side      CardSide `bson:"side"       json:"side"`
+	MediaType string   `bson:"media_type" json:"media_type"`
+	Size      int64    `bson:"size"       json:"size"`
+	Order     int      `bson:"order"      json:"order"`
+
+	// Object is the Media the picture is stored as, it is shared by the identical pictures
+	Object string `bson:"object,omitempty" json:"-"`
+	// Owner is the user the picture is charged to, the one who added it
+	Owner string `bson:"owner,omitempty" json:"-"`
+}
+
+// ObjectName returns the object name of the picture,
+// the pictures stored before Media have none but their ID.
+func (a CardAttachment) ObjectName() string {
+	if a.Object == "" {
+		return a.ID
+	}
+	return a.Object
+}
+
+// Attachment returns the attachment of the card with the id.
+func (c Card) Attachment(id string) (CardAttachment, bool) {
+	for _, attachment := range c.Attachments {
+		if attachment.ID == id {
+			return attachment, true
+		}
+	}
+	return CardAttachment{}, false
+}
+
+type ReorderAttachmentsRequest struct {
+	// IDs are the IDs of all the attachments of the card in the new order
+	IDs []string `json:"ids"`
+}
+
+// CardSide is the side of a card an attachment belongs to.
+type CardSide string
+
+const (
+	CardSideQuestion CardSide = "question"
+	CardSideAnswer   CardSide = "answer"
+)
+
+// ParseCardSide reads the side query parameter, it is required.
+func ParseCardSide(s string) (CardSide, error) {
+	side := CardSide(s)
+	if side != CardSideQuestion && side != CardSideAnswer {
+		return "", InvalidFields("side")
+	}
+	return side, nil
 }
 
 type OtherAnswers struct {
@@ -17,3 +133,9 @@ type OtherAnswers struct {
 type UploadCardPhotoResult struct {
 	ObjectName string `bson:"object_name" json:"object_name"`
 }
+
+type UploadCardAudioResult struct {
+	ObjectName string `bson:"object_name" json:"object_name"`
+	// DurationMs is the duration of the audio in milliseconds
+	DurationMs int64 `bson:"duration_ms" json:"duration_ms"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"main/database"
+	"time"
 )
 
 const (
@@ -33,6 +34,17 @@ type CollectionInfo struct {
 	Trainings int    `bson:"trainings" json:"trainings"`
 }
 
+// LegacyCollectionInfo is CollectionInfo with the cards of the clients released before Attachments.
+type LegacyCollectionInfo struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	IsPublic  bool         `json:"is_public"`
+	Cards     []LegacyCard `json:"cards"`
+	Author    string       `json:"author"`
+	Likes     int          `json:"likes"`
+	Trainings int          `json:"trainings"`
+}
+
 type CollectionPreview struct {
 	ID         string `bson:"_id"       json:"id"`
 	Name       string `bson:"name"      json:"name"`
@@ -74,23 +86,53 @@ type CollectionUseCase interface {
 	) ([]Collection, error)
 	SearchPublicByAuthor(c context.Context, author string) ([]Collection, error)
 	AddCard(c context.Context, collectionID string, card *Card) (Card, error)
-	DeleteCard(c context.Context, collectionID string, cardLocalID int) error
+	DeleteCard(c context.Context, userID string, collectionID string, cardLocalID int) error
 	UpdateCard(c context.Context, collectionID string, card *Card) error
-	GetCardPhoto(c context.Context, objectName string) ([]byte, error)
-	UploadCardPhoto(
+	GetCardPhoto(c context.Context, objectName string, size PictureSize) (Object, error)
+	GetCardPhotoURL(c context.Context, objectName string, size PictureSize) (PresignedURL, error)
+	AddCardAttachment(
 		c context.Context,
 		userID string,
 		collectionID string,
 		cardID int,
+		side CardSide,
 		picture io.Reader,
 		size int64,
+		contentType string,
+	) (CardAttachment, error)
+	RemoveCardAttachment(c context.Context, userID string, collectionID string, cardID int, attachmentID string) error
+	ReorderCardAttachments(c context.Context, collectionID string, cardID int, ids []string) ([]CardAttachment, error)
+	MigrateAttachments(c context.Context) (int, error)
+	GetCardAudio(c context.Context, objectName string) (Object, error)
+	UploadCardAudio(
+		c context.Context,
+		userID string,
+		collectionID string,
+		cardID int,
+		side CardSide,
+		audio io.Reader,
+		size int64,
+		contentType string,
 	) (string, error)
-	RemoveCardPicture(c context.Context, userID string, collectionID string, cardID int, objectName string) error
+	RemoveCardAudio(
+		c context.Context,
+		userID string,
+		collectionID string,
+		cardID int,
+		side CardSide,
+		objectName string,
+	) error
 }
 
 //nolint:iface // business logic
 type CollectionStorage interface {
-	GetObject(c context.Context, objectName string) ([]byte, error)
-	PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64) error
+	GetObject(c context.Context, objectName string) (Object, error)
+	GetPicture(c context.Context, objectName string, size PictureSize) (Object, error)
+	StatObject(c context.Context, objectName string) (ObjectInfo, error)
+	PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error
 	RemoveObject(c context.Context, objectName string) error
+	PresignGet(c context.Context, objectName string, size PictureSize, expires time.Duration) (string, error)
+	GetAudio(c context.Context, objectName string) (Object, error)
+	PutAudio(c context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error
+	RemoveAudio(c context.Context, objectName string) error
 }
@@ -8,6 +8,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -18,7 +19,238 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson3e1fa5ecDecodeMainDomain(in *jlexer.Lexer, out *UserStatistics) {
+func easyjson3e1fa5ecDecodeMainDomain(in *jlexer.Lexer, out *principalKey) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain(out *jwriter.Writer, in principalKey) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v principalKey) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v principalKey) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *principalKey) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *principalKey) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain1(in *jlexer.Lexer, out *VerifyEmailRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "token":
+			out.Token = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain1(out *jwriter.Writer, in VerifyEmailRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Token))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VerifyEmailRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VerifyEmailRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VerifyEmailRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VerifyEmailRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain1(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain2(in *jlexer.Lexer, out *UserToken) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = string(in.String())
+		case "purpose":
+			out.Purpose = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "created_at":
+			out.CreatedAt = int64(in.Int64())
+		case "expires_at":
+			out.ExpiresAt = int64(in.Int64())
+		case "used":
+			out.Used = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain2(out *jwriter.Writer, in UserToken) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"purpose\":"
+		out.RawString(prefix)
+		out.String(string(in.Purpose))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresAt))
+	}
+	{
+		const prefix string = ",\"used\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Used))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserToken) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserToken) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserToken) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserToken) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain2(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain3(in *jlexer.Lexer, out *UserStatistics) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -51,7 +283,7 @@ func easyjson3e1fa5ecDecodeMainDomain(in *jlexer.Lexer, out *UserStatistics) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain(out *jwriter.Writer, in UserStatistics) {
+func easyjson3e1fa5ecEncodeMainDomain3(out *jwriter.Writer, in UserStatistics) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -71,27 +303,27 @@ func easyjson3e1fa5ecEncodeMainDomain(out *jwriter.Writer, in UserStatistics) {
 // MarshalJSON supports json.Marshaler interface
 func (v UserStatistics) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserStatistics) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain(w, v)
+	easyjson3e1fa5ecEncodeMainDomain3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserStatistics) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserStatistics) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain(l, v)
+	easyjson3e1fa5ecDecodeMainDomain3(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain1(in *jlexer.Lexer, out *UserLimits) {
+func easyjson3e1fa5ecDecodeMainDomain4(in *jlexer.Lexer, out *UserLimits) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -122,7 +354,7 @@ func easyjson3e1fa5ecDecodeMainDomain1(in *jlexer.Lexer, out *UserLimits) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain1(out *jwriter.Writer, in UserLimits) {
+func easyjson3e1fa5ecEncodeMainDomain4(out *jwriter.Writer, in UserLimits) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -137,27 +369,27 @@ func easyjson3e1fa5ecEncodeMainDomain1(out *jwriter.Writer, in UserLimits) {
 // MarshalJSON supports json.Marshaler interface
 func (v UserLimits) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain1(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserLimits) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain1(w, v)
+	easyjson3e1fa5ecEncodeMainDomain4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserLimits) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain1(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserLimits) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain1(l, v)
+	easyjson3e1fa5ecDecodeMainDomain4(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain2(in *jlexer.Lexer, out *UserInfo) {
+func easyjson3e1fa5ecDecodeMainDomain5(in *jlexer.Lexer, out *UserInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -182,6 +414,10 @@ func easyjson3e1fa5ecDecodeMainDomain2(in *jlexer.Lexer, out *UserInfo) {
 			out.Username = string(in.String())
 		case "email":
 			out.Email = string(in.String())
+		case "email_verified":
+			out.EmailVerified = bool(in.Bool())
+		case "two_factor":
+			out.TwoFactor = bool(in.Bool())
 		case "has_picture":
 			out.HasPicture = bool(in.Bool())
 		case "collections":
@@ -242,7 +478,7 @@ func easyjson3e1fa5ecDecodeMainDomain2(in *jlexer.Lexer, out *UserInfo) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain2(out *jwriter.Writer, in UserInfo) {
+func easyjson3e1fa5ecEncodeMainDomain5(out *jwriter.Writer, in UserInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -261,6 +497,16 @@ func easyjson3e1fa5ecEncodeMainDomain2(out *jwriter.Writer, in UserInfo) {
 		out.RawString(prefix)
 		out.String(string(in.Email))
 	}
+	{
+		const prefix string = ",\"email_verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.EmailVerified))
+	}
+	{
+		const prefix string = ",\"two_factor\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.TwoFactor))
+	}
 	{
 		const prefix string = ",\"has_picture\":"
 		out.RawString(prefix)
@@ -309,27 +555,27 @@ func easyjson3e1fa5ecEncodeMainDomain2(out *jwriter.Writer, in UserInfo) {
 // MarshalJSON supports json.Marshaler interface
 func (v UserInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain2(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain2(w, v)
+	easyjson3e1fa5ecEncodeMainDomain5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain2(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain2(l, v)
+	easyjson3e1fa5ecDecodeMainDomain5(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain3(in *jlexer.Lexer, out *UserHistoryArray) {
+func easyjson3e1fa5ecDecodeMainDomain6(in *jlexer.Lexer, out *UserHistoryArray) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -383,7 +629,7 @@ func easyjson3e1fa5ecDecodeMainDomain3(in *jlexer.Lexer, out *UserHistoryArray)
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain3(out *jwriter.Writer, in UserHistoryArray) {
+func easyjson3e1fa5ecEncodeMainDomain6(out *jwriter.Writer, in UserHistoryArray) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -414,27 +660,27 @@ func easyjson3e1fa5ecEncodeMainDomain3(out *jwriter.Writer, in UserHistoryArray)
 // MarshalJSON supports json.Marshaler interface
 func (v UserHistoryArray) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain3(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserHistoryArray) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain3(w, v)
+	easyjson3e1fa5ecEncodeMainDomain6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserHistoryArray) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain3(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserHistoryArray) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain3(l, v)
+	easyjson3e1fa5ecDecodeMainDomain6(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain4(in *jlexer.Lexer, out *UserHistory) {
+func easyjson3e1fa5ecDecodeMainDomain7(in *jlexer.Lexer, out *UserHistory) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -488,7 +734,7 @@ func easyjson3e1fa5ecDecodeMainDomain4(in *jlexer.Lexer, out *UserHistory) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain4(out *jwriter.Writer, in UserHistory) {
+func easyjson3e1fa5ecEncodeMainDomain7(out *jwriter.Writer, in UserHistory) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -519,27 +765,27 @@ func easyjson3e1fa5ecEncodeMainDomain4(out *jwriter.Writer, in UserHistory) {
 // MarshalJSON supports json.Marshaler interface
 func (v UserHistory) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain4(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserHistory) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain4(w, v)
+	easyjson3e1fa5ecEncodeMainDomain7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserHistory) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain4(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain4(l, v)
+	easyjson3e1fa5ecDecodeMainDomain7(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain5(in *jlexer.Lexer, out *User) {
+func easyjson3e1fa5ecDecodeMainDomain8(in *jlexer.Lexer, out *User) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -564,6 +810,8 @@ func easyjson3e1fa5ecDecodeMainDomain5(in *jlexer.Lexer, out *User) {
 			out.Username = string(in.String())
 		case "email":
 			out.Email = string(in.String())
+		case "email_verified":
+			out.EmailVerified = bool(in.Bool())
 		case "password":
 			out.Password = string(in.String())
 		case "has_picture":
@@ -628,7 +876,7 @@ func easyjson3e1fa5ecDecodeMainDomain5(in *jlexer.Lexer, out *User) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain5(out *jwriter.Writer, in User) {
+func easyjson3e1fa5ecEncodeMainDomain8(out *jwriter.Writer, in User) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -647,6 +895,11 @@ func easyjson3e1fa5ecEncodeMainDomain5(out *jwriter.Writer, in User) {
 		out.RawString(prefix)
 		out.String(string(in.Email))
 	}
+	{
+		const prefix string = ",\"email_verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.EmailVerified))
+	}
 	{
 		const prefix string = ",\"password\":"
 		out.RawString(prefix)
@@ -705,27 +958,27 @@ func easyjson3e1fa5ecEncodeMainDomain5(out *jwriter.Writer, in User) {
 // MarshalJSON supports json.Marshaler interface
 func (v User) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain5(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v User) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain5(w, v)
+	easyjson3e1fa5ecEncodeMainDomain8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *User) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain5(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain5(l, v)
+	easyjson3e1fa5ecDecodeMainDomain8(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain6(in *jlexer.Lexer, out *UploadCardPhotoResult) {
+func easyjson3e1fa5ecDecodeMainDomain9(in *jlexer.Lexer, out *UploadTicket) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -744,54 +997,121 @@ func easyjson3e1fa5ecDecodeMainDomain6(in *jlexer.Lexer, out *UploadCardPhotoRes
 			continue
 		}
 		switch key {
-		case "object_name":
-			out.ObjectName = string(in.String())
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
+		case "id":
+			out.ID = string(in.String())
+		case "fields":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Fields = make(map[string]string)
+				} else {
+					out.Fields = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v19 string
+					v19 = string(in.String())
+					(out.Fields)[key] = v19
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "url":
+			out.URL = string(in.String())
+		case "method":
+			out.Method = string(in.String())
+		case "expires_at":
+			out.ExpiresAt = int64(in.Int64())
+		case "proxy":
+			out.Proxy = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain6(out *jwriter.Writer, in UploadCardPhotoResult) {
+func easyjson3e1fa5ecEncodeMainDomain9(out *jwriter.Writer, in UploadTicket) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"object_name\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ObjectName))
+		out.String(string(in.ID))
+	}
+	if len(in.Fields) != 0 {
+		const prefix string = ",\"fields\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v20First := true
+			for v20Name, v20Value := range in.Fields {
+				if v20First {
+					v20First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v20Name))
+				out.RawByte(':')
+				out.String(string(v20Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"method\":"
+		out.RawString(prefix)
+		out.String(string(in.Method))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresAt))
+	}
+	{
+		const prefix string = ",\"proxy\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Proxy))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v UploadCardPhotoResult) MarshalJSON() ([]byte, error) {
+func (v UploadTicket) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain6(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v UploadCardPhotoResult) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain6(w, v)
+func (v UploadTicket) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *UploadCardPhotoResult) UnmarshalJSON(data []byte) error {
+func (v *UploadTicket) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain6(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *UploadCardPhotoResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain6(l, v)
+func (v *UploadTicket) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain9(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain7(in *jlexer.Lexer, out *SuccessResponse) {
+func easyjson3e1fa5ecDecodeMainDomain10(in *jlexer.Lexer, out *UploadCardPhotoResult) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -810,8 +1130,8 @@ func easyjson3e1fa5ecDecodeMainDomain7(in *jlexer.Lexer, out *SuccessResponse) {
 			continue
 		}
 		switch key {
-		case "message":
-			out.Message = string(in.String())
+		case "object_name":
+			out.ObjectName = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -822,42 +1142,42 @@ func easyjson3e1fa5ecDecodeMainDomain7(in *jlexer.Lexer, out *SuccessResponse) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain7(out *jwriter.Writer, in SuccessResponse) {
+func easyjson3e1fa5ecEncodeMainDomain10(out *jwriter.Writer, in UploadCardPhotoResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"message\":"
+		const prefix string = ",\"object_name\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Message))
+		out.String(string(in.ObjectName))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v SuccessResponse) MarshalJSON() ([]byte, error) {
+func (v UploadCardPhotoResult) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain7(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v SuccessResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain7(w, v)
+func (v UploadCardPhotoResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *SuccessResponse) UnmarshalJSON(data []byte) error {
+func (v *UploadCardPhotoResult) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain7(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *SuccessResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain7(l, v)
+func (v *UploadCardPhotoResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain10(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain8(in *jlexer.Lexer, out *SmallHistoryItem) {
+func easyjson3e1fa5ecDecodeMainDomain11(in *jlexer.Lexer, out *UploadCardAudioResult) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -876,58 +1196,10 @@ func easyjson3e1fa5ecDecodeMainDomain8(in *jlexer.Lexer, out *SmallHistoryItem)
 			continue
 		}
 		switch key {
-		case "collection_name":
-			out.CollectionName = string(in.String())
-		case "time":
-			out.Time = int(in.Int())
-		case "correct_cards":
-			if in.IsNull() {
-				in.Skip()
-				out.CorrectCards = nil
-			} else {
-				in.Delim('[')
-				if out.CorrectCards == nil {
-					if !in.IsDelim(']') {
-						out.CorrectCards = make([]int, 0, 8)
-					} else {
-						out.CorrectCards = []int{}
-					}
-				} else {
-					out.CorrectCards = (out.CorrectCards)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v19 int
-					v19 = int(in.Int())
-					out.CorrectCards = append(out.CorrectCards, v19)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "incorrect_cards":
-			if in.IsNull() {
-				in.Skip()
-				out.IncorrectCards = nil
-			} else {
-				in.Delim('[')
-				if out.IncorrectCards == nil {
-					if !in.IsDelim(']') {
-						out.IncorrectCards = make([]int, 0, 8)
-					} else {
-						out.IncorrectCards = []int{}
-					}
-				} else {
-					out.IncorrectCards = (out.IncorrectCards)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v20 int
-					v20 = int(in.Int())
-					out.IncorrectCards = append(out.IncorrectCards, v20)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "all_cards_count":
-			out.AllCardsCount = int(in.Int())
+		case "object_name":
+			out.ObjectName = string(in.String())
+		case "duration_ms":
+			out.DurationMs = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -938,84 +1210,47 @@ func easyjson3e1fa5ecDecodeMainDomain8(in *jlexer.Lexer, out *SmallHistoryItem)
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain8(out *jwriter.Writer, in SmallHistoryItem) {
+func easyjson3e1fa5ecEncodeMainDomain11(out *jwriter.Writer, in UploadCardAudioResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"collection_name\":"
+		const prefix string = ",\"object_name\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.CollectionName))
-	}
-	{
-		const prefix string = ",\"time\":"
-		out.RawString(prefix)
-		out.Int(int(in.Time))
-	}
-	{
-		const prefix string = ",\"correct_cards\":"
-		out.RawString(prefix)
-		if in.CorrectCards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v21, v22 := range in.CorrectCards {
-				if v21 > 0 {
-					out.RawByte(',')
-				}
-				out.Int(int(v22))
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"incorrect_cards\":"
-		out.RawString(prefix)
-		if in.IncorrectCards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v23, v24 := range in.IncorrectCards {
-				if v23 > 0 {
-					out.RawByte(',')
-				}
-				out.Int(int(v24))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.ObjectName))
 	}
 	{
-		const prefix string = ",\"all_cards_count\":"
+		const prefix string = ",\"duration_ms\":"
 		out.RawString(prefix)
-		out.Int(int(in.AllCardsCount))
+		out.Int64(int64(in.DurationMs))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v SmallHistoryItem) MarshalJSON() ([]byte, error) {
+func (v UploadCardAudioResult) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain8(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain11(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v SmallHistoryItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain8(w, v)
+func (v UploadCardAudioResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain11(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *SmallHistoryItem) UnmarshalJSON(data []byte) error {
+func (v *UploadCardAudioResult) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain8(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain11(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *SmallHistoryItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain8(l, v)
+func (v *UploadCardAudioResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain11(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain9(in *jlexer.Lexer, out *SignupResponse) {
+func easyjson3e1fa5ecDecodeMainDomain12(in *jlexer.Lexer, out *Upload) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1035,11 +1270,9 @@ func easyjson3e1fa5ecDecodeMainDomain9(in *jlexer.Lexer, out *SignupResponse) {
 		}
 		switch key {
 		case "id":
-			out.UserID = string(in.String())
-		case "access_token":
-			out.AccessToken = string(in.String())
-		case "refresh_token":
-			out.RefreshToken = string(in.String())
+			out.ID = string(in.String())
+		case "target":
+			out.Target = UploadTarget(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1050,52 +1283,47 @@ func easyjson3e1fa5ecDecodeMainDomain9(in *jlexer.Lexer, out *SignupResponse) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain9(out *jwriter.Writer, in SignupResponse) {
+func easyjson3e1fa5ecEncodeMainDomain12(out *jwriter.Writer, in Upload) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
 		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.UserID))
-	}
-	{
-		const prefix string = ",\"access_token\":"
-		out.RawString(prefix)
-		out.String(string(in.AccessToken))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"refresh_token\":"
+		const prefix string = ",\"target\":"
 		out.RawString(prefix)
-		out.String(string(in.RefreshToken))
+		out.String(string(in.Target))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v SignupResponse) MarshalJSON() ([]byte, error) {
+func (v Upload) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain9(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain12(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v SignupResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain9(w, v)
+func (v Upload) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain12(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *SignupResponse) UnmarshalJSON(data []byte) error {
+func (v *Upload) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain9(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain12(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *SignupResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain9(l, v)
+func (v *Upload) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain12(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain10(in *jlexer.Lexer, out *SignupRequest) {
+func easyjson3e1fa5ecDecodeMainDomain13(in *jlexer.Lexer, out *TwoFactor) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1114,12 +1342,8 @@ func easyjson3e1fa5ecDecodeMainDomain10(in *jlexer.Lexer, out *SignupRequest) {
 			continue
 		}
 		switch key {
-		case "username":
-			out.Username = string(in.String())
-		case "email":
-			out.Email = string(in.String())
-		case "password":
-			out.Password = string(in.String())
+		case "enabled":
+			out.Enabled = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -1130,52 +1354,42 @@ func easyjson3e1fa5ecDecodeMainDomain10(in *jlexer.Lexer, out *SignupRequest) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain10(out *jwriter.Writer, in SignupRequest) {
+func easyjson3e1fa5ecEncodeMainDomain13(out *jwriter.Writer, in TwoFactor) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"username\":"
+		const prefix string = ",\"enabled\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Username))
-	}
-	{
-		const prefix string = ",\"email\":"
-		out.RawString(prefix)
-		out.String(string(in.Email))
-	}
-	{
-		const prefix string = ",\"password\":"
-		out.RawString(prefix)
-		out.String(string(in.Password))
+		out.Bool(bool(in.Enabled))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v SignupRequest) MarshalJSON() ([]byte, error) {
+func (v TwoFactor) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain10(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain13(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v SignupRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain10(w, v)
+func (v TwoFactor) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain13(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *SignupRequest) UnmarshalJSON(data []byte) error {
+func (v *TwoFactor) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain10(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain13(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *SignupRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain10(l, v)
+func (v *TwoFactor) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain13(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain11(in *jlexer.Lexer, out *RightAnswerItem) {
+func easyjson3e1fa5ecDecodeMainDomain14(in *jlexer.Lexer, out *TOTPEnrollResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1194,10 +1408,10 @@ func easyjson3e1fa5ecDecodeMainDomain11(in *jlexer.Lexer, out *RightAnswerItem)
 			continue
 		}
 		switch key {
-		case "card_id":
-			out.CardID = int(in.Int())
-		case "type":
-			out.Type = string(in.String())
+		case "secret":
+			out.Secret = string(in.String())
+		case "otpauth_uri":
+			out.URI = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1208,47 +1422,47 @@ func easyjson3e1fa5ecDecodeMainDomain11(in *jlexer.Lexer, out *RightAnswerItem)
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain11(out *jwriter.Writer, in RightAnswerItem) {
+func easyjson3e1fa5ecEncodeMainDomain14(out *jwriter.Writer, in TOTPEnrollResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"card_id\":"
+		const prefix string = ",\"secret\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.CardID))
+		out.String(string(in.Secret))
 	}
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"otpauth_uri\":"
 		out.RawString(prefix)
-		out.String(string(in.Type))
+		out.String(string(in.URI))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RightAnswerItem) MarshalJSON() ([]byte, error) {
+func (v TOTPEnrollResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain11(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain14(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RightAnswerItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain11(w, v)
+func (v TOTPEnrollResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain14(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RightAnswerItem) UnmarshalJSON(data []byte) error {
+func (v *TOTPEnrollResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain11(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain14(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RightAnswerItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain11(l, v)
+func (v *TOTPEnrollResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain14(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain12(in *jlexer.Lexer, out *RefreshTokenResponse) {
+func easyjson3e1fa5ecDecodeMainDomain15(in *jlexer.Lexer, out *TOTPCodeRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1267,10 +1481,8 @@ func easyjson3e1fa5ecDecodeMainDomain12(in *jlexer.Lexer, out *RefreshTokenRespo
 			continue
 		}
 		switch key {
-		case "access_token":
-			out.AccessToken = string(in.String())
-		case "refresh_token":
-			out.RefreshToken = string(in.String())
+		case "code":
+			out.Code = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1281,47 +1493,42 @@ func easyjson3e1fa5ecDecodeMainDomain12(in *jlexer.Lexer, out *RefreshTokenRespo
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain12(out *jwriter.Writer, in RefreshTokenResponse) {
+func easyjson3e1fa5ecEncodeMainDomain15(out *jwriter.Writer, in TOTPCodeRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"access_token\":"
+		const prefix string = ",\"code\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.AccessToken))
-	}
-	{
-		const prefix string = ",\"refresh_token\":"
-		out.RawString(prefix)
-		out.String(string(in.RefreshToken))
+		out.String(string(in.Code))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RefreshTokenResponse) MarshalJSON() ([]byte, error) {
+func (v TOTPCodeRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain12(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RefreshTokenResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain12(w, v)
+func (v TOTPCodeRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RefreshTokenResponse) UnmarshalJSON(data []byte) error {
+func (v *TOTPCodeRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain12(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RefreshTokenResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain12(l, v)
+func (v *TOTPCodeRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain15(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain13(in *jlexer.Lexer, out *RefreshTokenRequest) {
+func easyjson3e1fa5ecDecodeMainDomain16(in *jlexer.Lexer, out *SuccessResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1340,8 +1547,8 @@ func easyjson3e1fa5ecDecodeMainDomain13(in *jlexer.Lexer, out *RefreshTokenReque
 			continue
 		}
 		switch key {
-		case "refresh_token":
-			out.RefreshToken = string(in.String())
+		case "message":
+			out.Message = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1352,42 +1559,42 @@ func easyjson3e1fa5ecDecodeMainDomain13(in *jlexer.Lexer, out *RefreshTokenReque
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain13(out *jwriter.Writer, in RefreshTokenRequest) {
+func easyjson3e1fa5ecEncodeMainDomain16(out *jwriter.Writer, in SuccessResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"refresh_token\":"
+		const prefix string = ",\"message\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.RefreshToken))
+		out.String(string(in.Message))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RefreshTokenRequest) MarshalJSON() ([]byte, error) {
+func (v SuccessResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain13(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain16(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RefreshTokenRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain13(w, v)
+func (v SuccessResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain16(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RefreshTokenRequest) UnmarshalJSON(data []byte) error {
+func (v *SuccessResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain13(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain16(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RefreshTokenRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain13(l, v)
+func (v *SuccessResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain16(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain14(in *jlexer.Lexer, out *PublicUserInfo) {
+func easyjson3e1fa5ecDecodeMainDomain17(in *jlexer.Lexer, out *SmallHistoryItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1406,37 +1613,58 @@ func easyjson3e1fa5ecDecodeMainDomain14(in *jlexer.Lexer, out *PublicUserInfo) {
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
-		case "username":
-			out.Username = string(in.String())
-		case "has_picture":
-			out.HasPicture = bool(in.Bool())
-		case "collections":
+		case "collection_name":
+			out.CollectionName = string(in.String())
+		case "time":
+			out.Time = int(in.Int())
+		case "correct_cards":
 			if in.IsNull() {
 				in.Skip()
-				out.PublicCollections = nil
+				out.CorrectCards = nil
 			} else {
 				in.Delim('[')
-				if out.PublicCollections == nil {
+				if out.CorrectCards == nil {
 					if !in.IsDelim(']') {
-						out.PublicCollections = make([]string, 0, 4)
+						out.CorrectCards = make([]int, 0, 8)
 					} else {
-						out.PublicCollections = []string{}
+						out.CorrectCards = []int{}
 					}
 				} else {
-					out.PublicCollections = (out.PublicCollections)[:0]
+					out.CorrectCards = (out.CorrectCards)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v25 string
-					v25 = string(in.String())
-					out.PublicCollections = append(out.PublicCollections, v25)
+					var v21 int
+					v21 = int(in.Int())
+					out.CorrectCards = append(out.CorrectCards, v21)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "statistics":
-			(out.Statistics).UnmarshalEasyJSON(in)
+		case "incorrect_cards":
+			if in.IsNull() {
+				in.Skip()
+				out.IncorrectCards = nil
+			} else {
+				in.Delim('[')
+				if out.IncorrectCards == nil {
+					if !in.IsDelim(']') {
+						out.IncorrectCards = make([]int, 0, 8)
+					} else {
+						out.IncorrectCards = []int{}
+					}
+				} else {
+					out.IncorrectCards = (out.IncorrectCards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 int
+					v22 = int(in.Int())
+					out.IncorrectCards = append(out.IncorrectCards, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "all_cards_count":
+			out.AllCardsCount = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1447,73 +1675,84 @@ func easyjson3e1fa5ecDecodeMainDomain14(in *jlexer.Lexer, out *PublicUserInfo) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain14(out *jwriter.Writer, in PublicUserInfo) {
+func easyjson3e1fa5ecEncodeMainDomain17(out *jwriter.Writer, in SmallHistoryItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"collection_name\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
+		out.String(string(in.CollectionName))
 	}
 	{
-		const prefix string = ",\"username\":"
+		const prefix string = ",\"time\":"
 		out.RawString(prefix)
-		out.String(string(in.Username))
+		out.Int(int(in.Time))
 	}
 	{
-		const prefix string = ",\"has_picture\":"
+		const prefix string = ",\"correct_cards\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.HasPicture))
+		if in.CorrectCards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.CorrectCards {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v24))
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"collections\":"
+		const prefix string = ",\"incorrect_cards\":"
 		out.RawString(prefix)
-		if in.PublicCollections == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.IncorrectCards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v26, v27 := range in.PublicCollections {
-				if v26 > 0 {
+			for v25, v26 := range in.IncorrectCards {
+				if v25 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v27))
+				out.Int(int(v26))
 			}
 			out.RawByte(']')
 		}
 	}
 	{
-		const prefix string = ",\"statistics\":"
+		const prefix string = ",\"all_cards_count\":"
 		out.RawString(prefix)
-		(in.Statistics).MarshalEasyJSON(out)
+		out.Int(int(in.AllCardsCount))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PublicUserInfo) MarshalJSON() ([]byte, error) {
+func (v SmallHistoryItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain14(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PublicUserInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain14(w, v)
+func (v SmallHistoryItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PublicUserInfo) UnmarshalJSON(data []byte) error {
+func (v *SmallHistoryItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain14(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PublicUserInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain14(l, v)
+func (v *SmallHistoryItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain17(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain15(in *jlexer.Lexer, out *PlanResponse) {
+func easyjson3e1fa5ecDecodeMainDomain18(in *jlexer.Lexer, out *SignupResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1532,31 +1771,12 @@ func easyjson3e1fa5ecDecodeMainDomain15(in *jlexer.Lexer, out *PlanResponse) {
 			continue
 		}
 		switch key {
-		case "count":
-			out.Count = int(in.Int())
-		case "items":
-			if in.IsNull() {
-				in.Skip()
-				out.Items = nil
-			} else {
-				in.Delim('[')
-				if out.Items == nil {
-					if !in.IsDelim(']') {
-						out.Items = make([]int, 0, 8)
-					} else {
-						out.Items = []int{}
-					}
-				} else {
-					out.Items = (out.Items)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v28 int
-					v28 = int(in.Int())
-					out.Items = append(out.Items, v28)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "id":
+			out.UserID = string(in.String())
+		case "access_token":
+			out.AccessToken = string(in.String())
+		case "refresh_token":
+			out.RefreshToken = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1567,58 +1787,52 @@ func easyjson3e1fa5ecDecodeMainDomain15(in *jlexer.Lexer, out *PlanResponse) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain15(out *jwriter.Writer, in PlanResponse) {
+func easyjson3e1fa5ecEncodeMainDomain18(out *jwriter.Writer, in SignupResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"count\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Count))
+		out.String(string(in.UserID))
 	}
 	{
-		const prefix string = ",\"items\":"
+		const prefix string = ",\"access_token\":"
 		out.RawString(prefix)
-		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v29, v30 := range in.Items {
-				if v29 > 0 {
-					out.RawByte(',')
-				}
-				out.Int(int(v30))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.AccessToken))
+	}
+	{
+		const prefix string = ",\"refresh_token\":"
+		out.RawString(prefix)
+		out.String(string(in.RefreshToken))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PlanResponse) MarshalJSON() ([]byte, error) {
+func (v SignupResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain15(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain18(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PlanResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain15(w, v)
+func (v SignupResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain18(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PlanResponse) UnmarshalJSON(data []byte) error {
+func (v *SignupResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain15(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain18(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PlanResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain15(l, v)
+func (v *SignupResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain18(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain16(in *jlexer.Lexer, out *OtherAnswers) {
+func easyjson3e1fa5ecDecodeMainDomain19(in *jlexer.Lexer, out *SignupRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1637,31 +1851,12 @@ func easyjson3e1fa5ecDecodeMainDomain16(in *jlexer.Lexer, out *OtherAnswers) {
 			continue
 		}
 		switch key {
-		case "count":
-			out.Count = int(in.Int())
-		case "items":
-			if in.IsNull() {
-				in.Skip()
-				out.Items = nil
-			} else {
-				in.Delim('[')
-				if out.Items == nil {
-					if !in.IsDelim(']') {
-						out.Items = make([]string, 0, 4)
-					} else {
-						out.Items = []string{}
-					}
-				} else {
-					out.Items = (out.Items)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v31 string
-					v31 = string(in.String())
-					out.Items = append(out.Items, v31)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "username":
+			out.Username = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "password":
+			out.Password = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1672,58 +1867,52 @@ func easyjson3e1fa5ecDecodeMainDomain16(in *jlexer.Lexer, out *OtherAnswers) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain16(out *jwriter.Writer, in OtherAnswers) {
+func easyjson3e1fa5ecEncodeMainDomain19(out *jwriter.Writer, in SignupRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"count\":"
+		const prefix string = ",\"username\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Count))
+		out.String(string(in.Username))
 	}
 	{
-		const prefix string = ",\"items\":"
+		const prefix string = ",\"email\":"
 		out.RawString(prefix)
-		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v32, v33 := range in.Items {
-				if v32 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v33))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v OtherAnswers) MarshalJSON() ([]byte, error) {
+func (v SignupRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain16(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v OtherAnswers) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain16(w, v)
+func (v SignupRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *OtherAnswers) UnmarshalJSON(data []byte) error {
+func (v *SignupRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain16(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *OtherAnswers) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain16(l, v)
+func (v *SignupRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain19(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain17(in *jlexer.Lexer, out *MetricsRequest) {
+func easyjson3e1fa5ecDecodeMainDomain20(in *jlexer.Lexer, out *SessionInfoArray) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1742,16 +1931,31 @@ func easyjson3e1fa5ecDecodeMainDomain17(in *jlexer.Lexer, out *MetricsRequest) {
 			continue
 		}
 		switch key {
-		case "favourite_filter_button":
-			out.FilterClicks = int(in.Int())
-		case "favourite_profile_button":
-			out.ProfileClicks = int(in.Int())
-		case "last_in_app_time":
-			out.LastInAppTime = int(in.Int())
-		case "sum_trainings_time":
-			out.SumTrainingsTime = int(in.Int())
-		case "trainings_count":
-			out.TrainingsCount = int(in.Int())
+		case "count":
+			out.Count = int(in.Int())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]SessionInfo, 0, 0)
+					} else {
+						out.Items = []SessionInfo{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v27 SessionInfo
+					(v27).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v27)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1762,62 +1966,58 @@ func easyjson3e1fa5ecDecodeMainDomain17(in *jlexer.Lexer, out *MetricsRequest) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain17(out *jwriter.Writer, in MetricsRequest) {
+func easyjson3e1fa5ecEncodeMainDomain20(out *jwriter.Writer, in SessionInfoArray) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"favourite_filter_button\":"
+		const prefix string = ",\"count\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.FilterClicks))
-	}
-	{
-		const prefix string = ",\"favourite_profile_button\":"
-		out.RawString(prefix)
-		out.Int(int(in.ProfileClicks))
-	}
-	{
-		const prefix string = ",\"last_in_app_time\":"
-		out.RawString(prefix)
-		out.Int(int(in.LastInAppTime))
-	}
-	{
-		const prefix string = ",\"sum_trainings_time\":"
-		out.RawString(prefix)
-		out.Int(int(in.SumTrainingsTime))
+		out.Int(int(in.Count))
 	}
 	{
-		const prefix string = ",\"trainings_count\":"
+		const prefix string = ",\"items\":"
 		out.RawString(prefix)
-		out.Int(int(in.TrainingsCount))
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v28, v29 := range in.Items {
+				if v28 > 0 {
+					out.RawByte(',')
+				}
+				(v29).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MetricsRequest) MarshalJSON() ([]byte, error) {
+func (v SessionInfoArray) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain17(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain20(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MetricsRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain17(w, v)
+func (v SessionInfoArray) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain20(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MetricsRequest) UnmarshalJSON(data []byte) error {
+func (v *SessionInfoArray) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain17(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain20(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MetricsRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain17(l, v)
+func (v *SessionInfoArray) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain20(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain18(in *jlexer.Lexer, out *LoginResponse) {
+func easyjson3e1fa5ecDecodeMainDomain21(in *jlexer.Lexer, out *SessionInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1836,10 +2036,20 @@ func easyjson3e1fa5ecDecodeMainDomain18(in *jlexer.Lexer, out *LoginResponse) {
 			continue
 		}
 		switch key {
-		case "access_token":
-			out.AccessToken = string(in.String())
-		case "refresh_token":
-			out.RefreshToken = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "device_name":
+			out.DeviceName = string(in.String())
+		case "user_agent":
+			out.UserAgent = string(in.String())
+		case "ip":
+			out.IP = string(in.String())
+		case "created_at":
+			out.CreatedAt = int64(in.Int64())
+		case "last_used_at":
+			out.LastUsedAt = int64(in.Int64())
+		case "current":
+			out.Current = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -1850,47 +2060,72 @@ func easyjson3e1fa5ecDecodeMainDomain18(in *jlexer.Lexer, out *LoginResponse) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain18(out *jwriter.Writer, in LoginResponse) {
+func easyjson3e1fa5ecEncodeMainDomain21(out *jwriter.Writer, in SessionInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"access_token\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.AccessToken))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"refresh_token\":"
+		const prefix string = ",\"device_name\":"
 		out.RawString(prefix)
-		out.String(string(in.RefreshToken))
+		out.String(string(in.DeviceName))
+	}
+	{
+		const prefix string = ",\"user_agent\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAgent))
+	}
+	{
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix)
+		out.String(string(in.IP))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"last_used_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LastUsedAt))
+	}
+	{
+		const prefix string = ",\"current\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Current))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LoginResponse) MarshalJSON() ([]byte, error) {
+func (v SessionInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain18(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain21(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LoginResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain18(w, v)
+func (v SessionInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain21(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LoginResponse) UnmarshalJSON(data []byte) error {
+func (v *SessionInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain18(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain21(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LoginResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain18(l, v)
+func (v *SessionInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain21(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain19(in *jlexer.Lexer, out *LoginRequest) {
+func easyjson3e1fa5ecDecodeMainDomain22(in *jlexer.Lexer, out *Session) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1909,10 +2144,24 @@ func easyjson3e1fa5ecDecodeMainDomain19(in *jlexer.Lexer, out *LoginRequest) {
 			continue
 		}
 		switch key {
-		case "email":
-			out.Email = string(in.String())
-		case "password":
-			out.Password = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "user_id":
+			out.UserID = string(in.String())
+		case "device_name":
+			out.DeviceName = string(in.String())
+		case "user_agent":
+			out.UserAgent = string(in.String())
+		case "ip":
+			out.IP = string(in.String())
+		case "created_at":
+			out.CreatedAt = int64(in.Int64())
+		case "last_used_at":
+			out.LastUsedAt = int64(in.Int64())
+		case "expires_at":
+			out.ExpiresAt = int64(in.Int64())
+		case "revoked":
+			out.Revoked = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -1923,47 +2172,82 @@ func easyjson3e1fa5ecDecodeMainDomain19(in *jlexer.Lexer, out *LoginRequest) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain19(out *jwriter.Writer, in LoginRequest) {
+func easyjson3e1fa5ecEncodeMainDomain22(out *jwriter.Writer, in Session) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"email\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Email))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"password\":"
+		const prefix string = ",\"user_id\":"
 		out.RawString(prefix)
-		out.String(string(in.Password))
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"device_name\":"
+		out.RawString(prefix)
+		out.String(string(in.DeviceName))
+	}
+	{
+		const prefix string = ",\"user_agent\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAgent))
+	}
+	{
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix)
+		out.String(string(in.IP))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"last_used_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LastUsedAt))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresAt))
+	}
+	{
+		const prefix string = ",\"revoked\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Revoked))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LoginRequest) MarshalJSON() ([]byte, error) {
+func (v Session) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain19(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LoginRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain19(w, v)
+func (v Session) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LoginRequest) UnmarshalJSON(data []byte) error {
+func (v *Session) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain19(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LoginRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain19(l, v)
+func (v *Session) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain22(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain20(in *jlexer.Lexer, out *JwtCustomRefreshClaims) {
+func easyjson3e1fa5ecDecodeMainDomain23(in *jlexer.Lexer, out *RightAnswerItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1982,52 +2266,10 @@ func easyjson3e1fa5ecDecodeMainDomain20(in *jlexer.Lexer, out *JwtCustomRefreshC
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
-		case "iss":
-			out.Issuer = string(in.String())
-		case "sub":
-			out.Subject = string(in.String())
-		case "aud":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Audience).UnmarshalJSON(data))
-			}
-		case "exp":
-			if in.IsNull() {
-				in.Skip()
-				out.ExpiresAt = nil
-			} else {
-				if out.ExpiresAt == nil {
-					out.ExpiresAt = new(_v4.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
-				}
-			}
-		case "nbf":
-			if in.IsNull() {
-				in.Skip()
-				out.NotBefore = nil
-			} else {
-				if out.NotBefore == nil {
-					out.NotBefore = new(_v4.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.NotBefore).UnmarshalJSON(data))
-				}
-			}
-		case "iat":
-			if in.IsNull() {
-				in.Skip()
-				out.IssuedAt = nil
-			} else {
-				if out.IssuedAt == nil {
-					out.IssuedAt = new(_v4.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.IssuedAt).UnmarshalJSON(data))
-				}
-			}
+		case "card_id":
+			out.CardID = int(in.Int())
+		case "type":
+			out.Type = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2038,44 +2280,2707 @@ func easyjson3e1fa5ecDecodeMainDomain20(in *jlexer.Lexer, out *JwtCustomRefreshC
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain20(out *jwriter.Writer, in JwtCustomRefreshClaims) {
+func easyjson3e1fa5ecEncodeMainDomain23(out *jwriter.Writer, in RightAnswerItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"card_id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
-	}
-	if in.Issuer != "" {
-		const prefix string = ",\"iss\":"
-		out.RawString(prefix)
-		out.String(string(in.Issuer))
-	}
-	if in.Subject != "" {
-		const prefix string = ",\"sub\":"
-		out.RawString(prefix)
-		out.String(string(in.Subject))
-	}
-	if len(in.Audience) != 0 {
-		const prefix string = ",\"aud\":"
-		out.RawString(prefix)
-		out.Raw((in.Audience).MarshalJSON())
-	}
-	if in.ExpiresAt != nil {
-		const prefix string = ",\"exp\":"
-		out.RawString(prefix)
-		out.Raw((*in.ExpiresAt).MarshalJSON())
-	}
-	if in.NotBefore != nil {
-		const prefix string = ",\"nbf\":"
-		out.RawString(prefix)
-		out.Raw((*in.NotBefore).MarshalJSON())
+		out.Int(int(in.CardID))
 	}
-	if in.IssuedAt != nil {
-		const prefix string = ",\"iat\":"
+	{
+		const prefix string = ",\"type\":"
 		out.RawString(prefix)
-		out.Raw((*in.IssuedAt).MarshalJSON())
+		out.String(string(in.Type))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RightAnswerItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RightAnswerItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RightAnswerItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RightAnswerItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain23(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain24(in *jlexer.Lexer, out *ResetPasswordRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "token":
+			out.Token = string(in.String())
+		case "new_password":
+			out.NewPassword = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain24(out *jwriter.Writer, in ResetPasswordRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"new_password\":"
+		out.RawString(prefix)
+		out.String(string(in.NewPassword))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ResetPasswordRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ResetPasswordRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ResetPasswordRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ResetPasswordRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain24(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain25(in *jlexer.Lexer, out *ReorderAttachmentsRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ids":
+			if in.IsNull() {
+				in.Skip()
+				out.IDs = nil
+			} else {
+				in.Delim('[')
+				if out.IDs == nil {
+					if !in.IsDelim(']') {
+						out.IDs = make([]string, 0, 4)
+					} else {
+						out.IDs = []string{}
+					}
+				} else {
+					out.IDs = (out.IDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v30 string
+					v30 = string(in.String())
+					out.IDs = append(out.IDs, v30)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain25(out *jwriter.Writer, in ReorderAttachmentsRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ids\":"
+		out.RawString(prefix[1:])
+		if in.IDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v31, v32 := range in.IDs {
+				if v31 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v32))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReorderAttachmentsRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReorderAttachmentsRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReorderAttachmentsRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReorderAttachmentsRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain25(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain26(in *jlexer.Lexer, out *RefreshTokenResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "access_token":
+			out.AccessToken = string(in.String())
+		case "refresh_token":
+			out.RefreshToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain26(out *jwriter.Writer, in RefreshTokenResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"access_token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.AccessToken))
+	}
+	{
+		const prefix string = ",\"refresh_token\":"
+		out.RawString(prefix)
+		out.String(string(in.RefreshToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RefreshTokenResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RefreshTokenResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RefreshTokenResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RefreshTokenResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain26(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain27(in *jlexer.Lexer, out *RefreshTokenRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "refresh_token":
+			out.RefreshToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain27(out *jwriter.Writer, in RefreshTokenRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"refresh_token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RefreshToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RefreshTokenRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RefreshTokenRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RefreshTokenRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RefreshTokenRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain27(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain28(in *jlexer.Lexer, out *RecoveryCodesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "recovery_codes":
+			if in.IsNull() {
+				in.Skip()
+				out.RecoveryCodes = nil
+			} else {
+				in.Delim('[')
+				if out.RecoveryCodes == nil {
+					if !in.IsDelim(']') {
+						out.RecoveryCodes = make([]string, 0, 4)
+					} else {
+						out.RecoveryCodes = []string{}
+					}
+				} else {
+					out.RecoveryCodes = (out.RecoveryCodes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v33 string
+					v33 = string(in.String())
+					out.RecoveryCodes = append(out.RecoveryCodes, v33)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain28(out *jwriter.Writer, in RecoveryCodesResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"recovery_codes\":"
+		out.RawString(prefix[1:])
+		if in.RecoveryCodes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v34, v35 := range in.RecoveryCodes {
+				if v34 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v35))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RecoveryCodesResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RecoveryCodesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RecoveryCodesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RecoveryCodesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain28(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain29(in *jlexer.Lexer, out *PublicUserInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "username":
+			out.Username = string(in.String())
+		case "has_picture":
+			out.HasPicture = bool(in.Bool())
+		case "collections":
+			if in.IsNull() {
+				in.Skip()
+				out.PublicCollections = nil
+			} else {
+				in.Delim('[')
+				if out.PublicCollections == nil {
+					if !in.IsDelim(']') {
+						out.PublicCollections = make([]string, 0, 4)
+					} else {
+						out.PublicCollections = []string{}
+					}
+				} else {
+					out.PublicCollections = (out.PublicCollections)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v36 string
+					v36 = string(in.String())
+					out.PublicCollections = append(out.PublicCollections, v36)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "statistics":
+			(out.Statistics).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain29(out *jwriter.Writer, in PublicUserInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"username\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	{
+		const prefix string = ",\"has_picture\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HasPicture))
+	}
+	{
+		const prefix string = ",\"collections\":"
+		out.RawString(prefix)
+		if in.PublicCollections == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v37, v38 := range in.PublicCollections {
+				if v37 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v38))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"statistics\":"
+		out.RawString(prefix)
+		(in.Statistics).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PublicUserInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PublicUserInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PublicUserInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PublicUserInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain29(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain30(in *jlexer.Lexer, out *Principal) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "UserID":
+			out.UserID = string(in.String())
+		case "Username":
+			out.Username = string(in.String())
+		case "Roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]string, 0, 4)
+					} else {
+						out.Roles = []string{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v39 string
+					v39 = string(in.String())
+					out.Roles = append(out.Roles, v39)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "Scopes":
+			if in.IsNull() {
+				in.Skip()
+				out.Scopes = nil
+			} else {
+				in.Delim('[')
+				if out.Scopes == nil {
+					if !in.IsDelim(']') {
+						out.Scopes = make([]string, 0, 4)
+					} else {
+						out.Scopes = []string{}
+					}
+				} else {
+					out.Scopes = (out.Scopes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v40 string
+					v40 = string(in.String())
+					out.Scopes = append(out.Scopes, v40)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "SessionID":
+			out.SessionID = string(in.String())
+		case "AuthMethod":
+			out.AuthMethod = AuthMethod(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain30(out *jwriter.Writer, in Principal) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"UserID\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"Username\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	{
+		const prefix string = ",\"Roles\":"
+		out.RawString(prefix)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v41, v42 := range in.Roles {
+				if v41 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v42))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"Scopes\":"
+		out.RawString(prefix)
+		if in.Scopes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v43, v44 := range in.Scopes {
+				if v43 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v44))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"SessionID\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionID))
+	}
+	{
+		const prefix string = ",\"AuthMethod\":"
+		out.RawString(prefix)
+		out.String(string(in.AuthMethod))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Principal) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Principal) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Principal) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Principal) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain30(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain31(in *jlexer.Lexer, out *PresignedURL) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.URL = string(in.String())
+		case "method":
+			out.Method = string(in.String())
+		case "expires_at":
+			out.ExpiresAt = int64(in.Int64())
+		case "proxy":
+			out.Proxy = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain31(out *jwriter.Writer, in PresignedURL) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"method\":"
+		out.RawString(prefix)
+		out.String(string(in.Method))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresAt))
+	}
+	{
+		const prefix string = ",\"proxy\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Proxy))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PresignedURL) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain31(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PresignedURL) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain31(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PresignedURL) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain31(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PresignedURL) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain31(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain32(in *jlexer.Lexer, out *PlanResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = int(in.Int())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]int, 0, 8)
+					} else {
+						out.Items = []int{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v45 int
+					v45 = int(in.Int())
+					out.Items = append(out.Items, v45)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain32(out *jwriter.Writer, in PlanResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v46, v47 := range in.Items {
+				if v46 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v47))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PlanResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain32(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PlanResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain32(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PlanResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain32(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PlanResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain32(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain33(in *jlexer.Lexer, out *OtherAnswers) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = int(in.Int())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]string, 0, 4)
+					} else {
+						out.Items = []string{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v48 string
+					v48 = string(in.String())
+					out.Items = append(out.Items, v48)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain33(out *jwriter.Writer, in OtherAnswers) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v49, v50 := range in.Items {
+				if v49 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v50))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OtherAnswers) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain33(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OtherAnswers) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain33(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OtherAnswers) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain33(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OtherAnswers) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain33(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain34(in *jlexer.Lexer, out *OAuthState) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "provider":
+			out.Provider = string(in.String())
+		case "created_at":
+			out.CreatedAt = int64(in.Int64())
+		case "expires_at":
+			out.ExpiresAt = int64(in.Int64())
+		case "used":
+			out.Used = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain34(out *jwriter.Writer, in OAuthState) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"provider\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Provider))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresAt))
+	}
+	{
+		const prefix string = ",\"used\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Used))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OAuthState) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OAuthState) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OAuthState) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OAuthState) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain34(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain35(in *jlexer.Lexer, out *OAuthStartResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "authorization_url":
+			out.AuthorizationURL = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain35(out *jwriter.Writer, in OAuthStartResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"authorization_url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.AuthorizationURL))
+	}
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix)
+		out.String(string(in.State))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OAuthStartResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain35(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OAuthStartResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain35(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OAuthStartResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain35(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OAuthStartResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain35(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain36(in *jlexer.Lexer, out *OAuthCallbackRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		case "device_id":
+			out.DeviceID = string(in.String())
+		case "device":
+			out.Device = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain36(out *jwriter.Writer, in OAuthCallbackRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"state\":"
+		out.RawString(prefix)
+		out.String(string(in.State))
+	}
+	{
+		const prefix string = ",\"device_id\":"
+		out.RawString(prefix)
+		out.String(string(in.DeviceID))
+	}
+	{
+		const prefix string = ",\"device\":"
+		out.RawString(prefix)
+		out.String(string(in.Device))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OAuthCallbackRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain36(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OAuthCallbackRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain36(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OAuthCallbackRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain36(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OAuthCallbackRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain36(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain37(in *jlexer.Lexer, out *MetricsRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "favourite_filter_button":
+			out.FilterClicks = int(in.Int())
+		case "favourite_profile_button":
+			out.ProfileClicks = int(in.Int())
+		case "last_in_app_time":
+			out.LastInAppTime = int(in.Int())
+		case "sum_trainings_time":
+			out.SumTrainingsTime = int(in.Int())
+		case "trainings_count":
+			out.TrainingsCount = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain37(out *jwriter.Writer, in MetricsRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"favourite_filter_button\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.FilterClicks))
+	}
+	{
+		const prefix string = ",\"favourite_profile_button\":"
+		out.RawString(prefix)
+		out.Int(int(in.ProfileClicks))
+	}
+	{
+		const prefix string = ",\"last_in_app_time\":"
+		out.RawString(prefix)
+		out.Int(int(in.LastInAppTime))
+	}
+	{
+		const prefix string = ",\"sum_trainings_time\":"
+		out.RawString(prefix)
+		out.Int(int(in.SumTrainingsTime))
+	}
+	{
+		const prefix string = ",\"trainings_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.TrainingsCount))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MetricsRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain37(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MetricsRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain37(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MetricsRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain37(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MetricsRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain37(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain38(in *jlexer.Lexer, out *MediaRelease) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Disowned":
+			out.Disowned = bool(in.Bool())
+		case "Unused":
+			out.Unused = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain38(out *jwriter.Writer, in MediaRelease) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Disowned\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Disowned))
+	}
+	{
+		const prefix string = ",\"Unused\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Unused))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MediaRelease) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain38(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MediaRelease) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain38(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MediaRelease) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain38(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MediaRelease) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain38(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain39(in *jlexer.Lexer, out *MediaOwner) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = string(in.String())
+		case "ref_count":
+			out.RefCount = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain39(out *jwriter.Writer, in MediaOwner) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"ref_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.RefCount))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MediaOwner) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain39(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MediaOwner) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain39(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MediaOwner) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain39(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MediaOwner) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain39(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain40(in *jlexer.Lexer, out *Media) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "content_type":
+			out.ContentType = string(in.String())
+		case "ref_count":
+			out.RefCount = int(in.Int())
+		case "owners":
+			if in.IsNull() {
+				in.Skip()
+				out.Owners = nil
+			} else {
+				in.Delim('[')
+				if out.Owners == nil {
+					if !in.IsDelim(']') {
+						out.Owners = make([]MediaOwner, 0, 2)
+					} else {
+						out.Owners = []MediaOwner{}
+					}
+				} else {
+					out.Owners = (out.Owners)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v51 MediaOwner
+					(v51).UnmarshalEasyJSON(in)
+					out.Owners = append(out.Owners, v51)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain40(out *jwriter.Writer, in Media) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"content_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	{
+		const prefix string = ",\"ref_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.RefCount))
+	}
+	{
+		const prefix string = ",\"owners\":"
+		out.RawString(prefix)
+		if in.Owners == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v52, v53 := range in.Owners {
+				if v52 > 0 {
+					out.RawByte(',')
+				}
+				(v53).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Media) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain40(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Media) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain40(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Media) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain40(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Media) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain40(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain41(in *jlexer.Lexer, out *MFAChallengeResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "mfa_required":
+			out.MFARequired = bool(in.Bool())
+		case "mfa_token":
+			out.MFAToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain41(out *jwriter.Writer, in MFAChallengeResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"mfa_required\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.MFARequired))
+	}
+	{
+		const prefix string = ",\"mfa_token\":"
+		out.RawString(prefix)
+		out.String(string(in.MFAToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MFAChallengeResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain41(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MFAChallengeResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain41(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MFAChallengeResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain41(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MFAChallengeResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain41(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain42(in *jlexer.Lexer, out *LogoutRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "refresh_token":
+			out.RefreshToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain42(out *jwriter.Writer, in LogoutRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"refresh_token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RefreshToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LogoutRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain42(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LogoutRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain42(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LogoutRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain42(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LogoutRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain42(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain43(in *jlexer.Lexer, out *LoginResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "access_token":
+			out.AccessToken = string(in.String())
+		case "refresh_token":
+			out.RefreshToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain43(out *jwriter.Writer, in LoginResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"access_token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.AccessToken))
+	}
+	{
+		const prefix string = ",\"refresh_token\":"
+		out.RawString(prefix)
+		out.String(string(in.RefreshToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LoginResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain43(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LoginResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain43(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LoginResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain43(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LoginResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain43(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain44(in *jlexer.Lexer, out *LoginRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "email":
+			out.Email = string(in.String())
+		case "password":
+			out.Password = string(in.String())
+		case "device":
+			out.Device = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain44(out *jwriter.Writer, in LoginRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	{
+		const prefix string = ",\"device\":"
+		out.RawString(prefix)
+		out.String(string(in.Device))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LoginRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain44(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LoginRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain44(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LoginRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain44(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LoginRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain44(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain45(in *jlexer.Lexer, out *LoginMFARequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "mfa_token":
+			out.MFAToken = string(in.String())
+		case "code":
+			out.Code = string(in.String())
+		case "device":
+			out.Device = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain45(out *jwriter.Writer, in LoginMFARequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"mfa_token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.MFAToken))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"device\":"
+		out.RawString(prefix)
+		out.String(string(in.Device))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LoginMFARequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain45(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LoginMFARequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain45(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LoginMFARequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain45(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LoginMFARequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain45(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain46(in *jlexer.Lexer, out *LoginAudit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "ip":
+			out.IP = string(in.String())
+		case "user_agent":
+			out.UserAgent = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "created_at":
+			out.CreatedAt = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain46(out *jwriter.Writer, in LoginAudit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix)
+		out.String(string(in.IP))
+	}
+	{
+		const prefix string = ",\"user_agent\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAgent))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LoginAudit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain46(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LoginAudit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain46(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LoginAudit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain46(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LoginAudit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain46(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain47(in *jlexer.Lexer, out *LoginAttemptPolicy) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "FreeAttempts":
+			out.FreeAttempts = int(in.Int())
+		case "IPFreeAttempts":
+			out.IPFreeAttempts = int(in.Int())
+		case "BackoffBase":
+			out.BackoffBase = time.Duration(in.Int64())
+		case "BackoffMax":
+			out.BackoffMax = time.Duration(in.Int64())
+		case "LockoutThreshold":
+			out.LockoutThreshold = int(in.Int())
+		case "LockoutDuration":
+			out.LockoutDuration = time.Duration(in.Int64())
+		case "Window":
+			out.Window = time.Duration(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain47(out *jwriter.Writer, in LoginAttemptPolicy) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"FreeAttempts\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.FreeAttempts))
+	}
+	{
+		const prefix string = ",\"IPFreeAttempts\":"
+		out.RawString(prefix)
+		out.Int(int(in.IPFreeAttempts))
+	}
+	{
+		const prefix string = ",\"BackoffBase\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BackoffBase))
+	}
+	{
+		const prefix string = ",\"BackoffMax\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BackoffMax))
+	}
+	{
+		const prefix string = ",\"LockoutThreshold\":"
+		out.RawString(prefix)
+		out.Int(int(in.LockoutThreshold))
+	}
+	{
+		const prefix string = ",\"LockoutDuration\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.LockoutDuration))
+	}
+	{
+		const prefix string = ",\"Window\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Window))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LoginAttemptPolicy) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain47(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LoginAttemptPolicy) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain47(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LoginAttemptPolicy) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain47(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LoginAttemptPolicy) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain47(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain48(in *jlexer.Lexer, out *LoginAttempt) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Failures":
+			out.Failures = int(in.Int())
+		case "LastFailure":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.LastFailure).UnmarshalJSON(data))
+			}
+		case "LockedUntil":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.LockedUntil).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain48(out *jwriter.Writer, in LoginAttempt) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Failures\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Failures))
+	}
+	{
+		const prefix string = ",\"LastFailure\":"
+		out.RawString(prefix)
+		out.Raw((in.LastFailure).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"LockedUntil\":"
+		out.RawString(prefix)
+		out.Raw((in.LockedUntil).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LoginAttempt) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain48(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LoginAttempt) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain48(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LoginAttempt) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain48(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LoginAttempt) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain48(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain49(in *jlexer.Lexer, out *LegacyCollectionInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "is_public":
+			out.IsPublic = bool(in.Bool())
+		case "cards":
+			if in.IsNull() {
+				in.Skip()
+				out.Cards = nil
+			} else {
+				in.Delim('[')
+				if out.Cards == nil {
+					if !in.IsDelim(']') {
+						out.Cards = make([]LegacyCard, 0, 0)
+					} else {
+						out.Cards = []LegacyCard{}
+					}
+				} else {
+					out.Cards = (out.Cards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v54 LegacyCard
+					(v54).UnmarshalEasyJSON(in)
+					out.Cards = append(out.Cards, v54)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "author":
+			out.Author = string(in.String())
+		case "likes":
+			out.Likes = int(in.Int())
+		case "trainings":
+			out.Trainings = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain49(out *jwriter.Writer, in LegacyCollectionInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"is_public\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsPublic))
+	}
+	{
+		const prefix string = ",\"cards\":"
+		out.RawString(prefix)
+		if in.Cards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v55, v56 := range in.Cards {
+				if v55 > 0 {
+					out.RawByte(',')
+				}
+				(v56).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"likes\":"
+		out.RawString(prefix)
+		out.Int(int(in.Likes))
+	}
+	{
+		const prefix string = ",\"trainings\":"
+		out.RawString(prefix)
+		out.Int(int(in.Trainings))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LegacyCollectionInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain49(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LegacyCollectionInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain49(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LegacyCollectionInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain49(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LegacyCollectionInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain49(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain50(in *jlexer.Lexer, out *LegacyCard) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "local_id":
+			out.LocalID = int(in.Int())
+		case "question":
+			out.Question = string(in.String())
+		case "answer":
+			out.Answer = string(in.String())
+		case "attachment":
+			out.Attachment = string(in.String())
+		case "other_answers":
+			(out.OtherAnswers).UnmarshalEasyJSON(in)
+		case "question_audio":
+			out.QuestionAudio = string(in.String())
+		case "answer_audio":
+			out.AnswerAudio = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain50(out *jwriter.Writer, in LegacyCard) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"local_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.LocalID))
+	}
+	{
+		const prefix string = ",\"question\":"
+		out.RawString(prefix)
+		out.String(string(in.Question))
+	}
+	{
+		const prefix string = ",\"answer\":"
+		out.RawString(prefix)
+		out.String(string(in.Answer))
+	}
+	{
+		const prefix string = ",\"attachment\":"
+		out.RawString(prefix)
+		out.String(string(in.Attachment))
+	}
+	{
+		const prefix string = ",\"other_answers\":"
+		out.RawString(prefix)
+		(in.OtherAnswers).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"question_audio\":"
+		out.RawString(prefix)
+		out.String(string(in.QuestionAudio))
+	}
+	{
+		const prefix string = ",\"answer_audio\":"
+		out.RawString(prefix)
+		out.String(string(in.AnswerAudio))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LegacyCard) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain50(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LegacyCard) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain50(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LegacyCard) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain50(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LegacyCard) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain50(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain51(in *jlexer.Lexer, out *JwtCustomRefreshClaims) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "typ":
+			out.Type = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "sid":
+			out.SessionID = string(in.String())
+		case "tid":
+			out.TokenID = string(in.String())
+		case "iss":
+			out.Issuer = string(in.String())
+		case "sub":
+			out.Subject = string(in.String())
+		case "aud":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Audience).UnmarshalJSON(data))
+			}
+		case "exp":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(_v4.NumericDate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		case "nbf":
+			if in.IsNull() {
+				in.Skip()
+				out.NotBefore = nil
+			} else {
+				if out.NotBefore == nil {
+					out.NotBefore = new(_v4.NumericDate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.NotBefore).UnmarshalJSON(data))
+				}
+			}
+		case "iat":
+			if in.IsNull() {
+				in.Skip()
+				out.IssuedAt = nil
+			} else {
+				if out.IssuedAt == nil {
+					out.IssuedAt = new(_v4.NumericDate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.IssuedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain51(out *jwriter.Writer, in JwtCustomRefreshClaims) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"typ\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"sid\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionID))
+	}
+	{
+		const prefix string = ",\"tid\":"
+		out.RawString(prefix)
+		out.String(string(in.TokenID))
+	}
+	if in.Issuer != "" {
+		const prefix string = ",\"iss\":"
+		out.RawString(prefix)
+		out.String(string(in.Issuer))
+	}
+	if in.Subject != "" {
+		const prefix string = ",\"sub\":"
+		out.RawString(prefix)
+		out.String(string(in.Subject))
+	}
+	if len(in.Audience) != 0 {
+		const prefix string = ",\"aud\":"
+		out.RawString(prefix)
+		out.Raw((in.Audience).MarshalJSON())
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"exp\":"
+		out.RawString(prefix)
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	if in.NotBefore != nil {
+		const prefix string = ",\"nbf\":"
+		out.RawString(prefix)
+		out.Raw((*in.NotBefore).MarshalJSON())
+	}
+	if in.IssuedAt != nil {
+		const prefix string = ",\"iat\":"
+		out.RawString(prefix)
+		out.Raw((*in.IssuedAt).MarshalJSON())
 	}
 	out.RawByte('}')
 }
@@ -2083,27 +4988,1658 @@ func easyjson3e1fa5ecEncodeMainDomain20(out *jwriter.Writer, in JwtCustomRefresh
 // MarshalJSON supports json.Marshaler interface
 func (v JwtCustomRefreshClaims) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain20(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain51(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JwtCustomRefreshClaims) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain51(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JwtCustomRefreshClaims) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain51(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JwtCustomRefreshClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain51(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain52(in *jlexer.Lexer, out *JwtCustomClaims) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "typ":
+			out.Type = string(in.String())
+		case "username":
+			out.Username = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "sid":
+			out.SessionID = string(in.String())
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]string, 0, 4)
+					} else {
+						out.Roles = []string{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v57 string
+					v57 = string(in.String())
+					out.Roles = append(out.Roles, v57)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "iss":
+			out.Issuer = string(in.String())
+		case "sub":
+			out.Subject = string(in.String())
+		case "aud":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Audience).UnmarshalJSON(data))
+			}
+		case "exp":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(_v4.NumericDate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		case "nbf":
+			if in.IsNull() {
+				in.Skip()
+				out.NotBefore = nil
+			} else {
+				if out.NotBefore == nil {
+					out.NotBefore = new(_v4.NumericDate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.NotBefore).UnmarshalJSON(data))
+				}
+			}
+		case "iat":
+			if in.IsNull() {
+				in.Skip()
+				out.IssuedAt = nil
+			} else {
+				if out.IssuedAt == nil {
+					out.IssuedAt = new(_v4.NumericDate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.IssuedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain52(out *jwriter.Writer, in JwtCustomClaims) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"typ\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"username\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"sid\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionID))
+	}
+	if len(in.Roles) != 0 {
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v58, v59 := range in.Roles {
+				if v58 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v59))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Issuer != "" {
+		const prefix string = ",\"iss\":"
+		out.RawString(prefix)
+		out.String(string(in.Issuer))
+	}
+	if in.Subject != "" {
+		const prefix string = ",\"sub\":"
+		out.RawString(prefix)
+		out.String(string(in.Subject))
+	}
+	if len(in.Audience) != 0 {
+		const prefix string = ",\"aud\":"
+		out.RawString(prefix)
+		out.Raw((in.Audience).MarshalJSON())
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"exp\":"
+		out.RawString(prefix)
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	if in.NotBefore != nil {
+		const prefix string = ",\"nbf\":"
+		out.RawString(prefix)
+		out.Raw((*in.NotBefore).MarshalJSON())
+	}
+	if in.IssuedAt != nil {
+		const prefix string = ",\"iat\":"
+		out.RawString(prefix)
+		out.Raw((*in.IssuedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JwtCustomClaims) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain52(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JwtCustomClaims) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain52(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JwtCustomClaims) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain52(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JwtCustomClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain52(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain53(in *jlexer.Lexer, out *JWKS) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "keys":
+			if in.IsNull() {
+				in.Skip()
+				out.Keys = nil
+			} else {
+				in.Delim('[')
+				if out.Keys == nil {
+					if !in.IsDelim(']') {
+						out.Keys = make([]JWK, 0, 0)
+					} else {
+						out.Keys = []JWK{}
+					}
+				} else {
+					out.Keys = (out.Keys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v60 JWK
+					(v60).UnmarshalEasyJSON(in)
+					out.Keys = append(out.Keys, v60)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain53(out *jwriter.Writer, in JWKS) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keys\":"
+		out.RawString(prefix[1:])
+		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v61, v62 := range in.Keys {
+				if v61 > 0 {
+					out.RawByte(',')
+				}
+				(v62).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JWKS) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain53(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JWKS) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain53(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JWKS) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain53(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JWKS) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain53(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain54(in *jlexer.Lexer, out *JWK) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "kty":
+			out.Kty = string(in.String())
+		case "use":
+			out.Use = string(in.String())
+		case "kid":
+			out.Kid = string(in.String())
+		case "alg":
+			out.Alg = string(in.String())
+		case "n":
+			out.N = string(in.String())
+		case "e":
+			out.E = string(in.String())
+		case "crv":
+			out.Crv = string(in.String())
+		case "x":
+			out.X = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain54(out *jwriter.Writer, in JWK) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kty\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Kty))
+	}
+	{
+		const prefix string = ",\"use\":"
+		out.RawString(prefix)
+		out.String(string(in.Use))
+	}
+	{
+		const prefix string = ",\"kid\":"
+		out.RawString(prefix)
+		out.String(string(in.Kid))
+	}
+	{
+		const prefix string = ",\"alg\":"
+		out.RawString(prefix)
+		out.String(string(in.Alg))
+	}
+	if in.N != "" {
+		const prefix string = ",\"n\":"
+		out.RawString(prefix)
+		out.String(string(in.N))
+	}
+	if in.E != "" {
+		const prefix string = ",\"e\":"
+		out.RawString(prefix)
+		out.String(string(in.E))
+	}
+	if in.Crv != "" {
+		const prefix string = ",\"crv\":"
+		out.RawString(prefix)
+		out.String(string(in.Crv))
+	}
+	if in.X != "" {
+		const prefix string = ",\"x\":"
+		out.RawString(prefix)
+		out.String(string(in.X))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JWK) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain54(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JWK) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain54(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JWK) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain54(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JWK) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain54(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain55(in *jlexer.Lexer, out *IdentityArray) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = int(in.Int())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]Identity, 0, 0)
+					} else {
+						out.Items = []Identity{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v63 Identity
+					(v63).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v63)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain55(out *jwriter.Writer, in IdentityArray) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v64, v65 := range in.Items {
+				if v64 > 0 {
+					out.RawByte(',')
+				}
+				(v65).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v IdentityArray) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain55(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v IdentityArray) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain55(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *IdentityArray) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain55(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *IdentityArray) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain55(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain56(in *jlexer.Lexer, out *Identity) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "provider":
+			out.Provider = string(in.String())
+		case "subject":
+			out.Subject = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "created_at":
+			out.CreatedAt = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain56(out *jwriter.Writer, in Identity) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"provider\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Provider))
+	}
+	{
+		const prefix string = ",\"subject\":"
+		out.RawString(prefix)
+		out.String(string(in.Subject))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.CreatedAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Identity) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain56(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Identity) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain56(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Identity) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain56(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Identity) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain56(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain57(in *jlexer.Lexer, out *HistoryItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "collection_id":
+			out.CollectionID = string(in.String())
+		case "collection_name":
+			out.CollectionName = string(in.String())
+		case "time":
+			out.Time = int(in.Int())
+		case "correct_cards":
+			if in.IsNull() {
+				in.Skip()
+				out.CorrectCards = nil
+			} else {
+				in.Delim('[')
+				if out.CorrectCards == nil {
+					if !in.IsDelim(']') {
+						out.CorrectCards = make([]int, 0, 8)
+					} else {
+						out.CorrectCards = []int{}
+					}
+				} else {
+					out.CorrectCards = (out.CorrectCards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v66 int
+					v66 = int(in.Int())
+					out.CorrectCards = append(out.CorrectCards, v66)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "incorrect_cards":
+			if in.IsNull() {
+				in.Skip()
+				out.IncorrectCards = nil
+			} else {
+				in.Delim('[')
+				if out.IncorrectCards == nil {
+					if !in.IsDelim(']') {
+						out.IncorrectCards = make([]int, 0, 8)
+					} else {
+						out.IncorrectCards = []int{}
+					}
+				} else {
+					out.IncorrectCards = (out.IncorrectCards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v67 int
+					v67 = int(in.Int())
+					out.IncorrectCards = append(out.IncorrectCards, v67)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "all_cards_count":
+			out.AllCardsCount = int(in.Int())
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]ErrorItem, 0, 0)
+					} else {
+						out.Errors = []ErrorItem{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v68 ErrorItem
+					(v68).UnmarshalEasyJSON(in)
+					out.Errors = append(out.Errors, v68)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "right_answers":
+			if in.IsNull() {
+				in.Skip()
+				out.RightAnswers = nil
+			} else {
+				in.Delim('[')
+				if out.RightAnswers == nil {
+					if !in.IsDelim(']') {
+						out.RightAnswers = make([]RightAnswerItem, 0, 2)
+					} else {
+						out.RightAnswers = []RightAnswerItem{}
+					}
+				} else {
+					out.RightAnswers = (out.RightAnswers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v69 RightAnswerItem
+					(v69).UnmarshalEasyJSON(in)
+					out.RightAnswers = append(out.RightAnswers, v69)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain57(out *jwriter.Writer, in HistoryItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"collection_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CollectionID))
+	}
+	{
+		const prefix string = ",\"collection_name\":"
+		out.RawString(prefix)
+		out.String(string(in.CollectionName))
+	}
+	{
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		out.Int(int(in.Time))
+	}
+	{
+		const prefix string = ",\"correct_cards\":"
+		out.RawString(prefix)
+		if in.CorrectCards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v70, v71 := range in.CorrectCards {
+				if v70 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v71))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"incorrect_cards\":"
+		out.RawString(prefix)
+		if in.IncorrectCards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v72, v73 := range in.IncorrectCards {
+				if v72 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v73))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"all_cards_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.AllCardsCount))
+	}
+	{
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		if in.Errors == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v74, v75 := range in.Errors {
+				if v74 > 0 {
+					out.RawByte(',')
+				}
+				(v75).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"right_answers\":"
+		out.RawString(prefix)
+		if in.RightAnswers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v76, v77 := range in.RightAnswers {
+				if v76 > 0 {
+					out.RawByte(',')
+				}
+				(v77).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HistoryItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain57(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HistoryItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain57(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HistoryItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain57(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HistoryItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain57(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain58(in *jlexer.Lexer, out *ForgotPasswordRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "email":
+			out.Email = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain58(out *jwriter.Writer, in ForgotPasswordRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Email))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForgotPasswordRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain58(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForgotPasswordRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain58(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForgotPasswordRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain58(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForgotPasswordRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain58(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain59(in *jlexer.Lexer, out *FieldError) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "field":
+			out.Field = string(in.String())
+		case "code":
+			out.Code = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain59(out *jwriter.Writer, in FieldError) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"field\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Field))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FieldError) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain59(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FieldError) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain59(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FieldError) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain59(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FieldError) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain59(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain60(in *jlexer.Lexer, out *ErrorResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "details":
+			if in.IsNull() {
+				in.Skip()
+				out.Details = nil
+			} else {
+				in.Delim('[')
+				if out.Details == nil {
+					if !in.IsDelim(']') {
+						out.Details = make([]FieldError, 0, 1)
+					} else {
+						out.Details = []FieldError{}
+					}
+				} else {
+					out.Details = (out.Details)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v78 FieldError
+					(v78).UnmarshalEasyJSON(in)
+					out.Details = append(out.Details, v78)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain60(out *jwriter.Writer, in ErrorResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if len(in.Details) != 0 {
+		const prefix string = ",\"details\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v79, v80 := range in.Details {
+				if v79 > 0 {
+					out.RawByte(',')
+				}
+				(v80).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ErrorResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain60(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ErrorResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain60(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ErrorResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain60(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ErrorResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain60(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain61(in *jlexer.Lexer, out *ErrorItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "card_id":
+			out.CardID = int(in.Int())
+		case "question":
+			out.Question = string(in.String())
+		case "answer":
+			out.Answer = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "user_answer":
+			out.UserAnswer = string(in.String())
+		case "blank_answer":
+			out.BlankAnswer = string(in.String())
+		case "attachment":
+			out.Attachment = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain61(out *jwriter.Writer, in ErrorItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"card_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.CardID))
+	}
+	{
+		const prefix string = ",\"question\":"
+		out.RawString(prefix)
+		out.String(string(in.Question))
+	}
+	{
+		const prefix string = ",\"answer\":"
+		out.RawString(prefix)
+		out.String(string(in.Answer))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"user_answer\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAnswer))
+	}
+	{
+		const prefix string = ",\"blank_answer\":"
+		out.RawString(prefix)
+		out.String(string(in.BlankAnswer))
+	}
+	{
+		const prefix string = ",\"attachment\":"
+		out.RawString(prefix)
+		out.String(string(in.Attachment))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ErrorItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain61(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ErrorItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain61(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ErrorItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain61(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ErrorItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain61(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain62(in *jlexer.Lexer, out *Error) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Kind":
+			out.Kind = ErrorKind(in.Int())
+		case "Code":
+			out.Code = string(in.String())
+		case "Message":
+			out.Message = string(in.String())
+		case "Details":
+			if in.IsNull() {
+				in.Skip()
+				out.Details = nil
+			} else {
+				in.Delim('[')
+				if out.Details == nil {
+					if !in.IsDelim(']') {
+						out.Details = make([]FieldError, 0, 1)
+					} else {
+						out.Details = []FieldError{}
+					}
+				} else {
+					out.Details = (out.Details)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v81 FieldError
+					(v81).UnmarshalEasyJSON(in)
+					out.Details = append(out.Details, v81)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "RetryAfter":
+			out.RetryAfter = time.Duration(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain62(out *jwriter.Writer, in Error) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Kind\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Kind))
+	}
+	{
+		const prefix string = ",\"Code\":"
+		out.RawString(prefix)
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"Message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"Details\":"
+		out.RawString(prefix)
+		if in.Details == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v82, v83 := range in.Details {
+				if v82 > 0 {
+					out.RawByte(',')
+				}
+				(v83).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"RetryAfter\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.RetryAfter))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Error) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain62(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Error) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain62(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Error) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain62(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Error) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain62(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain63(in *jlexer.Lexer, out *CreateApiKeyResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "key":
+			out.Key = string(in.String())
+		case "api_key":
+			(out.ApiKey).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain63(out *jwriter.Writer, in CreateApiKeyResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"key\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Key))
+	}
+	{
+		const prefix string = ",\"api_key\":"
+		out.RawString(prefix)
+		(in.ApiKey).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateApiKeyResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain63(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateApiKeyResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain63(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateApiKeyResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain63(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateApiKeyResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain63(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain64(in *jlexer.Lexer, out *CreateApiKeyRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "scopes":
+			if in.IsNull() {
+				in.Skip()
+				out.Scopes = nil
+			} else {
+				in.Delim('[')
+				if out.Scopes == nil {
+					if !in.IsDelim(']') {
+						out.Scopes = make([]string, 0, 4)
+					} else {
+						out.Scopes = []string{}
+					}
+				} else {
+					out.Scopes = (out.Scopes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v84 string
+					v84 = string(in.String())
+					out.Scopes = append(out.Scopes, v84)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "expires_in_days":
+			out.ExpiresInDays = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain64(out *jwriter.Writer, in CreateApiKeyRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"scopes\":"
+		out.RawString(prefix)
+		if in.Scopes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v85, v86 := range in.Scopes {
+				if v85 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v86))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"expires_in_days\":"
+		out.RawString(prefix)
+		out.Int(int(in.ExpiresInDays))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateApiKeyRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain64(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateApiKeyRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain64(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateApiKeyRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain64(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateApiKeyRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain64(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain65(in *jlexer.Lexer, out *CollectionPreviewArray) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = int(in.Int())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]CollectionPreview, 0, 1)
+					} else {
+						out.Items = []CollectionPreview{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v87 CollectionPreview
+					(v87).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v87)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain65(out *jwriter.Writer, in CollectionPreviewArray) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v88, v89 := range in.Items {
+				if v88 > 0 {
+					out.RawByte(',')
+				}
+				(v89).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CollectionPreviewArray) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain65(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v JwtCustomRefreshClaims) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain20(w, v)
+func (v CollectionPreviewArray) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain65(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *JwtCustomRefreshClaims) UnmarshalJSON(data []byte) error {
+func (v *CollectionPreviewArray) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain20(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain65(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *JwtCustomRefreshClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain20(l, v)
+func (v *CollectionPreviewArray) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain65(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain21(in *jlexer.Lexer, out *JwtCustomClaims) {
+func easyjson3e1fa5ecDecodeMainDomain66(in *jlexer.Lexer, out *CollectionPreview) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2122,54 +6658,18 @@ func easyjson3e1fa5ecDecodeMainDomain21(in *jlexer.Lexer, out *JwtCustomClaims)
 			continue
 		}
 		switch key {
-		case "username":
-			out.Username = string(in.String())
 		case "id":
 			out.ID = string(in.String())
-		case "iss":
-			out.Issuer = string(in.String())
-		case "sub":
-			out.Subject = string(in.String())
-		case "aud":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Audience).UnmarshalJSON(data))
-			}
-		case "exp":
-			if in.IsNull() {
-				in.Skip()
-				out.ExpiresAt = nil
-			} else {
-				if out.ExpiresAt == nil {
-					out.ExpiresAt = new(_v4.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
-				}
-			}
-		case "nbf":
-			if in.IsNull() {
-				in.Skip()
-				out.NotBefore = nil
-			} else {
-				if out.NotBefore == nil {
-					out.NotBefore = new(_v4.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.NotBefore).UnmarshalJSON(data))
-				}
-			}
-		case "iat":
-			if in.IsNull() {
-				in.Skip()
-				out.IssuedAt = nil
-			} else {
-				if out.IssuedAt == nil {
-					out.IssuedAt = new(_v4.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.IssuedAt).UnmarshalJSON(data))
-				}
-			}
+		case "name":
+			out.Name = string(in.String())
+		case "is_public":
+			out.IsPublic = bool(in.Bool())
+		case "cards_count":
+			out.CardsCount = int(in.Int())
+		case "likes":
+			out.Likes = int(in.Int())
+		case "trainings":
+			out.Trainings = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -2180,77 +6680,67 @@ func easyjson3e1fa5ecDecodeMainDomain21(in *jlexer.Lexer, out *JwtCustomClaims)
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain21(out *jwriter.Writer, in JwtCustomClaims) {
+func easyjson3e1fa5ecEncodeMainDomain66(out *jwriter.Writer, in CollectionPreview) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"username\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Username))
-	}
 	{
 		const prefix string = ",\"id\":"
-		out.RawString(prefix)
+		out.RawString(prefix[1:])
 		out.String(string(in.ID))
 	}
-	if in.Issuer != "" {
-		const prefix string = ",\"iss\":"
-		out.RawString(prefix)
-		out.String(string(in.Issuer))
-	}
-	if in.Subject != "" {
-		const prefix string = ",\"sub\":"
+	{
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		out.String(string(in.Subject))
+		out.String(string(in.Name))
 	}
-	if len(in.Audience) != 0 {
-		const prefix string = ",\"aud\":"
+	{
+		const prefix string = ",\"is_public\":"
 		out.RawString(prefix)
-		out.Raw((in.Audience).MarshalJSON())
+		out.Bool(bool(in.IsPublic))
 	}
-	if in.ExpiresAt != nil {
-		const prefix string = ",\"exp\":"
+	{
+		const prefix string = ",\"cards_count\":"
 		out.RawString(prefix)
-		out.Raw((*in.ExpiresAt).MarshalJSON())
+		out.Int(int(in.CardsCount))
 	}
-	if in.NotBefore != nil {
-		const prefix string = ",\"nbf\":"
+	{
+		const prefix string = ",\"likes\":"
 		out.RawString(prefix)
-		out.Raw((*in.NotBefore).MarshalJSON())
+		out.Int(int(in.Likes))
 	}
-	if in.IssuedAt != nil {
-		const prefix string = ",\"iat\":"
+	{
+		const prefix string = ",\"trainings\":"
 		out.RawString(prefix)
-		out.Raw((*in.IssuedAt).MarshalJSON())
+		out.Int(int(in.Trainings))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v JwtCustomClaims) MarshalJSON() ([]byte, error) {
+func (v CollectionPreview) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain21(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain66(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v JwtCustomClaims) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain21(w, v)
+func (v CollectionPreview) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain66(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *JwtCustomClaims) UnmarshalJSON(data []byte) error {
+func (v *CollectionPreview) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain21(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain66(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *JwtCustomClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain21(l, v)
+func (v *CollectionPreview) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain66(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain22(in *jlexer.Lexer, out *HistoryItem) {
+func easyjson3e1fa5ecDecodeMainDomain67(in *jlexer.Lexer, out *CollectionInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2269,102 +6759,167 @@ func easyjson3e1fa5ecDecodeMainDomain22(in *jlexer.Lexer, out *HistoryItem) {
 			continue
 		}
 		switch key {
-		case "collection_id":
-			out.CollectionID = string(in.String())
-		case "collection_name":
-			out.CollectionName = string(in.String())
-		case "time":
-			out.Time = int(in.Int())
-		case "correct_cards":
-			if in.IsNull() {
-				in.Skip()
-				out.CorrectCards = nil
-			} else {
-				in.Delim('[')
-				if out.CorrectCards == nil {
-					if !in.IsDelim(']') {
-						out.CorrectCards = make([]int, 0, 8)
-					} else {
-						out.CorrectCards = []int{}
-					}
-				} else {
-					out.CorrectCards = (out.CorrectCards)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v34 int
-					v34 = int(in.Int())
-					out.CorrectCards = append(out.CorrectCards, v34)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "incorrect_cards":
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "is_public":
+			out.IsPublic = bool(in.Bool())
+		case "cards":
 			if in.IsNull() {
 				in.Skip()
-				out.IncorrectCards = nil
+				out.Cards = nil
 			} else {
 				in.Delim('[')
-				if out.IncorrectCards == nil {
+				if out.Cards == nil {
 					if !in.IsDelim(']') {
-						out.IncorrectCards = make([]int, 0, 8)
+						out.Cards = make([]Card, 0, 0)
 					} else {
-						out.IncorrectCards = []int{}
+						out.Cards = []Card{}
 					}
 				} else {
-					out.IncorrectCards = (out.IncorrectCards)[:0]
+					out.Cards = (out.Cards)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v35 int
-					v35 = int(in.Int())
-					out.IncorrectCards = append(out.IncorrectCards, v35)
+					var v90 Card
+					(v90).UnmarshalEasyJSON(in)
+					out.Cards = append(out.Cards, v90)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "all_cards_count":
-			out.AllCardsCount = int(in.Int())
-		case "errors":
-			if in.IsNull() {
-				in.Skip()
-				out.Errors = nil
-			} else {
-				in.Delim('[')
-				if out.Errors == nil {
-					if !in.IsDelim(']') {
-						out.Errors = make([]ErrorItem, 0, 0)
-					} else {
-						out.Errors = []ErrorItem{}
-					}
-				} else {
-					out.Errors = (out.Errors)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v36 ErrorItem
-					(v36).UnmarshalEasyJSON(in)
-					out.Errors = append(out.Errors, v36)
-					in.WantComma()
+		case "author":
+			out.Author = string(in.String())
+		case "likes":
+			out.Likes = int(in.Int())
+		case "trainings":
+			out.Trainings = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e1fa5ecEncodeMainDomain67(out *jwriter.Writer, in CollectionInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"is_public\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsPublic))
+	}
+	{
+		const prefix string = ",\"cards\":"
+		out.RawString(prefix)
+		if in.Cards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v91, v92 := range in.Cards {
+				if v91 > 0 {
+					out.RawByte(',')
 				}
-				in.Delim(']')
+				(v92).MarshalEasyJSON(out)
 			}
-		case "right_answers":
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"likes\":"
+		out.RawString(prefix)
+		out.Int(int(in.Likes))
+	}
+	{
+		const prefix string = ",\"trainings\":"
+		out.RawString(prefix)
+		out.Int(int(in.Trainings))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CollectionInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e1fa5ecEncodeMainDomain67(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CollectionInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain67(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CollectionInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e1fa5ecDecodeMainDomain67(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CollectionInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain67(l, v)
+}
+func easyjson3e1fa5ecDecodeMainDomain68(in *jlexer.Lexer, out *CollectionHistory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "collection_id":
+			out.CollectionID = string(in.String())
+		case "items":
 			if in.IsNull() {
 				in.Skip()
-				out.RightAnswers = nil
+				out.Items = nil
 			} else {
 				in.Delim('[')
-				if out.RightAnswers == nil {
+				if out.Items == nil {
 					if !in.IsDelim(']') {
-						out.RightAnswers = make([]RightAnswerItem, 0, 2)
+						out.Items = make([]SmallHistoryItem, 0, 0)
 					} else {
-						out.RightAnswers = []RightAnswerItem{}
+						out.Items = []SmallHistoryItem{}
 					}
 				} else {
-					out.RightAnswers = (out.RightAnswers)[:0]
+					out.Items = (out.Items)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v37 RightAnswerItem
-					(v37).UnmarshalEasyJSON(in)
-					out.RightAnswers = append(out.RightAnswers, v37)
+					var v93 SmallHistoryItem
+					(v93).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v93)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2379,7 +6934,7 @@ func easyjson3e1fa5ecDecodeMainDomain22(in *jlexer.Lexer, out *HistoryItem) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain22(out *jwriter.Writer, in HistoryItem) {
+func easyjson3e1fa5ecEncodeMainDomain68(out *jwriter.Writer, in CollectionHistory) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2389,80 +6944,17 @@ func easyjson3e1fa5ecEncodeMainDomain22(out *jwriter.Writer, in HistoryItem) {
 		out.String(string(in.CollectionID))
 	}
 	{
-		const prefix string = ",\"collection_name\":"
-		out.RawString(prefix)
-		out.String(string(in.CollectionName))
-	}
-	{
-		const prefix string = ",\"time\":"
-		out.RawString(prefix)
-		out.Int(int(in.Time))
-	}
-	{
-		const prefix string = ",\"correct_cards\":"
-		out.RawString(prefix)
-		if in.CorrectCards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v38, v39 := range in.CorrectCards {
-				if v38 > 0 {
-					out.RawByte(',')
-				}
-				out.Int(int(v39))
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"incorrect_cards\":"
-		out.RawString(prefix)
-		if in.IncorrectCards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v40, v41 := range in.IncorrectCards {
-				if v40 > 0 {
-					out.RawByte(',')
-				}
-				out.Int(int(v41))
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"all_cards_count\":"
-		out.RawString(prefix)
-		out.Int(int(in.AllCardsCount))
-	}
-	{
-		const prefix string = ",\"errors\":"
-		out.RawString(prefix)
-		if in.Errors == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v42, v43 := range in.Errors {
-				if v42 > 0 {
-					out.RawByte(',')
-				}
-				(v43).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"right_answers\":"
+		const prefix string = ",\"items\":"
 		out.RawString(prefix)
-		if in.RightAnswers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v44, v45 := range in.RightAnswers {
-				if v44 > 0 {
+			for v94, v95 := range in.Items {
+				if v94 > 0 {
 					out.RawByte(',')
 				}
-				(v45).MarshalEasyJSON(out)
+				(v95).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2471,29 +6963,29 @@ func easyjson3e1fa5ecEncodeMainDomain22(out *jwriter.Writer, in HistoryItem) {
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v HistoryItem) MarshalJSON() ([]byte, error) {
+func (v CollectionHistory) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain22(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain68(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v HistoryItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain22(w, v)
+func (v CollectionHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain68(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *HistoryItem) UnmarshalJSON(data []byte) error {
+func (v *CollectionHistory) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain22(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain68(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *HistoryItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain22(l, v)
+func (v *CollectionHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain68(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain23(in *jlexer.Lexer, out *ErrorItem) {
+func easyjson3e1fa5ecDecodeMainDomain69(in *jlexer.Lexer, out *Collection) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2512,20 +7004,45 @@ func easyjson3e1fa5ecDecodeMainDomain23(in *jlexer.Lexer, out *ErrorItem) {
 			continue
 		}
 		switch key {
-		case "card_id":
-			out.CardID = int(in.Int())
-		case "question":
-			out.Question = string(in.String())
-		case "answer":
-			out.Answer = string(in.String())
-		case "type":
-			out.Type = string(in.String())
-		case "user_answer":
-			out.UserAnswer = string(in.String())
-		case "blank_answer":
-			out.BlankAnswer = string(in.String())
-		case "attachment":
-			out.Attachment = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "name_lower":
+			out.NameLower = string(in.String())
+		case "is_public":
+			out.IsPublic = bool(in.Bool())
+		case "cards":
+			if in.IsNull() {
+				in.Skip()
+				out.Cards = nil
+			} else {
+				in.Delim('[')
+				if out.Cards == nil {
+					if !in.IsDelim(']') {
+						out.Cards = make([]Card, 0, 0)
+					} else {
+						out.Cards = []Card{}
+					}
+				} else {
+					out.Cards = (out.Cards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v96 Card
+					(v96).UnmarshalEasyJSON(in)
+					out.Cards = append(out.Cards, v96)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "max_id":
+			out.MaxID = int(in.Int())
+		case "author":
+			out.Author = string(in.String())
+		case "likes":
+			out.Likes = int(in.Int())
+		case "trainings":
+			out.Trainings = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -2536,72 +7053,93 @@ func easyjson3e1fa5ecDecodeMainDomain23(in *jlexer.Lexer, out *ErrorItem) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain23(out *jwriter.Writer, in ErrorItem) {
+func easyjson3e1fa5ecEncodeMainDomain69(out *jwriter.Writer, in Collection) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"card_id\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.CardID))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"question\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		out.String(string(in.Question))
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"answer\":"
+		const prefix string = ",\"name_lower\":"
 		out.RawString(prefix)
-		out.String(string(in.Answer))
+		out.String(string(in.NameLower))
 	}
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"is_public\":"
 		out.RawString(prefix)
-		out.String(string(in.Type))
+		out.Bool(bool(in.IsPublic))
 	}
 	{
-		const prefix string = ",\"user_answer\":"
+		const prefix string = ",\"cards\":"
 		out.RawString(prefix)
-		out.String(string(in.UserAnswer))
+		if in.Cards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v97, v98 := range in.Cards {
+				if v97 > 0 {
+					out.RawByte(',')
+				}
+				(v98).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"blank_answer\":"
+		const prefix string = ",\"max_id\":"
 		out.RawString(prefix)
-		out.String(string(in.BlankAnswer))
+		out.Int(int(in.MaxID))
 	}
 	{
-		const prefix string = ",\"attachment\":"
+		const prefix string = ",\"author\":"
 		out.RawString(prefix)
-		out.String(string(in.Attachment))
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"likes\":"
+		out.RawString(prefix)
+		out.Int(int(in.Likes))
+	}
+	{
+		const prefix string = ",\"trainings\":"
+		out.RawString(prefix)
+		out.Int(int(in.Trainings))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ErrorItem) MarshalJSON() ([]byte, error) {
+func (v Collection) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain23(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain69(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ErrorItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain23(w, v)
+func (v Collection) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain69(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ErrorItem) UnmarshalJSON(data []byte) error {
+func (v *Collection) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain23(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain69(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ErrorItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain23(l, v)
+func (v *Collection) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain69(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain24(in *jlexer.Lexer, out *CollectionPreviewArray) {
+func easyjson3e1fa5ecDecodeMainDomain70(in *jlexer.Lexer, out *ChangePasswordRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2620,31 +7158,10 @@ func easyjson3e1fa5ecDecodeMainDomain24(in *jlexer.Lexer, out *CollectionPreview
 			continue
 		}
 		switch key {
-		case "count":
-			out.Count = int(in.Int())
-		case "items":
-			if in.IsNull() {
-				in.Skip()
-				out.Items = nil
-			} else {
-				in.Delim('[')
-				if out.Items == nil {
-					if !in.IsDelim(']') {
-						out.Items = make([]CollectionPreview, 0, 1)
-					} else {
-						out.Items = []CollectionPreview{}
-					}
-				} else {
-					out.Items = (out.Items)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v46 CollectionPreview
-					(v46).UnmarshalEasyJSON(in)
-					out.Items = append(out.Items, v46)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "old_password":
+			out.OldPassword = string(in.String())
+		case "new_password":
+			out.NewPassword = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2655,58 +7172,47 @@ func easyjson3e1fa5ecDecodeMainDomain24(in *jlexer.Lexer, out *CollectionPreview
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain24(out *jwriter.Writer, in CollectionPreviewArray) {
+func easyjson3e1fa5ecEncodeMainDomain70(out *jwriter.Writer, in ChangePasswordRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"count\":"
+		const prefix string = ",\"old_password\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Count))
+		out.String(string(in.OldPassword))
 	}
 	{
-		const prefix string = ",\"items\":"
-		out.RawString(prefix)
-		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v47, v48 := range in.Items {
-				if v47 > 0 {
-					out.RawByte(',')
-				}
-				(v48).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
-		}
+		const prefix string = ",\"new_password\":"
+		out.RawString(prefix)
+		out.String(string(in.NewPassword))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CollectionPreviewArray) MarshalJSON() ([]byte, error) {
+func (v ChangePasswordRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain24(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain70(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CollectionPreviewArray) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain24(w, v)
+func (v ChangePasswordRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain70(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CollectionPreviewArray) UnmarshalJSON(data []byte) error {
+func (v *ChangePasswordRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain24(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain70(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CollectionPreviewArray) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain24(l, v)
+func (v *ChangePasswordRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain70(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain25(in *jlexer.Lexer, out *CollectionPreview) {
+func easyjson3e1fa5ecDecodeMainDomain71(in *jlexer.Lexer, out *CardAttachment) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2727,16 +7233,14 @@ func easyjson3e1fa5ecDecodeMainDomain25(in *jlexer.Lexer, out *CollectionPreview
 		switch key {
 		case "id":
 			out.ID = string(in.String())
-		case "name":
-			out.Name = string(in.String())
-		case "is_public":
-			out.IsPublic = bool(in.Bool())
-		case "cards_count":
-			out.CardsCount = int(in.Int())
-		case "likes":
-			out.Likes = int(in.Int())
-		case "trainings":
-			out.Trainings = int(in.Int())
+		case "side":
+			out.Side = CardSide(in.String())
+		case "media_type":
+			out.MediaType = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "order":
+			out.Order = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -2747,7 +7251,7 @@ func easyjson3e1fa5ecDecodeMainDomain25(in *jlexer.Lexer, out *CollectionPreview
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain25(out *jwriter.Writer, in CollectionPreview) {
+func easyjson3e1fa5ecEncodeMainDomain71(out *jwriter.Writer, in CardAttachment) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2757,57 +7261,52 @@ func easyjson3e1fa5ecEncodeMainDomain25(out *jwriter.Writer, in CollectionPrevie
 		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"name\":"
-		out.RawString(prefix)
-		out.String(string(in.Name))
-	}
-	{
-		const prefix string = ",\"is_public\":"
+		const prefix string = ",\"side\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.IsPublic))
+		out.String(string(in.Side))
 	}
 	{
-		const prefix string = ",\"cards_count\":"
+		const prefix string = ",\"media_type\":"
 		out.RawString(prefix)
-		out.Int(int(in.CardsCount))
+		out.String(string(in.MediaType))
 	}
 	{
-		const prefix string = ",\"likes\":"
+		const prefix string = ",\"size\":"
 		out.RawString(prefix)
-		out.Int(int(in.Likes))
+		out.Int64(int64(in.Size))
 	}
 	{
-		const prefix string = ",\"trainings\":"
+		const prefix string = ",\"order\":"
 		out.RawString(prefix)
-		out.Int(int(in.Trainings))
+		out.Int(int(in.Order))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CollectionPreview) MarshalJSON() ([]byte, error) {
+func (v CardAttachment) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain25(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain71(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CollectionPreview) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain25(w, v)
+func (v CardAttachment) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain71(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CollectionPreview) UnmarshalJSON(data []byte) error {
+func (v *CardAttachment) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain25(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain71(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CollectionPreview) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain25(l, v)
+func (v *CardAttachment) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain71(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain26(in *jlexer.Lexer, out *CollectionInfo) {
+func easyjson3e1fa5ecDecodeMainDomain72(in *jlexer.Lexer, out *Card) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2826,41 +7325,41 @@ func easyjson3e1fa5ecDecodeMainDomain26(in *jlexer.Lexer, out *CollectionInfo) {
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
-		case "name":
-			out.Name = string(in.String())
-		case "is_public":
-			out.IsPublic = bool(in.Bool())
-		case "cards":
+		case "local_id":
+			out.LocalID = int(in.Int())
+		case "question":
+			out.Question = string(in.String())
+		case "answer":
+			out.Answer = string(in.String())
+		case "attachments":
 			if in.IsNull() {
 				in.Skip()
-				out.Cards = nil
+				out.Attachments = nil
 			} else {
 				in.Delim('[')
-				if out.Cards == nil {
+				if out.Attachments == nil {
 					if !in.IsDelim(']') {
-						out.Cards = make([]Card, 0, 0)
+						out.Attachments = make([]CardAttachment, 0, 0)
 					} else {
-						out.Cards = []Card{}
+						out.Attachments = []CardAttachment{}
 					}
 				} else {
-					out.Cards = (out.Cards)[:0]
+					out.Attachments = (out.Attachments)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v49 Card
-					(v49).UnmarshalEasyJSON(in)
-					out.Cards = append(out.Cards, v49)
+					var v99 CardAttachment
+					(v99).UnmarshalEasyJSON(in)
+					out.Attachments = append(out.Attachments, v99)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "author":
-			out.Author = string(in.String())
-		case "likes":
-			out.Likes = int(in.Int())
-		case "trainings":
-			out.Trainings = int(in.Int())
+		case "other_answers":
+			(out.OtherAnswers).UnmarshalEasyJSON(in)
+		case "question_audio":
+			out.QuestionAudio = string(in.String())
+		case "answer_audio":
+			out.AnswerAudio = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2871,83 +7370,83 @@ func easyjson3e1fa5ecDecodeMainDomain26(in *jlexer.Lexer, out *CollectionInfo) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain26(out *jwriter.Writer, in CollectionInfo) {
+func easyjson3e1fa5ecEncodeMainDomain72(out *jwriter.Writer, in Card) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"local_id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
+		out.Int(int(in.LocalID))
 	}
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"question\":"
 		out.RawString(prefix)
-		out.String(string(in.Name))
+		out.String(string(in.Question))
 	}
 	{
-		const prefix string = ",\"is_public\":"
+		const prefix string = ",\"answer\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.IsPublic))
+		out.String(string(in.Answer))
 	}
 	{
-		const prefix string = ",\"cards\":"
+		const prefix string = ",\"attachments\":"
 		out.RawString(prefix)
-		if in.Cards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Attachments == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v50, v51 := range in.Cards {
-				if v50 > 0 {
+			for v100, v101 := range in.Attachments {
+				if v100 > 0 {
 					out.RawByte(',')
 				}
-				(v51).MarshalEasyJSON(out)
+				(v101).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
 	{
-		const prefix string = ",\"author\":"
+		const prefix string = ",\"other_answers\":"
 		out.RawString(prefix)
-		out.String(string(in.Author))
+		(in.OtherAnswers).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"likes\":"
+		const prefix string = ",\"question_audio\":"
 		out.RawString(prefix)
-		out.Int(int(in.Likes))
+		out.String(string(in.QuestionAudio))
 	}
 	{
-		const prefix string = ",\"trainings\":"
+		const prefix string = ",\"answer_audio\":"
 		out.RawString(prefix)
-		out.Int(int(in.Trainings))
+		out.String(string(in.AnswerAudio))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CollectionInfo) MarshalJSON() ([]byte, error) {
+func (v Card) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain26(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain72(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CollectionInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain26(w, v)
+func (v Card) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain72(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CollectionInfo) UnmarshalJSON(data []byte) error {
+func (v *Card) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain26(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain72(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CollectionInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain26(l, v)
+func (v *Card) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain72(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain27(in *jlexer.Lexer, out *CollectionHistory) {
+func easyjson3e1fa5ecDecodeMainDomain73(in *jlexer.Lexer, out *ApiKeyArray) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2966,8 +7465,8 @@ func easyjson3e1fa5ecDecodeMainDomain27(in *jlexer.Lexer, out *CollectionHistory
 			continue
 		}
 		switch key {
-		case "collection_id":
-			out.CollectionID = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
 		case "items":
 			if in.IsNull() {
 				in.Skip()
@@ -2976,17 +7475,17 @@ func easyjson3e1fa5ecDecodeMainDomain27(in *jlexer.Lexer, out *CollectionHistory
 				in.Delim('[')
 				if out.Items == nil {
 					if !in.IsDelim(']') {
-						out.Items = make([]SmallHistoryItem, 0, 0)
+						out.Items = make([]ApiKey, 0, 0)
 					} else {
-						out.Items = []SmallHistoryItem{}
+						out.Items = []ApiKey{}
 					}
 				} else {
 					out.Items = (out.Items)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v52 SmallHistoryItem
-					(v52).UnmarshalEasyJSON(in)
-					out.Items = append(out.Items, v52)
+					var v102 ApiKey
+					(v102).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v102)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3001,14 +7500,14 @@ func easyjson3e1fa5ecDecodeMainDomain27(in *jlexer.Lexer, out *CollectionHistory
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain27(out *jwriter.Writer, in CollectionHistory) {
+func easyjson3e1fa5ecEncodeMainDomain73(out *jwriter.Writer, in ApiKeyArray) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"collection_id\":"
+		const prefix string = ",\"count\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.CollectionID))
+		out.Int(int(in.Count))
 	}
 	{
 		const prefix string = ",\"items\":"
@@ -3017,11 +7516,11 @@ func easyjson3e1fa5ecEncodeMainDomain27(out *jwriter.Writer, in CollectionHistor
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v53, v54 := range in.Items {
-				if v53 > 0 {
+			for v103, v104 := range in.Items {
+				if v103 > 0 {
 					out.RawByte(',')
 				}
-				(v54).MarshalEasyJSON(out)
+				(v104).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3030,29 +7529,29 @@ func easyjson3e1fa5ecEncodeMainDomain27(out *jwriter.Writer, in CollectionHistor
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CollectionHistory) MarshalJSON() ([]byte, error) {
+func (v ApiKeyArray) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain27(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain73(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CollectionHistory) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain27(w, v)
+func (v ApiKeyArray) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain73(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CollectionHistory) UnmarshalJSON(data []byte) error {
+func (v *ApiKeyArray) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain27(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain73(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CollectionHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain27(l, v)
+func (v *ApiKeyArray) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain73(l, v)
 }
-func easyjson3e1fa5ecDecodeMainDomain28(in *jlexer.Lexer, out *Collection) {
+func easyjson3e1fa5ecDecodeMainDomain74(in *jlexer.Lexer, out *ApiKey) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3075,41 +7574,37 @@ func easyjson3e1fa5ecDecodeMainDomain28(in *jlexer.Lexer, out *Collection) {
 			out.ID = string(in.String())
 		case "name":
 			out.Name = string(in.String())
-		case "name_lower":
-			out.NameLower = string(in.String())
-		case "is_public":
-			out.IsPublic = bool(in.Bool())
-		case "cards":
+		case "prefix":
+			out.Prefix = string(in.String())
+		case "scopes":
 			if in.IsNull() {
 				in.Skip()
-				out.Cards = nil
+				out.Scopes = nil
 			} else {
 				in.Delim('[')
-				if out.Cards == nil {
+				if out.Scopes == nil {
 					if !in.IsDelim(']') {
-						out.Cards = make([]Card, 0, 0)
+						out.Scopes = make([]string, 0, 4)
 					} else {
-						out.Cards = []Card{}
+						out.Scopes = []string{}
 					}
 				} else {
-					out.Cards = (out.Cards)[:0]
+					out.Scopes = (out.Scopes)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v55 Card
-					(v55).UnmarshalEasyJSON(in)
-					out.Cards = append(out.Cards, v55)
+					var v105 string
+					v105 = string(in.String())
+					out.Scopes = append(out.Scopes, v105)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "max_id":
-			out.MaxID = int(in.Int())
-		case "author":
-			out.Author = string(in.String())
-		case "likes":
-			out.Likes = int(in.Int())
-		case "trainings":
-			out.Trainings = int(in.Int())
+		case "created_at":
+			out.CreatedAt = int64(in.Int64())
+		case "expires_at":
+			out.ExpiresAt = int64(in.Int64())
+		case "last_used_at":
+			out.LastUsedAt = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -3120,7 +7615,7 @@ func easyjson3e1fa5ecDecodeMainDomain28(in *jlexer.Lexer, out *Collection) {
 		in.Consumed()
 	}
 }
-func easyjson3e1fa5ecEncodeMainDomain28(out *jwriter.Writer, in Collection) {
+func easyjson3e1fa5ecEncodeMainDomain74(out *jwriter.Writer, in ApiKey) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3135,168 +7630,64 @@ func easyjson3e1fa5ecEncodeMainDomain28(out *jwriter.Writer, in Collection) {
 		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"name_lower\":"
-		out.RawString(prefix)
-		out.String(string(in.NameLower))
-	}
-	{
-		const prefix string = ",\"is_public\":"
+		const prefix string = ",\"prefix\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.IsPublic))
+		out.String(string(in.Prefix))
 	}
 	{
-		const prefix string = ",\"cards\":"
+		const prefix string = ",\"scopes\":"
 		out.RawString(prefix)
-		if in.Cards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Scopes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v56, v57 := range in.Cards {
-				if v56 > 0 {
+			for v106, v107 := range in.Scopes {
+				if v106 > 0 {
 					out.RawByte(',')
 				}
-				(v57).MarshalEasyJSON(out)
+				out.String(string(v107))
 			}
 			out.RawByte(']')
 		}
 	}
 	{
-		const prefix string = ",\"max_id\":"
-		out.RawString(prefix)
-		out.Int(int(in.MaxID))
-	}
-	{
-		const prefix string = ",\"author\":"
-		out.RawString(prefix)
-		out.String(string(in.Author))
-	}
-	{
-		const prefix string = ",\"likes\":"
-		out.RawString(prefix)
-		out.Int(int(in.Likes))
-	}
-	{
-		const prefix string = ",\"trainings\":"
-		out.RawString(prefix)
-		out.Int(int(in.Trainings))
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v Collection) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain28(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Collection) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain28(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *Collection) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain28(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Collection) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain28(l, v)
-}
-func easyjson3e1fa5ecDecodeMainDomain29(in *jlexer.Lexer, out *Card) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "local_id":
-			out.LocalID = int(in.Int())
-		case "question":
-			out.Question = string(in.String())
-		case "answer":
-			out.Answer = string(in.String())
-		case "attachment":
-			out.Attachment = string(in.String())
-		case "other_answers":
-			(out.OtherAnswers).UnmarshalEasyJSON(in)
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson3e1fa5ecEncodeMainDomain29(out *jwriter.Writer, in Card) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"local_id\":"
-		out.RawString(prefix[1:])
-		out.Int(int(in.LocalID))
-	}
-	{
-		const prefix string = ",\"question\":"
-		out.RawString(prefix)
-		out.String(string(in.Question))
-	}
-	{
-		const prefix string = ",\"answer\":"
+		const prefix string = ",\"created_at\":"
 		out.RawString(prefix)
-		out.String(string(in.Answer))
+		out.Int64(int64(in.CreatedAt))
 	}
 	{
-		const prefix string = ",\"attachment\":"
+		const prefix string = ",\"expires_at\":"
 		out.RawString(prefix)
-		out.String(string(in.Attachment))
+		out.Int64(int64(in.ExpiresAt))
 	}
 	{
-		const prefix string = ",\"other_answers\":"
+		const prefix string = ",\"last_used_at\":"
 		out.RawString(prefix)
-		(in.OtherAnswers).MarshalEasyJSON(out)
+		out.Int64(int64(in.LastUsedAt))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Card) MarshalJSON() ([]byte, error) {
+func (v ApiKey) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3e1fa5ecEncodeMainDomain29(&w, v)
+	easyjson3e1fa5ecEncodeMainDomain74(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Card) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3e1fa5ecEncodeMainDomain29(w, v)
+func (v ApiKey) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e1fa5ecEncodeMainDomain74(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Card) UnmarshalJSON(data []byte) error {
+func (v *ApiKey) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3e1fa5ecDecodeMainDomain29(&r, v)
+	easyjson3e1fa5ecDecodeMainDomain74(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Card) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3e1fa5ecDecodeMainDomain29(l, v)
+func (v *ApiKey) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e1fa5ecDecodeMainDomain74(l, v)
 }
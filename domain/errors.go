@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"time"
+)
+
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindQuotaExceeded
+	KindLocked
+	KindTooManyRequests
+)
+
+// Error is an error that is reported to the client. Code is stable and is used
+// by the clients and for the translation of Message, which is in English.
+type Error struct {
+	Kind       ErrorKind
+	Code       string
+	Message    string
+	Details    []FieldError
+	RetryAfter time.Duration
+}
+
+// FieldError points to a field of a request that failed the validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ErrorResponse struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+func NewError(kind ErrorKind, code string, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is makes errors.Is match the copies made by WithDetails and WithRetryAfter.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	err := *e
+	err.Details = append(err.Details[:len(err.Details):len(err.Details)], details...)
+	return &err
+}
+
+func (e *Error) WithRetryAfter(retryAfter time.Duration) *Error {
+	err := *e
+	err.RetryAfter = retryAfter
+	return &err
+}
+
+const (
+	FieldRequired = "required"
+	FieldInvalid  = "invalid"
+)
+
+// InvalidFields is a validation error for the given fields of a request.
+func InvalidFields(fields ...string) *Error {
+	details := make([]FieldError, 0, len(fields))
+	for _, field := range fields {
+		details = append(details, FieldError{Field: field, Code: FieldInvalid, Message: "invalid value"})
+	}
+	return ErrInvalidData.WithDetails(details...)
+}
+
+// RequiredFields is a validation error for the missing fields of a request.
+func RequiredFields(fields ...string) *Error {
+	details := make([]FieldError, 0, len(fields))
+	for _, field := range fields {
+		details = append(details, FieldError{Field: field, Code: FieldRequired, Message: "is required"})
+	}
+	return ErrInvalidData.WithDetails(details...)
+}
+
+var (
+	ErrInternal        = NewError(KindInternal, "internal", "Internal server error")
+	ErrInvalidBody     = NewError(KindValidation, "invalid_body", "Invalid request body")
+	ErrInvalidData     = NewError(KindValidation, "invalid_data", "Invalid data")
+	ErrNotAuthorized   = NewError(KindUnauthorized, "not_authorized", "Not authorized")
+	ErrInvalidToken    = NewError(KindUnauthorized, "invalid_token", "Invalid token")
+	ErrAccessDenied    = NewError(KindForbidden, "access_denied", "Access denied")
+	ErrTooManyRequests = NewError(KindTooManyRequests, "too_many_requests", "Too many requests")
+
+	ErrUserNotFound         = NewError(KindNotFound, "user_not_found", "User not found")
+	ErrUserExists           = NewError(KindConflict, "user_exists", "User with this email already exists")
+	ErrUserPictureNotFound  = NewError(KindNotFound, "user_picture_not_found", "User picture not found")
+	ErrInvalidCredentials   = NewError(KindValidation, "invalid_credentials", "Invalid credentials")
+	ErrEmailInUse           = NewError(KindConflict, "email_in_use", "Email already in use")
+	ErrEmailAlreadyVerified = NewError(KindConflict, "email_already_verified", "Email already verified")
+	ErrVerificationSent     = NewError(
+		KindTooManyRequests, "verification_sent", "Verification email was sent recently",
+	)
+	ErrInvalidOrExpiredToken = NewError(KindValidation, "invalid_or_expired_token", "Invalid or expired token")
+	ErrAccountLocked         = NewError(KindLocked, "account_locked", "Account is temporarily locked")
+	ErrTooManyLoginAttempts  = NewError(KindTooManyRequests, "too_many_login_attempts", "Too many login attempts")
+	ErrInvalidRefreshToken   = NewError(KindUnauthorized, "invalid_refresh_token", "Invalid refresh token")
+	ErrSessionNotFound       = NewError(KindNotFound, "session_not_found", "Session not found")
+	ErrSessionRevoked        = NewError(KindUnauthorized, "session_revoked", "Session revoked")
+	ErrRefreshTokenReused    = NewError(
+		KindUnauthorized, "refresh_token_reused", "Refresh token reuse detected, session revoked",
+	)
+	ErrTwoFactorEnabled     = NewError(KindConflict, "two_factor_enabled", "Two-factor authentication already enabled")
+	ErrTwoFactorNotEnrolled = NewError(
+		KindValidation, "two_factor_not_enrolled", "Two-factor authentication is not enrolled",
+	)
+	ErrTwoFactorNotEnabled = NewError(
+		KindValidation, "two_factor_not_enabled", "Two-factor authentication is not enabled",
+	)
+	ErrInvalidCode              = NewError(KindValidation, "invalid_code", "Invalid code")
+	ErrInvalidMFAToken          = NewError(KindUnauthorized, "invalid_mfa_token", "Invalid or expired MFA token")
+	ErrUnknownProvider          = NewError(KindNotFound, "unknown_provider", "Unknown provider")
+	ErrInvalidOAuthState        = NewError(KindValidation, "invalid_oauth_state", "Invalid or expired state")
+	ErrOAuthExchange            = NewError(KindUnauthorized, "oauth_exchange", "Sign in with the provider failed")
+	ErrProviderEmailNotVerified = NewError(
+		KindForbidden, "provider_email_not_verified", "Email is not verified by the provider",
+	)
+	ErrIdentityLinked = NewError(KindConflict, "identity_linked", "Identity is linked to another account")
+	ErrLinkProvider   = NewError(
+		KindConflict, "link_provider", "Account with this email exists, link the provider from its settings",
+	)
+	ErrLastSignInMethod = NewError(KindConflict, "last_sign_in_method", "Can't unlink the only sign-in method")
+	ErrIdentityNotFound = NewError(KindNotFound, "identity_not_found", "Identity not found")
+	ErrApiKeyNotFound   = NewError(KindNotFound, "api_key_not_found", "API key not found")
+	ErrInvalidApiKey    = NewError(KindUnauthorized, "invalid_api_key", "Invalid API key")
+	ErrApiKeyExpired    = NewError(KindUnauthorized, "api_key_expired", "API key expired")
+	ErrApiKeyScope      = NewError(KindForbidden, "api_key_scope", "API key has no access to this endpoint")
+
+	ErrCollectionNotFound  = NewError(KindNotFound, "collection_not_found", "There is no collection with this ID")
+	ErrNotCollectionOwner  = NewError(KindForbidden, "not_collection_owner", "You are not the owner of this collection")
+	ErrCollectionNotPublic = NewError(KindForbidden, "collection_not_public", "Collection is not public")
+	ErrAlreadyInFavourites = NewError(KindConflict, "already_in_favourites", "Collection already in favourites")
+	ErrNotInFavourites     = NewError(KindConflict, "not_in_favourites", "Collection not in favourites")
+	ErrNothingFound        = NewError(KindNotFound, "nothing_found", "Couldn't find anything")
+	ErrCardNotFound        = NewError(KindNotFound, "card_not_found", "Card not found")
+	ErrCardPictureNotFound = NewError(KindNotFound, "card_picture_not_found", "Card picture not found")
+	ErrFileSizeLimit       = NewError(KindQuotaExceeded, "file_size_limit", "You reached the total file size limit")
+	ErrInvalidFile         = NewError(KindValidation, "invalid_file", "Error with file or its max size")
+	ErrImageTooLarge       = NewError(KindValidation, "image_too_large", "Image should be less than 5 MB and 40 MP")
+	ErrInvalidImageType    = NewError(KindValidation, "invalid_image_type", "Image should be JPEG, PNG, WebP or GIF")
+
+	ErrUnsupportedImageType = NewError(
+		KindValidation, "unsupported_image_type", "HEIC images are not supported, convert them to JPEG",
+	)
+
+	ErrCardAudioNotFound = NewError(KindNotFound, "card_audio_not_found", "Card audio not found")
+	ErrCardAudioChanged  = NewError(KindConflict, "card_audio_changed", "Card audio was changed meanwhile")
+	ErrAudioTooLarge     = NewError(KindValidation, "audio_too_large", "Audio should be less than 10 MB and 5 minutes")
+	ErrInvalidAudioType  = NewError(KindValidation, "invalid_audio_type", "Audio should be MP3, OGG, WAV or M4A")
+
+	ErrTooManyAttachments = NewError(
+		KindValidation, "too_many_attachments", "A card can have up to 10 attachments, remove one first",
+	)
+
+	ErrUploadNotFound   = NewError(KindNotFound, "upload_not_found", "Upload not found or expired")
+	ErrUploadIncomplete = NewError(KindConflict, "upload_incomplete", "The file is not uploaded yet")
+)
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"main/database"
+	"net/url"
+)
+
+const (
+	IdentityCollection   = "identities"
+	OAuthStateCollection = "oauth_states"
+)
+
+// Identity links an account of an external provider to a user.
+// ID is "<provider>:<subject>", so one external account can't be linked twice.
+type Identity struct {
+	ID        string `bson:"_id"        json:"-"`
+	UserID    string `bson:"user_id"    json:"-"`
+	Provider  string `bson:"provider"   json:"provider"`
+	Subject   string `bson:"subject"    json:"subject"`
+	Email     string `bson:"email"      json:"email"`
+	CreatedAt int64  `bson:"created_at" json:"created_at"`
+}
+
+type IdentityArray struct {
+	Count int        `json:"count"`
+	Items []Identity `json:"items"`
+}
+
+// OAuthState remembers a started authorization until the provider redirects back.
+// ID is the hash of the state parameter; LinkUserID is set when a signed in
+// user links a provider to their account.
+type OAuthState struct {
+	ID           string `bson:"_id"           json:"-"`
+	Provider     string `bson:"provider"      json:"provider"`
+	CodeVerifier string `bson:"code_verifier" json:"-"`
+	LinkUserID   string `bson:"link_user_id"  json:"-"`
+	CreatedAt    int64  `bson:"created_at"    json:"created_at"`
+	ExpiresAt    int64  `bson:"expires_at"    json:"expires_at"`
+	Used         bool   `bson:"used"          json:"used"`
+}
+
+type OAuthStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+type OAuthCallbackRequest struct {
+	Code     string `json:"code"`
+	State    string `json:"state"`
+	DeviceID string `json:"device_id"`
+	Device   string `json:"device"`
+}
+
+type IdentityRepository interface {
+	Create(c context.Context, identity *Identity) (string, error)
+	GetByID(c context.Context, identityID string) (Identity, error)
+	GetByUserID(c context.Context, userID string) ([]Identity, error)
+	DeleteByID(c context.Context, identityID string) error
+}
+
+type OAuthStateRepository interface {
+	Create(c context.Context, state *OAuthState) (string, error)
+	Update(c context.Context, filter interface{}, update interface{}) (database.UpdateResult, error)
+	GetByID(c context.Context, stateID string) (OAuthState, error)
+}
+
+type OAuthUseCase interface {
+	Start(c context.Context, provider string, linkUserID string, expiry int) (OAuthStartResponse, error)
+	Complete(c context.Context, provider string, code string, state string, extra url.Values) (User, error)
+	GetIdentities(c context.Context, userID string) ([]Identity, error)
+	Unlink(c context.Context, userID string, provider string) error
+}
@@ -2,13 +2,27 @@ package domain
 
 import "github.com/golang-jwt/jwt/v4"
 
+// Token types, so that a refresh token can't be passed off as an access token.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 type JwtCustomClaims struct {
-	Username string `json:"username"`
-	ID       string `json:"id"`
+	Type      string   `json:"typ"`
+	Username  string   `json:"username"`
+	ID        string   `json:"id"`
+	SessionID string   `json:"sid"`
+	Roles     []string `json:"roles,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// JwtCustomRefreshClaims binds a refresh token to its session (token family);
+// TokenID changes on every rotation.
 type JwtCustomRefreshClaims struct {
-	ID string `json:"id"`
+	Type      string `json:"typ"`
+	ID        string `json:"id"`
+	SessionID string `json:"sid"`
+	TokenID   string `json:"tid"`
 	jwt.RegisteredClaims
 }
@@ -0,0 +1,28 @@
+package domain
+
+import "github.com/golang-jwt/jwt/v4"
+
+// KeySet signs access tokens with the current key and finds the key
+// for verification by the kid header, so that keys can be rotated
+// without invalidating tokens signed by the previous one.
+type KeySet interface {
+	Sign(claims jwt.Claims) (string, error)
+	VerificationKey(token *jwt.Token) (interface{}, error)
+	JWKS() JWKS
+}
+
+// JWK is a public key in the RFC 7517 format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
@@ -1,8 +1,25 @@
 package domain
 
+import "time"
+
 //nolint:revive // constant
 var MAX_TOTAL_FILE_SIZE = 3 * 1024 * 1024 * 1024
 
+// MaxPictureSize is the size of a picture, the original one, before it is cleaned.
+const MaxPictureSize = 5 << 20
+
+// the limits of an audio attachment of a card, it counts toward the total file size too
+const (
+	MaxAudioSize     = 10 << 20
+	MaxAudioDuration = 5 * time.Minute
+)
+
+// MaxJSONBodySize is the size of a JSON request body, it is read whole for the contract validation.
+const MaxJSONBodySize = 1 << 20
+
+// MaxCardAttachments is the number of the attachments of a card on both sides.
+const MaxCardAttachments = 10
+
 type UserLimits struct {
 	TotalFileSize int `bson:"total_file_size" json:"total_file_size"`
 }
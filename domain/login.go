@@ -8,6 +8,7 @@ import (
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Device   string `json:"device"`
 }
 
 type LoginResponse struct {
@@ -15,8 +16,25 @@ type LoginResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// MFAChallengeResponse is returned by login instead of LoginResponse
+// when the user has two-factor authentication enabled.
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+}
+
+type LoginMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+	Device   string `json:"device"`
+}
+
 type LoginUseCase interface {
 	GetUserByEmail(c context.Context, email string) (User, error)
-	CreateAccessToken(user *User, secret string, expiry int) (accessToken string, expTime time.Time, err error)
-	CreateRefreshToken(user *User, secret string, expiry int) (refreshToken string, expTime time.Time, err error)
+	CreateAccessToken(
+		user *User, sessionID string, keySet KeySet, expiry int,
+	) (accessToken string, expTime time.Time, err error)
+	CreateRefreshToken(
+		c context.Context, user *User, session *Session, secret string, expiry int,
+	) (refreshToken string, expTime time.Time, err error)
 }
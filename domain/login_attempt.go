@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	LoginAuditCollection = "login_audit"
+)
+
+// LoginAttempt counts the failed logins for one email or client IP.
+type LoginAttempt struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// LoginAttemptPolicy describes how failed logins slow down the next ones:
+// after the free attempts every failure doubles the delay up to BackoffMax,
+// and LockoutThreshold failures for an email lock it for LockoutDuration.
+// Counters are forgotten Window after the last failure.
+type LoginAttemptPolicy struct {
+	FreeAttempts     int
+	IPFreeAttempts   int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	Window           time.Duration
+}
+
+// LoginAudit is a record of a failed login attempt.
+type LoginAudit struct {
+	ID        string `bson:"_id"        json:"id"`
+	Email     string `bson:"email"      json:"email"`
+	IP        string `bson:"ip"         json:"ip"`
+	UserAgent string `bson:"user_agent" json:"user_agent"`
+	Reason    string `bson:"reason"     json:"reason"`
+	CreatedAt int64  `bson:"created_at" json:"created_at"`
+}
+
+type LoginAttemptRepository interface {
+	Get(c context.Context, key string) (LoginAttempt, error)
+	// Reserve counts the attempt only if the counter still has the given failures, like a conditional $inc,
+	// and returns the new counter; false means another attempt has been counted in between.
+	Reserve(c context.Context, key string, failures int, at time.Time, window time.Duration) (LoginAttempt, bool, error)
+	Release(c context.Context, key string) error
+	// Touch moves the last failure of a reserved attempt to the time it has failed at
+	Touch(c context.Context, key string, at time.Time) error
+	Lock(c context.Context, key string, until time.Time) error
+	Reset(c context.Context, key string) error
+}
+
+type LoginAuditRepository interface {
+	Create(c context.Context, audit *LoginAudit) (string, error)
+}
+
+type LoginAttemptUseCase interface {
+	Reserve(c context.Context, email string, ip string) error
+	Fail(c context.Context, audit *LoginAudit) error
+	Succeed(c context.Context, email string, ip string) error
+	// ReserveCode, FailCode and SucceedCode limit the guesses of the two-factor codes of a signed in user
+	ReserveCode(c context.Context, userID string) error
+	FailCode(c context.Context, userID string) error
+	SucceedCode(c context.Context, userID string) error
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "context"
+
+const MediaCollection = "media"
+
+// Media is a file of the cards stored once for all its references. Its ID is the SHA-256 of the content,
+// it is the object name of the file too, so identical uploads share the object. The object is removed
+// when the last reference is released, and the size counts toward UserLimits.TotalFileSize of each owner once.
+// Deleting marks the media whose object is being removed since DeletingAt, it is not acquired until purged.
+type Media struct {
+	ID          string       `bson:"_id"                   json:"id"`
+	Size        int64        `bson:"size"                  json:"size"`
+	ContentType string       `bson:"content_type"          json:"content_type"`
+	RefCount    int          `bson:"ref_count"             json:"ref_count"`
+	Owners      []MediaOwner `bson:"owners"                json:"owners"`
+	Deleting    bool         `bson:"deleting,omitempty"    json:"-"`
+	DeletingAt  int64        `bson:"deleting_at,omitempty" json:"-"`
+}
+
+// MediaOwner is a user the media is referenced by, RefCount is the number of the references of the user.
+type MediaOwner struct {
+	UserID   string `bson:"user_id"   json:"user_id"`
+	RefCount int    `bson:"ref_count" json:"ref_count"`
+}
+
+// Owned tells if the user references the media.
+func (m Media) Owned(userID string) bool {
+	for _, owner := range m.Owners {
+		if owner.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// MediaRelease is what a released reference has left of the media.
+type MediaRelease struct {
+	// Disowned is set if it was the last reference of the user, the size is not charged to them anymore
+	Disowned bool
+	// Unused is set if it was the last reference at all, the object is to be removed and the media purged
+	Unused bool
+}
+
+type MediaRepository interface {
+	GetByID(c context.Context, mediaID string) (Media, error)
+	// Acquire adds a reference of the user to the media, the media is created if there is none.
+	// It returns true if the user referenced the media before.
+	Acquire(c context.Context, media *Media, userID string) (bool, error)
+	// Release removes a reference of the user to the media, the media is marked deleting with the last reference.
+	Release(c context.Context, mediaID string, userID string) (MediaRelease, error)
+	// Purge deletes the media marked deleting once its object is removed.
+	Purge(c context.Context, mediaID string) error
+}
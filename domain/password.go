@@ -0,0 +1,24 @@
+package domain
+
+import "context"
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+type PasswordUseCase interface {
+	GetUserByID(c context.Context, userID string) (User, error)
+	UpdatePassword(c context.Context, userID string, hashedPassword string) error
+	RequestReset(c context.Context, email string, expiry int) error
+	Reset(c context.Context, token string, hashedPassword string) error
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+type AuthMethod string
+
+const (
+	AuthMethodJwt    AuthMethod = "jwt"
+	AuthMethodApiKey AuthMethod = "api_key"
+)
+
+// Principal is the authenticated caller of a request, put into the context by JwtAuthMiddleware.
+type Principal struct {
+	UserID     string
+	Username   string
+	Roles      []string
+	Scopes     []string
+	SessionID  string
+	AuthMethod AuthMethod
+}
+
+// HasScope reports whether the principal may use the given scope.
+// Only API keys are restricted, a signed-in user has every scope.
+func (p Principal) HasScope(scope string) bool {
+	return p.AuthMethod != AuthMethodApiKey || slices.Contains(p.Scopes, scope)
+}
+
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+type principalKey struct{}
+
+func ContextWithPrincipal(c context.Context, principal Principal) context.Context {
+	return context.WithValue(c, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller of the request;
+// ok is false when the request was not authenticated.
+func PrincipalFromContext(c context.Context) (principal Principal, ok bool) {
+	principal, ok = c.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// UserIDFromContext returns the ID of the authenticated user or an empty string.
+func UserIDFromContext(c context.Context) string {
+	principal, _ := PrincipalFromContext(c)
+	return principal.UserID
+}
+
+// SessionIDFromContext returns the session of the access token,
+// empty for API keys and for tokens issued before sessions were introduced.
+func SessionIDFromContext(c context.Context) string {
+	principal, _ := PrincipalFromContext(c)
+	return principal.SessionID
+}
@@ -16,7 +16,11 @@ type RefreshTokenResponse struct {
 
 type RefreshTokenUseCase interface {
 	GetUserByID(c context.Context, id string) (User, error)
-	CreateAccessToken(user *User, secret string, expiry int) (accessToken string, exp time.Time, err error)
-	CreateRefreshToken(user *User, secret string, expiry int) (refreshToken string, exp time.Time, err error)
-	ExtractIDFromToken(requestToken string, secret string) (string, error)
+	CreateAccessToken(
+		user *User, sessionID string, keySet KeySet, expiry int,
+	) (accessToken string, exp time.Time, err error)
+	ParseRefreshToken(requestToken string, secret string) (*JwtCustomRefreshClaims, error)
+	RotateRefreshToken(
+		c context.Context, user *User, claims *JwtCustomRefreshClaims, secret string, expiry int,
+	) (refreshToken string, exp time.Time, err error)
 }
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"main/database"
+)
+
+const (
+	SessionCollection = "sessions"
+)
+
+// Session is one refresh token family: every refresh rotates TokenID,
+// and presenting any older token of the family revokes the whole session.
+type Session struct {
+	ID         string `bson:"_id"          json:"id"`
+	UserID     string `bson:"user_id"      json:"user_id"`
+	TokenID    string `bson:"token_id"     json:"-"`
+	DeviceName string `bson:"device_name"  json:"device_name"`
+	UserAgent  string `bson:"user_agent"   json:"user_agent"`
+	IP         string `bson:"ip"           json:"ip"`
+	CreatedAt  int64  `bson:"created_at"   json:"created_at"`
+	LastUsedAt int64  `bson:"last_used_at" json:"last_used_at"`
+	ExpiresAt  int64  `bson:"expires_at"   json:"expires_at"`
+	Revoked    bool   `bson:"revoked"      json:"revoked"`
+}
+
+type SessionInfo struct {
+	ID         string `json:"id"`
+	DeviceName string `json:"device_name"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastUsedAt int64  `json:"last_used_at"`
+	Current    bool   `json:"current"`
+}
+
+type SessionInfoArray struct {
+	Count int           `json:"count"`
+	Items []SessionInfo `json:"items"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type SessionRepository interface {
+	Create(c context.Context, session *Session) (string, error)
+	Update(c context.Context, filter interface{}, update interface{}) (database.UpdateResult, error)
+	GetByID(c context.Context, sessionID string) (Session, error)
+	GetActiveByUserID(c context.Context, userID string, now int64) ([]Session, error)
+	RevokeByID(c context.Context, sessionID string) error
+	// RevokeByUserID revokes the sessions of the user but keep, all of them if keep is empty
+	RevokeByUserID(c context.Context, userID string, keep string) error
+}
+
+type SessionUseCase interface {
+	ParseRefreshToken(requestToken string, secret string) (*JwtCustomRefreshClaims, error)
+	GetActiveByUserID(c context.Context, userID string) ([]Session, error)
+	IsActive(c context.Context, sessionID string) (bool, error)
+	Revoke(c context.Context, userID string, sessionID string) error
+	RevokeAll(c context.Context, userID string) error
+	RevokeOthers(c context.Context, userID string, sessionID string) error
+}
@@ -20,6 +20,10 @@ type SignupResponse struct {
 type SignupUseCase interface {
 	Create(c context.Context, user *User) (string, error)
 	GetUserByEmail(c context.Context, email string) (User, error)
-	CreateAccessToken(user *User, secret string, expiry int) (accessToken string, exp time.Time, err error)
-	CreateRefreshToken(user *User, secret string, expiry int) (refreshToken string, exp time.Time, err error)
+	CreateAccessToken(
+		user *User, sessionID string, keySet KeySet, expiry int,
+	) (accessToken string, exp time.Time, err error)
+	CreateRefreshToken(
+		c context.Context, user *User, session *Session, secret string, expiry int,
+	) (refreshToken string, exp time.Time, err error)
 }
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+//easyjson:skip
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+	// ETag is the unquoted entity tag of the content, it changes with the content
+	ETag         string
+	LastModified time.Time
+}
+
+// Object is a stored object read as a stream, the reader has to be closed.
+// It is seekable, so that the ranges of it can be served without reading the rest.
+// It is read after the method that opened it returns, so the context given to
+// that method has to outlive the reading, and no usecase timeout applies to it.
+//
+//easyjson:skip
+type Object struct {
+	io.ReadSeekCloser
+	ObjectInfo
+}
+
+// PictureSize selects a variant of a stored picture. The smaller variants are made from the original
+// on the first request and kept next to it, they don't count toward UserLimits.TotalFileSize.
+type PictureSize string
+
+const (
+	PictureSizeThumb    PictureSize = "thumb"
+	PictureSizeMedium   PictureSize = "medium"
+	PictureSizeOriginal PictureSize = "original"
+)
+
+// PictureSides are the longest sides of the variants in pixels,
+// the pictures that are smaller already are served as they are.
+var PictureSides = map[PictureSize]int{
+	PictureSizeThumb:  256,
+	PictureSizeMedium: 1024,
+}
+
+// ParsePictureSize reads the size query parameter, the original is the default.
+func ParsePictureSize(s string) (PictureSize, error) {
+	size := PictureSize(s)
+	switch size {
+	case "":
+		return PictureSizeOriginal, nil
+	case PictureSizeThumb, PictureSizeMedium, PictureSizeOriginal:
+		return size, nil
+	}
+	return "", InvalidFields("size")
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+)
+
+// TwoFactor is the TOTP state of a user. Secret is set at enrolment
+// and only takes effect once Enabled is set by a confirmed code.
+type TwoFactor struct {
+	Enabled       bool     `bson:"enabled"        json:"enabled"`
+	Secret        string   `bson:"secret"         json:"-"`
+	LastStep      int64    `bson:"last_step"      json:"-"`
+	RecoveryCodes []string `bson:"recovery_codes" json:"-"`
+}
+
+type TOTPEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorUseCase interface {
+	Enroll(c context.Context, userID string, issuer string) (TOTPEnrollResponse, error)
+	Confirm(c context.Context, userID string, code string) ([]string, error)
+	Disable(c context.Context, userID string, code string) error
+	CreateChallenge(c context.Context, user *User, expiry int) (string, error)
+	VerifyChallenge(c context.Context, token string, code string) (User, error)
+}
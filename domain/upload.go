@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+const (
+	UploadCollection = "uploads"
+	UploadBucket     = "uploads"
+)
+
+const (
+	// PresignExpiry is how long the presigned URLs are valid.
+	PresignExpiry = 15 * time.Minute
+	// UploadExpiry is how long an upload can be completed after it is started,
+	// it is longer than PresignExpiry, so that a put started in time can finish.
+	UploadExpiry = time.Hour
+	// UploadSweepInterval is how often the expired uploads of all the users are removed.
+	UploadSweepInterval = 10 * time.Minute
+)
+
+// UploadTarget is what an uploaded picture becomes once the upload is completed.
+type UploadTarget string
+
+const (
+	UploadTargetCardAttachment UploadTarget = "card_attachment"
+	UploadTargetProfilePicture UploadTarget = "profile_picture"
+)
+
+// Upload is a picture the client puts to the storage by itself. The picture is kept in UploadBucket
+// under the ID of the upload until it is completed: then it is checked, cleaned and stored as the target,
+// only then it counts toward UserLimits.TotalFileSize. ClaimedUntil is set while the upload is being completed,
+// so that the picture is stored once.
+type Upload struct {
+	ID           string       `bson:"_id"                     json:"id"`
+	UserID       string       `bson:"user_id"                 json:"-"`
+	Target       UploadTarget `bson:"target"                  json:"target"`
+	CollectionID string       `bson:"collection_id,omitempty" json:"-"`
+	CardID       int          `bson:"card_id,omitempty"       json:"-"`
+	Side         CardSide     `bson:"side,omitempty"          json:"-"`
+	CreatedAt    int64        `bson:"created_at"              json:"-"`
+	ExpiresAt    int64        `bson:"expires_at"              json:"-"`
+	ClaimedUntil int64        `bson:"claimed_until"           json:"-"`
+}
+
+// PresignedURL is a short-lived URL of an object in the storage. If the storage can't presign,
+// Proxy is set and URL is the path of the API the object goes through instead,
+// it is requested with the credentials of the user and doesn't expire.
+type PresignedURL struct {
+	URL       string `json:"url"`
+	Method    string `json:"method"`
+	ExpiresAt int64  `json:"expires_at"`
+	Proxy     bool   `json:"proxy"`
+}
+
+// UploadTicket is a started upload, the picture is sent to its URL and then the upload is completed by ID.
+// The presigned URL takes a multipart form POST: Fields go first and the picture is the "file" field,
+// the storage refuses a picture larger than MaxPictureSize. The proxy URL takes the picture as the body of a PUT.
+type UploadTicket struct {
+	ID string `json:"id"`
+	PresignedURL
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+type UploadRepository interface {
+	Create(c context.Context, upload *Upload) (string, error)
+	GetByID(c context.Context, uploadID string) (Upload, error)
+	GetExpiredByUserID(c context.Context, userID string, now int64) ([]Upload, error)
+	// GetExpired returns the expired uploads of all the users that are not being completed
+	GetExpired(c context.Context, now int64) ([]Upload, error)
+	// Claim returns false if the upload is being completed already or there is no such upload
+	Claim(c context.Context, uploadID string, now int64, until int64) (bool, error)
+	Unclaim(c context.Context, uploadID string) error
+	// DeleteByID returns false if there was no such upload, e.g. it was completed concurrently
+	DeleteByID(c context.Context, uploadID string) (bool, error)
+}
+
+type UploadUseCase interface {
+	Start(c context.Context, upload *Upload) (UploadTicket, error)
+	Put(c context.Context, userID string, uploadID string, target UploadTarget, file io.Reader, size int64) error
+	Complete(
+		c context.Context,
+		userID string,
+		uploadID string,
+		target UploadTarget,
+		store func(c context.Context, upload Upload, data []byte) error,
+	) error
+	RemoveExpired(c context.Context) (int, error)
+}
+
+//nolint:iface // business logic
+type UploadStorage interface {
+	GetObject(c context.Context, objectName string) (Object, error)
+	StatObject(c context.Context, objectName string) (ObjectInfo, error)
+	PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error
+	RemoveObject(c context.Context, objectName string) error
+	PresignPost(
+		c context.Context,
+		objectName string,
+		expires time.Duration,
+		maxSize int64,
+	) (string, map[string]string, error)
+}
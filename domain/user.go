@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"main/database"
+	"time"
 )
 
 const (
@@ -12,25 +13,30 @@ const (
 )
 
 type User struct {
-	ID          string         `bson:"_id"         json:"id"`
-	Username    string         `bson:"username"    json:"username"`
-	Email       string         `bson:"email"       json:"email"`
-	Password    string         `bson:"password"    json:"password"`
-	HasPicture  bool           `bson:"has_picture" json:"has_picture"`
-	Collections []string       `bson:"collections" json:"collections"`
-	Favourite   []string       `bson:"favourite"   json:"favourite"`
-	Statistics  UserStatistics `bson:"statistics"  json:"statistics"`
-	Limits      UserLimits     `bson:"limits"      json:"limits"`
+	ID            string         `bson:"_id"            json:"id"`
+	Username      string         `bson:"username"       json:"username"`
+	Email         string         `bson:"email"          json:"email"`
+	EmailVerified bool           `bson:"email_verified" json:"email_verified"`
+	Password      string         `bson:"password"       json:"password"`
+	HasPicture    bool           `bson:"has_picture"    json:"has_picture"`
+	Collections   []string       `bson:"collections"    json:"collections"`
+	Favourite     []string       `bson:"favourite"      json:"favourite"`
+	Statistics    UserStatistics `bson:"statistics"     json:"statistics"`
+	Limits        UserLimits     `bson:"limits"         json:"limits"`
+	TwoFactor     TwoFactor      `bson:"two_factor"     json:"-"`
+	Roles         []string       `bson:"roles"          json:"-"`
 }
 
 type UserInfo struct {
-	ID          string         `bson:"_id"         json:"id"`
-	Username    string         `bson:"username"    json:"username"`
-	Email       string         `bson:"email"       json:"email"`
-	HasPicture  bool           `bson:"has_picture" json:"has_picture"`
-	Collections []string       `bson:"collections" json:"collections"`
-	Statistics  UserStatistics `bson:"statistics"  json:"statistics"`
-	Favourite   []string       `bson:"favourite"   json:"favourite"`
+	ID            string         `bson:"_id"            json:"id"`
+	Username      string         `bson:"username"       json:"username"`
+	Email         string         `bson:"email"          json:"email"`
+	EmailVerified bool           `bson:"email_verified" json:"email_verified"`
+	TwoFactor     bool           `bson:"-"              json:"two_factor"`
+	HasPicture    bool           `bson:"has_picture"    json:"has_picture"`
+	Collections   []string       `bson:"collections"    json:"collections"`
+	Statistics    UserStatistics `bson:"statistics"     json:"statistics"`
+	Favourite     []string       `bson:"favourite"      json:"favourite"`
 }
 
 type PublicUserInfo struct {
@@ -53,17 +59,27 @@ type UserRepository interface {
 }
 
 type UserUseCase interface {
-	PutByID(c context.Context, userID string, user *User) error
+	PutByID(c context.Context, userID string, user *User) (emailChanged bool, err error)
 	GetByID(c context.Context, userID string) (User, error)
 	DeleteByID(c context.Context, userID string) error
-	GetProfilePicture(c context.Context, userID string) ([]byte, error)
-	UploadProfilePicture(c context.Context, userID string, picture io.Reader, size int64) error
+	GetProfilePicture(c context.Context, userID string, size PictureSize) (Object, error)
+	GetProfilePictureURL(c context.Context, userID string, size PictureSize) (PresignedURL, error)
+	UploadProfilePicture(
+		c context.Context,
+		userID string,
+		picture io.Reader,
+		size int64,
+		contentType string,
+	) error
 	RemoveProfilePicture(c context.Context, userID string) error
 }
 
 //nolint:iface // business logic
 type UserStorage interface {
-	GetObject(c context.Context, objectName string) ([]byte, error)
-	PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64) error
+	GetObject(c context.Context, objectName string) (Object, error)
+	GetPicture(c context.Context, objectName string, size PictureSize) (Object, error)
+	StatObject(c context.Context, objectName string) (ObjectInfo, error)
+	PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error
 	RemoveObject(c context.Context, objectName string) error
+	PresignGet(c context.Context, objectName string, size PictureSize, expires time.Duration) (string, error)
 }
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"main/database"
+)
+
+const (
+	UserTokenCollection = "user_tokens"
+
+	PasswordResetPurpose     = "password_reset"
+	EmailVerificationPurpose = "email_verification"
+	MFAChallengePurpose      = "mfa_challenge"
+)
+
+// UserToken is a single-use secret sent to the user by mail.
+// Only the SHA-256 hash of the secret is stored as ID. Email is the address
+// the token was sent to, so that a token for an old address can't verify a new one.
+type UserToken struct {
+	ID        string `bson:"_id"        json:"-"`
+	UserID    string `bson:"user_id"    json:"user_id"`
+	Purpose   string `bson:"purpose"    json:"purpose"`
+	Email     string `bson:"email"      json:"email"`
+	CreatedAt int64  `bson:"created_at" json:"created_at"`
+	ExpiresAt int64  `bson:"expires_at" json:"expires_at"`
+	Used      bool   `bson:"used"       json:"used"`
+}
+
+type UserTokenRepository interface {
+	Create(c context.Context, token *UserToken) (string, error)
+	Update(c context.Context, filter interface{}, update interface{}) (database.UpdateResult, error)
+	GetByID(c context.Context, tokenID string) (UserToken, error)
+	GetLatestByUserID(c context.Context, userID string, purpose string) (UserToken, error)
+}
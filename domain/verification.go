@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type VerificationUseCase interface {
+	SendVerification(c context.Context, userID string, expiry int) error
+	ResendVerification(c context.Context, userID string, expiry int, cooldown int) error
+	Verify(c context.Context, token string) error
+}
@@ -0,0 +1,275 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"main/domain"
+	"time"
+)
+
+const (
+	ContentTypeMP3 = "audio/mpeg"
+	ContentTypeOGG = "audio/ogg"
+	ContentTypeWAV = "audio/wav"
+	ContentTypeM4A = "audio/mp4"
+)
+
+// AudioContentTypes are the content types CheckAudio detects.
+var AudioContentTypes = []string{ContentTypeMP3, ContentTypeOGG, ContentTypeWAV, ContentTypeM4A}
+
+// CheckAudio detects the format of the audio by its content and reads its duration from the headers,
+// the audio is stored as it is. The audio that is not MP3, OGG (Vorbis or Opus), WAV or M4A
+// or has no duration is rejected with ErrInvalidAudioType.
+func CheckAudio(data []byte) (string, time.Duration, error) {
+	var contentType string
+	var duration time.Duration
+	switch {
+	//nolint:mnd // RIFF, the size, WAVE
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		contentType, duration = ContentTypeWAV, wavDuration(data)
+	case bytes.HasPrefix(data, []byte("OggS")):
+		contentType, duration = ContentTypeOGG, oggDuration(data)
+	//nolint:mnd // the size of the box, ftyp
+	case len(data) >= 12 && string(data[4:8]) == "ftyp":
+		contentType, duration = ContentTypeM4A, m4aDuration(data)
+	default:
+		contentType, duration = ContentTypeMP3, mp3Duration(data)
+	}
+	if duration <= 0 {
+		return "", 0, domain.ErrInvalidAudioType
+	}
+	return contentType, duration, nil
+}
+
+func wavDuration(data []byte) time.Duration {
+	chunks, ok := riffChunks(data)
+	if !ok {
+		return 0
+	}
+	var byteRate, size int
+	for _, chunk := range chunks {
+		switch chunk.id {
+		case "fmt ":
+			//nolint:mnd // the format, the channels, the sample rate and the byte rate
+			if len(chunk.data) >= 12 {
+				byteRate = int(binary.LittleEndian.Uint32(chunk.data[8:]))
+			}
+		case "data":
+			size = len(chunk.data)
+		}
+	}
+	return samplesDuration(uint64(size), uint64(byteRate))
+}
+
+// oggDuration reads the sample rate from the first packet and the samples from the granule position
+// of the last page of the stream, https://www.xiph.org/ogg/doc/framing.html.
+func oggDuration(data []byte) time.Duration {
+	var serial uint32
+	var rate, preSkip, granule int64
+	//nolint:mnd // a page is OggS, the version, the type, the granule position, the serial number,
+	// the sequence number, the checksum, the number of segments and the segment table
+	for i := 0; i < len(data); {
+		if i+27 > len(data) || string(data[i:i+4]) != "OggS" {
+			return 0
+		}
+		segments := int(data[i+26])
+		if i+27+segments > len(data) {
+			return 0
+		}
+		size := 0
+		for _, segment := range data[i+27 : i+27+segments] {
+			size += int(segment)
+		}
+		body := i + 27 + segments
+		if body+size > len(data) {
+			return 0
+		}
+
+		pageSerial := binary.LittleEndian.Uint32(data[i+14:])
+		if i == 0 {
+			serial = pageSerial
+			packet := data[body : body+size]
+			switch {
+			case bytes.HasPrefix(packet, []byte("\x01vorbis")) && len(packet) >= 16:
+				rate = int64(binary.LittleEndian.Uint32(packet[12:]))
+			case bytes.HasPrefix(packet, []byte("OpusHead")) && len(packet) >= 12:
+				// the granule position of Opus is always at 48 kHz
+				rate = 48000
+				preSkip = int64(binary.LittleEndian.Uint16(packet[10:]))
+			default:
+				return 0
+			}
+		}
+		if pageSerial == serial {
+			if position := int64(binary.LittleEndian.Uint64(data[i+6:])); position > 0 { //nolint:gosec // -1 is none
+				granule = position
+			}
+		}
+		i = body + size
+	}
+	if granule <= preSkip {
+		return 0
+	}
+	return samplesDuration(uint64(granule-preSkip), uint64(rate))
+}
+
+// m4aDuration reads the duration from the movie header of the MP4 container,
+// the file has to be branded as M4A (or M4B for the audio books).
+func m4aDuration(data []byte) time.Duration {
+	boxes := mp4Boxes(data)
+	ftyp, ok := boxes["ftyp"]
+	//nolint:mnd // the major brand, the minor version and the compatible brands
+	if !ok || len(ftyp) < 8 {
+		return 0
+	}
+	audio := false
+	for i := 0; i+4 <= len(ftyp); i += 4 {
+		//nolint:mnd // the minor version is not a brand
+		if i == 4 {
+			continue
+		}
+		if brand := string(ftyp[i : i+4]); brand == "M4A " || brand == "M4B " {
+			audio = true
+		}
+	}
+	mvhd, ok := mp4Boxes(boxes["moov"])["mvhd"]
+	if !audio || !ok || len(mvhd) < 1 {
+		return 0
+	}
+
+	var timescale, duration uint64
+	//nolint:mnd // the version and the flags, the creation and the modification times, the timescale, the duration
+	switch {
+	case mvhd[0] == 0 && len(mvhd) >= 20:
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[12:]))
+		duration = uint64(binary.BigEndian.Uint32(mvhd[16:]))
+	case mvhd[0] == 1 && len(mvhd) >= 32:
+		timescale = uint64(binary.BigEndian.Uint32(mvhd[20:]))
+		duration = binary.BigEndian.Uint64(mvhd[24:])
+	}
+	return samplesDuration(duration, timescale)
+}
+
+// samplesDuration is the duration of the samples at the rate (per second), 0 if it is unknown or too long to tell.
+func samplesDuration(samples uint64, rate uint64) time.Duration {
+	//nolint:mnd // a day is longer than any attachment
+	if rate == 0 || samples/rate > 24*60*60 {
+		return 0
+	}
+	seconds, rest := samples/rate, samples%rate
+	return time.Duration(seconds)*time.Second + time.Duration(rest*uint64(time.Second)/rate) //nolint:gosec // checked
+}
+
+// mp4Boxes returns the data of the boxes on the top level of data by their type.
+func mp4Boxes(data []byte) map[string][]byte {
+	boxes := make(map[string][]byte)
+	//nolint:mnd // a box is its size and its type, the size 1 is followed by the 64-bit size, 0 is up to the end
+	for i := 0; i+8 <= len(data); {
+		size := int(binary.BigEndian.Uint32(data[i:]))
+		kind := string(data[i+4 : i+8])
+		header := 8
+		switch size {
+		case 0:
+			size = len(data) - i
+		case 1:
+			if i+16 > len(data) {
+				return boxes
+			}
+			large := binary.BigEndian.Uint64(data[i+8:])
+			if large > uint64(len(data)-i) {
+				return boxes
+			}
+			size, header = int(large), 16 //nolint:gosec // less than the data
+		}
+		if size < header || i+size > len(data) {
+			return boxes
+		}
+		boxes[kind] = data[i+header : i+size]
+		i += size
+	}
+	return boxes
+}
+
+//nolint:mnd // the tables of the MPEG audio frame header, http://www.mp3-tech.org/programmer/frame_header.html
+var (
+	mp3Bitrates = map[[2]int][16]int{
+		// MPEG-1 layers I, II and III
+		{3, 3}: {0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{3, 2}: {0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{3, 1}: {0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+		// MPEG-2 and MPEG-2.5
+		{2, 3}: {0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{2, 2}: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{2, 1}: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	}
+	mp3SampleRates = map[int][3]int{
+		3: {44100, 48000, 32000},
+		2: {22050, 24000, 16000},
+		0: {11025, 12000, 8000},
+	}
+)
+
+// mp3Duration walks the frames of MPEG audio after the ID3v2 tag and sums their samples.
+// At least two frames in a row are needed, so that a random file is not taken for one.
+func mp3Duration(data []byte) time.Duration {
+	i := 0
+	//nolint:mnd // ID3, the version, the flags and the syncsafe size, the footer is as long as the header
+	if bytes.HasPrefix(data, []byte("ID3")) && len(data) >= 10 {
+		i = 10 + (int(data[6])<<21 | int(data[7])<<14 | int(data[8])<<7 | int(data[9]))
+		if data[5]&0x10 != 0 {
+			i += 10
+		}
+	}
+	for i < len(data) && data[i] == 0 {
+		i++
+	}
+
+	frames := 0
+	var duration time.Duration
+	for i+4 <= len(data) {
+		length, samples, rate := mp3Frame(data[i : i+4])
+		if length == 0 || i+length > len(data) {
+			break
+		}
+		frames++
+		duration += time.Duration(samples) * time.Second / time.Duration(rate)
+		i += length
+	}
+	//nolint:mnd // see above
+	if frames < 2 {
+		return 0
+	}
+	return duration
+}
+
+// mp3Frame reads the frame header, the length 0 is not a frame.
+func mp3Frame(header []byte) (length int, samples int, rate int) {
+	//nolint:mnd // the bits of the header
+	if header[0] != 0xFF || header[1]&0xE0 != 0xE0 {
+		return 0, 0, 0
+	}
+	//nolint:mnd // the bits of the header
+	version, layer := int(header[1]>>3&0x03), int(header[1]>>1&0x03)
+	bitrateIndex, rateIndex, padding := int(header[2]>>4), int(header[2]>>2&0x03), int(header[2]>>1&0x01)
+	//nolint:mnd // 1 is the reserved version, 0 the reserved layer, 15 the bad bitrate, 3 the reserved rate
+	if version == 1 || layer == 0 || bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+		return 0, 0, 0
+	}
+
+	table := version
+	if table == 0 {
+		table = 2
+	}
+	bitrate := mp3Bitrates[[2]int{table, layer}][bitrateIndex] * 1000 //nolint:mnd // kbit/s
+	rate = mp3SampleRates[version][rateIndex]
+	//nolint:mnd // the samples of the layers, the slots of layer I are 4 bytes
+	switch {
+	case layer == 3:
+		return (12*bitrate/rate + padding) * 4, 384, rate
+	case layer == 1 && version != 3:
+		samples = 576
+	default:
+		samples = 1152
+	}
+	return samples/8*bitrate/rate + padding, samples, rate //nolint:mnd // bits
+}
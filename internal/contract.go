@@ -0,0 +1,207 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/domain"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// Contract validates requests and responses against the OpenAPI document
+// of the API (api/contract.yaml).
+type Contract struct {
+	doc     *openapi3.T
+	router  routers.Router
+	options *openapi3filter.Options
+	// bodyless skips the body, the files are checked by the handlers
+	// while they are read within their own limits
+	bodyless *openapi3filter.Options
+}
+
+func init() {
+	// the pictures and the audio are binary, their bodies are not decoded for the validation
+	for _, contentType := range []string{ContentTypeJPEG, ContentTypePNG, ContentTypeWebP, ContentTypeGIF} {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+	for _, contentType := range AudioContentTypes {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+}
+
+func LoadContract(path string) (*Contract, error) {
+	doc, err := openapi3.NewLoader().LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", path, err)
+	}
+	err = doc.Validate(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	// the servers of the document carry the host and the version prefix,
+	// the routes are matched by RoutePath only
+	doc.Servers = nil
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:          true,
+		SkipSettingDefaults: true,
+		// authentication is checked by JwtAuthMiddleware
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	bodyless := *options
+	bodyless.ExcludeRequestBody = true
+
+	return &Contract{
+		doc:      doc,
+		router:   router,
+		options:  options,
+		bodyless: &bodyless,
+	}, nil
+}
+
+// Operations returns the operations of the contract as "METHOD /path".
+func (c *Contract) Operations() []string {
+	var operations []string
+	for path, item := range c.doc.Paths.Map() {
+		for method := range item.Operations() {
+			operations = append(operations, method+" "+path)
+		}
+	}
+	sort.Strings(operations)
+	return operations
+}
+
+// FindRoute returns the operation of the request, ok is false if the contract
+// has no such path or method. The paths of the contract are relative to the
+// API version, so the request is matched by its RoutePath.
+func (c *Contract) FindRoute(r *http.Request) (*routers.Route, map[string]string, bool) {
+	u := *r.URL
+	u.Path = RoutePath(r)
+	req := *r
+	req.URL = &u
+
+	route, pathParams, err := c.router.FindRoute(&req)
+	if err != nil {
+		return nil, nil, false
+	}
+	return route, pathParams, true
+}
+
+// ValidateRequest checks the parameters and the body of the request; the body
+// stays readable for the handler. Only JSON bodies are checked, the multipart and
+// binary ones are left to the handler. The returned error is a domain validation
+// error with a detail per invalid field.
+func (c *Contract) ValidateRequest(r *http.Request, route *routers.Route, pathParams map[string]string) error {
+	options := c.options
+	if !IsJSON(r) {
+		options = c.bodyless
+	}
+	err := openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options,
+	})
+	if err == nil {
+		return nil
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return domain.ErrInvalidBody
+	}
+
+	var me openapi3.MultiError
+	if !errors.As(err, &me) {
+		me = openapi3.MultiError{err}
+	}
+	details := make([]domain.FieldError, 0, len(me))
+	for _, e := range me {
+		details = append(details, fieldErrors(e)...)
+	}
+	return domain.ErrInvalidData.WithDetails(details...)
+}
+
+// IsJSON tells if the request has a JSON body.
+func IsJSON(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "application/json"
+}
+
+// ValidateResponse checks a recorded response of the request,
+// a response status that is not in the contract is an error too.
+func (c *Contract) ValidateResponse(
+	r *http.Request,
+	route *routers.Route,
+	pathParams map[string]string,
+	status int,
+	header http.Header,
+	body []byte,
+) error {
+	options := *c.options
+	options.IncludeResponseStatus = true
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &options,
+		},
+		Status:  status,
+		Header:  header,
+		Options: &options,
+	}
+	input.SetBodyBytes(body)
+	return openapi3filter.ValidateResponse(r.Context(), input)
+}
+
+func fieldErrors(err error) []domain.FieldError {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return []domain.FieldError{{Code: domain.FieldInvalid, Message: err.Error()}}
+	}
+
+	if requestErr.Parameter != nil {
+		code := domain.FieldInvalid
+		if errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired) {
+			code = domain.FieldRequired
+		}
+		return []domain.FieldError{{Field: requestErr.Parameter.Name, Code: code, Message: requestErr.Error()}}
+	}
+
+	var me openapi3.MultiError
+	if !errors.As(requestErr.Err, &me) {
+		me = openapi3.MultiError{requestErr.Err}
+	}
+	details := make([]domain.FieldError, 0, len(me))
+	for _, e := range me {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(e, &schemaErr) {
+			details = append(details, domain.FieldError{Code: domain.FieldInvalid, Message: requestErr.Error()})
+			continue
+		}
+		code := domain.FieldInvalid
+		if schemaErr.SchemaField == "required" {
+			code = domain.FieldRequired
+		}
+		details = append(details, domain.FieldError{
+			Field:   strings.Join(schemaErr.JSONPointer(), "."),
+			Code:    code,
+			Message: schemaErr.Reason,
+		})
+	}
+	return details
+}
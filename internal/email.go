@@ -0,0 +1,10 @@
+package internal
+
+import "net/mail"
+
+// ValidateEmail tells if the value is a bare address like john@email.com,
+// without a display name or angle brackets.
+func ValidateEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
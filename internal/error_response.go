@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"main/domain"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gookit/slog"
+)
+
+var errorStatuses = map[domain.ErrorKind]int{
+	domain.KindInternal:        http.StatusInternalServerError,
+	domain.KindValidation:      http.StatusBadRequest,
+	domain.KindUnauthorized:    http.StatusUnauthorized,
+	domain.KindForbidden:       http.StatusForbidden,
+	domain.KindNotFound:        http.StatusNotFound,
+	domain.KindConflict:        http.StatusConflict,
+	domain.KindQuotaExceeded:   http.StatusRequestEntityTooLarge,
+	domain.KindLocked:          http.StatusLocked,
+	domain.KindTooManyRequests: http.StatusTooManyRequests,
+}
+
+// WriteError writes err as a {code, message, details} body with the status of its kind.
+// Errors that are not domain errors are logged and hidden behind a generic internal error,
+// so that database and storage errors never reach the client.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		slog.Errorf("%s %s: %v", r.Method, r.URL.Path, err)
+		domainErr = domain.ErrInternal
+	}
+
+	status, ok := errorStatuses[domainErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	language := Language(r)
+	response := domain.ErrorResponse{
+		Code:    domainErr.Code,
+		Message: Translate(language, domainErr.Code, domainErr.Message),
+	}
+	for _, detail := range domainErr.Details {
+		detail.Message = Translate(language, detail.Code, detail.Message)
+		response.Details = append(response.Details, detail)
+	}
+
+	if domainErr.RetryAfter > 0 {
+		seconds := (domainErr.RetryAfter + time.Second - 1) / time.Second
+		w.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+	}
+	w.Header().Set("Content-Language", language)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// Language picks the language of the messages from the Accept-Language header:
+// Russian or English, English by default.
+func Language(r *http.Request) string {
+	best, bestQuality := "en", 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if _, ok := translations[primary]; (ok || primary == "en") && quality > bestQuality {
+			best, bestQuality = primary, quality
+		}
+	}
+	return best
+}
+
+// Translate returns the message of the code in the language,
+// falling back to the English message.
+func Translate(language string, code string, message string) string {
+	if translated, ok := translations[language][code]; ok {
+		return translated
+	}
+	return message
+}
+
+var translations = map[string]map[string]string{
+	"ru": {
+		domain.FieldRequired: "обязательное поле",
+		domain.FieldInvalid:  "недопустимое значение",
+		"unknown_scope":      "неизвестный scope",
+
+		"internal":          "Внутренняя ошибка сервера",
+		"invalid_body":      "Некорректное тело запроса",
+		"invalid_data":      "Некорректные данные",
+		"not_authorized":    "Требуется авторизация",
+		"invalid_token":     "Недействительный токен",
+		"access_denied":     "Доступ запрещён",
+		"too_many_requests": "Слишком много запросов",
+
+		"user_not_found":              "Пользователь не найден",
+		"user_exists":                 "Пользователь с таким email уже существует",
+		"user_picture_not_found":      "Аватар пользователя не найден",
+		"invalid_credentials":         "Неверный логин или пароль",
+		"email_in_use":                "Email уже используется",
+		"email_already_verified":      "Email уже подтверждён",
+		"verification_sent":           "Письмо для подтверждения уже было отправлено недавно",
+		"invalid_or_expired_token":    "Недействительный или просроченный токен",
+		"account_locked":              "Аккаунт временно заблокирован",
+		"too_many_login_attempts":     "Слишком много попыток входа",
+		"invalid_refresh_token":       "Недействительный refresh токен",
+		"session_not_found":           "Сессия не найдена",
+		"session_revoked":             "Сессия отозвана",
+		"refresh_token_reused":        "Refresh токен использован повторно, сессия отозвана",
+		"two_factor_enabled":          "Двухфакторная аутентификация уже включена",
+		"two_factor_not_enrolled":     "Двухфакторная аутентификация не настроена",
+		"two_factor_not_enabled":      "Двухфакторная аутентификация не включена",
+		"invalid_code":                "Неверный код",
+		"invalid_mfa_token":           "Недействительный или просроченный MFA токен",
+		"unknown_provider":            "Неизвестный провайдер",
+		"invalid_oauth_state":         "Недействительный или просроченный state",
+		"oauth_exchange":              "Не удалось войти через провайдера",
+		"provider_email_not_verified": "Email не подтверждён у провайдера",
+		"identity_linked":             "Аккаунт провайдера привязан к другому пользователю",
+		"link_provider":               "Аккаунт с таким email уже существует, привяжите провайдера в его настройках",
+		"last_sign_in_method":         "Нельзя отвязать единственный способ входа",
+		"identity_not_found":          "Привязанный аккаунт не найден",
+		"api_key_not_found":           "API-ключ не найден",
+		"invalid_api_key":             "Недействительный API-ключ",
+		"api_key_expired":             "Срок действия API-ключа истёк",
+		"api_key_scope":               "У API-ключа нет доступа к этому методу",
+
+		"collection_not_found":   "Колода с таким ID не найдена",
+		"not_collection_owner":   "Вы не владелец этой колоды",
+		"collection_not_public":  "Колода не публичная",
+		"already_in_favourites":  "Колода уже в избранном",
+		"not_in_favourites":      "Колоды нет в избранном",
+		"nothing_found":          "Ничего не найдено",
+		"card_not_found":         "Карточка не найдена",
+		"card_picture_not_found": "Изображение карточки не найдено",
+		"file_size_limit":        "Достигнут лимит общего размера файлов",
+		"invalid_file":           "Ошибка файла или превышен его максимальный размер",
+		"image_too_large":        "Изображение должно быть меньше 5 МБ и 40 Мп",
+		"invalid_image_type":     "Изображение должно быть в формате JPEG, PNG, WebP или GIF",
+		"unsupported_image_type": "Изображения HEIC не поддерживаются, сконвертируйте их в JPEG",
+		"card_audio_not_found":   "Аудио карточки не найдено",
+		"card_audio_changed":     "Аудио карточки изменилось во время загрузки, повторите её",
+		"audio_too_large":        "Аудио должно быть меньше 10 МБ и 5 минут",
+		"invalid_audio_type":     "Аудио должно быть в формате MP3, OGG, WAV или M4A",
+		"too_many_attachments":   "У карточки может быть до 10 вложений, сначала удалите одно",
+		"upload_not_found":       "Загрузка не найдена или истекла",
+		"upload_incomplete":      "Файл ещё не загружен",
+	},
+}
@@ -0,0 +1,424 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"main/domain"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+	ContentTypeWebP = "image/webp"
+)
+
+const (
+	// maxImagePixels keeps a small file from decoding into gigabytes (40 megapixels, all frames of a GIF)
+	maxImagePixels = 40_000_000
+	jpegQuality    = 90
+
+	orientationTag = 0x0112
+	// webp VP8X flags, https://developers.google.com/speed/webp/docs/riff_container#extended_file_format
+	webpAnimation = 1 << 1
+	webpXMP       = 1 << 2
+	webpEXIF      = 1 << 3
+)
+
+var heicBrands = map[string]bool{
+	"heic": true, "heix": true, "hevc": true, "hevx": true,
+	"heim": true, "heis": true, "mif1": true, "msf1": true,
+}
+
+// CleanImage detects the format of the picture by its content and returns the picture without its metadata
+// (EXIF with the location, XMP, comments) together with its content type. The EXIF orientation is applied
+// to the pixels, so the picture looks the same without it.
+// The pictures that are not JPEG, PNG, WebP or GIF are rejected with ErrInvalidImageType,
+// HEIC with ErrUnsupportedImageType, there is no pure Go decoder for it.
+func CleanImage(data []byte) ([]byte, string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return cleanJPEG(data)
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return cleanPNG(data)
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return cleanGIF(data)
+	//nolint:mnd // RIFF, the size, WEBP
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return cleanWebP(data)
+	//nolint:mnd // the size of the box, ftyp, the major brand
+	case len(data) >= 12 && string(data[4:8]) == "ftyp" && heicBrands[string(data[8:12])]:
+		return nil, "", domain.ErrUnsupportedImageType
+	}
+	return nil, "", domain.ErrInvalidImageType
+}
+
+func cleanJPEG(data []byte) ([]byte, string, error) {
+	config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", domain.ErrInvalidImageType
+	}
+	if err = checkPixels(config, 1); err != nil {
+		return nil, "", err
+	}
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", domain.ErrInvalidImageType
+	}
+
+	// the encoder writes no APP segments, so the EXIF, XMP and comments are left behind
+	var out bytes.Buffer
+	err = jpeg.Encode(&out, orient(img, exifOrientation(jpegEXIF(data))), &jpeg.Options{Quality: jpegQuality})
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Bytes(), ContentTypeJPEG, nil
+}
+
+func cleanPNG(data []byte) ([]byte, string, error) {
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", domain.ErrInvalidImageType
+	}
+	if err = checkPixels(config, 1); err != nil {
+		return nil, "", err
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", domain.ErrInvalidImageType
+	}
+
+	// the encoder writes the pixels only, without the text and eXIf chunks
+	var out bytes.Buffer
+	err = png.Encode(&out, orient(img, exifOrientation(pngEXIF(data))))
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Bytes(), ContentTypePNG, nil
+}
+
+// cleanGIF re-encodes all the frames, the comments and the application extensions
+// except the loop count are dropped. GIF has no EXIF, so there is no orientation to apply.
+func cleanGIF(data []byte) ([]byte, string, error) {
+	config, err := gif.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", domain.ErrInvalidImageType
+	}
+	if err = checkPixels(config, gifFrames(data)); err != nil {
+		return nil, "", err
+	}
+	img, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", domain.ErrInvalidImageType
+	}
+
+	var out bytes.Buffer
+	err = gif.EncodeAll(&out, img)
+	if err != nil {
+		return nil, "", err
+	}
+	return out.Bytes(), ContentTypeGIF, nil
+}
+
+// cleanWebP drops the EXIF and XMP chunks from the container and keeps the image data as is.
+// A rotated still picture is decoded and re-encoded, as there is no WebP encoder in pure Go
+// it becomes PNG if it is transparent and JPEG otherwise.
+func cleanWebP(data []byte) ([]byte, string, error) {
+	chunks, ok := riffChunks(data)
+	if !ok {
+		return nil, "", domain.ErrInvalidImageType
+	}
+
+	var exif []byte
+	var flags byte
+	kept := make([]riffChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		switch chunk.id {
+		case "EXIF":
+			exif = chunk.data
+			continue
+		case "XMP ":
+			continue
+		case "VP8X":
+			if len(chunk.data) > 0 {
+				chunk.data = append([]byte{chunk.data[0] &^ (webpEXIF | webpXMP)}, chunk.data[1:]...)
+				flags = chunk.data[0]
+			}
+		}
+		kept = append(kept, chunk)
+	}
+
+	config, err := webp.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", domain.ErrInvalidImageType
+	}
+	if err = checkPixels(config, 1); err != nil {
+		return nil, "", err
+	}
+	// the decoder knows no animations, they are kept as they are
+	if flags&webpAnimation != 0 {
+		return riffBytes(kept), ContentTypeWebP, nil
+	}
+	img, err := webp.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", domain.ErrInvalidImageType
+	}
+
+	orientation := exifOrientation(bytes.TrimPrefix(exif, []byte("Exif\x00\x00")))
+	if orientation <= 1 {
+		return riffBytes(kept), ContentTypeWebP, nil
+	}
+	var out bytes.Buffer
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+		err = png.Encode(&out, orient(img, orientation))
+		return out.Bytes(), ContentTypePNG, err
+	}
+	err = jpeg.Encode(&out, orient(img, orientation), &jpeg.Options{Quality: jpegQuality})
+	return out.Bytes(), ContentTypeJPEG, err
+}
+
+// ResizeImage scales the picture down so its longest side is maxSide, the result is PNG if it is transparent
+// and JPEG otherwise. The first frame of an animation is taken. False is returned if the picture fits already
+// or can't be decoded (animated WebP), the original is good to be served as it is then.
+func ResizeImage(data []byte, maxSide int) ([]byte, string, bool) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || checkPixels(config, 1) != nil || max(config.Width, config.Height) <= maxSide {
+		return nil, "", false
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", false
+	}
+
+	bounds := img.Bounds()
+	width, height := maxSide, maxSide
+	if bounds.Dx() > bounds.Dy() {
+		height = max(1, bounds.Dy()*maxSide/bounds.Dx())
+	} else {
+		width = max(1, bounds.Dx()*maxSide/bounds.Dy())
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+
+	var out bytes.Buffer
+	if !dst.Opaque() {
+		err = png.Encode(&out, dst)
+		return out.Bytes(), ContentTypePNG, err == nil
+	}
+	err = jpeg.Encode(&out, dst, &jpeg.Options{Quality: jpegQuality})
+	return out.Bytes(), ContentTypeJPEG, err == nil
+}
+
+func checkPixels(config image.Config, frames int) error {
+	if config.Width <= 0 || config.Height <= 0 {
+		return domain.ErrInvalidImageType
+	}
+	if int64(config.Width)*int64(config.Height)*int64(frames) > maxImagePixels {
+		return domain.ErrImageTooLarge
+	}
+	return nil
+}
+
+// orient turns the picture so it looks as the EXIF orientation (1-8) says it should,
+// https://www.cipa.jp/std/documents/e/DC-008-2012_E.pdf, section 4.6.4.A.
+func orient(img image.Image, orientation int) image.Image {
+	//nolint:mnd // the orientations are numbered by EXIF
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	//nolint:mnd // the transposed orientations
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		for x := range dw {
+			var sx, sy int
+			//nolint:mnd // the orientations are numbered by EXIF
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, img.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return dst
+}
+
+// jpegEXIF returns the TIFF data of the EXIF APP1 segment, nil if there is none.
+func jpegEXIF(data []byte) []byte {
+	//nolint:mnd // the segments start after SOI, a segment is a marker and a big-endian length
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		//nolint:mnd // the start of scan and the end of image end the headers
+		if marker == 0xDA || marker == 0xD9 || length < 2 || i+2+length > len(data) {
+			return nil
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		i += 2 + length
+	}
+	return nil
+}
+
+// pngEXIF returns the data of the eXIf chunk, nil if there is none.
+func pngEXIF(data []byte) []byte {
+	//nolint:mnd // the chunks start after the signature, a chunk is a length, a type, the data and a CRC
+	for i := 8; i+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		kind := string(data[i+4 : i+8])
+		if length < 0 || i+12+length > len(data) || kind == "IDAT" {
+			return nil
+		}
+		if kind == "eXIf" {
+			return data[i+8 : i+8+length]
+		}
+		i += 12 + length
+	}
+	return nil
+}
+
+// exifOrientation reads the orientation tag of the first IFD of the TIFF data, 1 (as is) if there is none.
+func exifOrientation(tiff []byte) int {
+	//nolint:mnd // the TIFF header is the byte order, 42 and the offset of the first IFD
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	//nolint:mnd // an entry is the tag, the type, the count and the value
+	for i := range entries {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == orientationTag {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 1
+}
+
+// gifFrames counts the image descriptors of the GIF, the frames past the broken block are not counted,
+// the decoder rejects such a file anyway.
+func gifFrames(data []byte) int {
+	//nolint:mnd // the header and the logical screen descriptor, the size of the global color table
+	i := 13
+	if len(data) < i {
+		return 0
+	}
+	if data[10]&0x80 != 0 {
+		i += 3 << (data[10]&0x07 + 1)
+	}
+	frames := 0
+	for i < len(data) {
+		switch data[i] {
+		case 0x21: //nolint:mnd // an extension, its label and the sub-blocks
+			i = skipSubBlocks(data, i+2)
+		case 0x2C: //nolint:mnd // an image descriptor, the local color table, the LZW code size and the sub-blocks
+			frames++
+			if i+10 > len(data) {
+				return frames
+			}
+			flags := data[i+9]
+			i += 10
+			if flags&0x80 != 0 {
+				i += 3 << (flags&0x07 + 1)
+			}
+			i = skipSubBlocks(data, i+1)
+		default:
+			return frames
+		}
+	}
+	return frames
+}
+
+func skipSubBlocks(data []byte, i int) int {
+	for i < len(data) && data[i] != 0 {
+		i += int(data[i]) + 1
+	}
+	return i + 1
+}
+
+type riffChunk struct {
+	id   string
+	data []byte
+}
+
+// riffChunks splits the RIFF container into its chunks, false if it is broken.
+func riffChunks(data []byte) ([]riffChunk, bool) {
+	//nolint:mnd // RIFF, the size and the form type
+	size := int(binary.LittleEndian.Uint32(data[4:])) + 8
+	if size > len(data) || size < 12 {
+		return nil, false
+	}
+	var chunks []riffChunk
+	//nolint:mnd // a chunk is its id, its size and the data padded to an even size
+	for i := 12; i < size; {
+		if i+8 > size {
+			return nil, false
+		}
+		length := int(binary.LittleEndian.Uint32(data[i+4:]))
+		if length < 0 || i+8+length > size {
+			return nil, false
+		}
+		chunks = append(chunks, riffChunk{id: string(data[i : i+4]), data: data[i+8 : i+8+length]})
+		i += 8 + length + length%2
+	}
+	return chunks, true
+}
+
+func riffBytes(chunks []riffChunk) []byte {
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+	for _, chunk := range chunks {
+		body.WriteString(chunk.id)
+		_ = binary.Write(&body, binary.LittleEndian, uint32(len(chunk.data))) //nolint:gosec // less than the file
+		body.Write(chunk.data)
+		if len(chunk.data)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+	out := make([]byte, 0, body.Len()+8) //nolint:mnd // RIFF and the size
+	out = append(out, "RIFF"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(body.Len())) //nolint:gosec // less than the file
+	return append(out, body.Bytes()...)
+}
@@ -9,18 +9,25 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
-func CreateAccessToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
+func CreateAccessToken(
+	user *domain.User,
+	sessionID string,
+	keySet domain.KeySet,
+	expiry int,
+) (string, time.Time, error) {
 	expTime := time.Now().Add(time.Duration(expiry) * time.Hour)
 	claims := &domain.JwtCustomClaims{
-		Username: user.Username,
-		ID:       user.ID,
+		Type:      domain.TokenTypeAccess,
+		Username:  user.Username,
+		ID:        user.ID,
+		SessionID: sessionID,
+		Roles:     user.Roles,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: &jwt.NumericDate{Time: expTime},
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	accessToken, err := token.SignedString([]byte(secret))
+	accessToken, err := keySet.Sign(claims)
 	if err != nil {
 		return "", expTime, err
 	}
@@ -28,10 +35,19 @@ func CreateAccessToken(user *domain.User, secret string, expiry int) (string, ti
 	return accessToken, expTime, nil
 }
 
-func CreateRefreshToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
+func CreateRefreshToken(
+	user *domain.User,
+	sessionID string,
+	tokenID string,
+	secret string,
+	expiry int,
+) (string, time.Time, error) {
 	expTime := time.Now().Add(time.Duration(expiry) * time.Hour)
-	claimsRefresh := &domain.JwtCustomClaims{
-		ID: user.ID,
+	claimsRefresh := &domain.JwtCustomRefreshClaims{
+		Type:      domain.TokenTypeRefresh,
+		ID:        user.ID,
+		SessionID: sessionID,
+		TokenID:   tokenID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: &jwt.NumericDate{Time: expTime},
 		},
@@ -45,29 +61,30 @@ func CreateRefreshToken(user *domain.User, secret string, expiry int) (string, t
 	return refreshToken, expTime, nil
 }
 
-func ExtractIDFromToken(requestToken string, secret string) (string, error) {
-	token, err := jwt.Parse(requestToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
+// ParseAccessToken verifies the token and returns its claims. Tokens of another type,
+// including refresh tokens signed with the same secret, are rejected. Access tokens issued
+// before the type was added have neither the type nor a session and are accepted only
+// from the legacy key, for as long as it is accepted.
+func ParseAccessToken(requestToken string, keySet domain.KeySet) (*domain.JwtCustomClaims, error) {
+	claims := &domain.JwtCustomClaims{}
+	token, err := jwt.ParseWithClaims(requestToken, claims, keySet.VerificationKey)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-
-	if !ok && !token.Valid {
-		return "", errors.New("invalid token")
+	_, hasKeyID := token.Header["kid"]
+	untyped := claims.Type == "" && !hasKeyID && claims.SessionID == ""
+	if !token.Valid || claims.ID == "" || (claims.Type != domain.TokenTypeAccess && !untyped) {
+		return nil, errors.New("invalid token")
 	}
 
-	return claims["id"].(string), nil
+	return claims, nil
 }
 
-func IsAuthorized(token string, secret string) (bool, error) {
-	_, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+func ParseRefreshToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	claims := &domain.JwtCustomRefreshClaims{}
+	token, err := jwt.ParseWithClaims(requestToken, claims, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
@@ -75,8 +92,17 @@ func IsAuthorized(token string, secret string) (bool, error) {
 	})
 
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	// refresh tokens issued before the type was added have none
+	if claims.Type != domain.TokenTypeRefresh && claims.Type != "" {
+		return nil, errors.New("invalid token")
+	}
+
+	if !token.Valid || claims.ID == "" || claims.SessionID == "" || claims.TokenID == "" {
+		return nil, errors.New("invalid token")
 	}
 
-	return true, nil
+	return claims, nil
 }
@@ -0,0 +1,213 @@
+package internal
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"main/domain"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type KeySetConfig struct {
+	// SigningKeyID selects the key from PrivateKeys that new tokens are signed with;
+	// without it tokens are signed with LegacySecret using HS256.
+	SigningKeyID string
+	PrivateKeys  map[string]crypto.Signer
+	// PublicKeys are only used for verification, e.g. a retired key whose tokens
+	// have not expired yet.
+	PublicKeys   map[string]crypto.PublicKey
+	LegacySecret string
+	// AcceptLegacy keeps HS256 tokens without kid valid during the migration.
+	AcceptLegacy bool
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+type keySet struct {
+	signingKeyID  string
+	signingMethod jwt.SigningMethod
+	signer        crypto.Signer
+	keys          map[string]verificationKey
+	legacySecret  []byte
+	acceptLegacy  bool
+}
+
+func NewKeySet(config KeySetConfig) (domain.KeySet, error) {
+	ks := &keySet{
+		keys:         make(map[string]verificationKey),
+		legacySecret: []byte(config.LegacySecret),
+		acceptLegacy: config.AcceptLegacy || config.SigningKeyID == "",
+	}
+
+	for kid, public := range config.PublicKeys {
+		method, err := signingMethod(public)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", kid, err)
+		}
+		ks.keys[kid] = verificationKey{method: method, key: public}
+	}
+	for kid, private := range config.PrivateKeys {
+		method, err := signingMethod(private.Public())
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", kid, err)
+		}
+		ks.keys[kid] = verificationKey{method: method, key: private.Public()}
+	}
+
+	if config.SigningKeyID != "" {
+		signer, ok := config.PrivateKeys[config.SigningKeyID]
+		if !ok {
+			return nil, fmt.Errorf("no private key %s to sign with", config.SigningKeyID)
+		}
+		ks.signingKeyID = config.SigningKeyID
+		ks.signingMethod = ks.keys[config.SigningKeyID].method
+		ks.signer = signer
+	}
+
+	if ks.acceptLegacy && len(ks.legacySecret) == 0 {
+		return nil, errors.New("HS256 tokens need a secret")
+	}
+	return ks, nil
+}
+
+// LoadKeySet reads PEM encoded keys from dir; the file name without
+// the .pem extension is the kid.
+func LoadKeySet(dir string, signingKeyID string, legacySecret string, acceptLegacy bool) (domain.KeySet, error) {
+	config := KeySetConfig{
+		SigningKeyID: signingKeyID,
+		PrivateKeys:  make(map[string]crypto.Signer),
+		PublicKeys:   make(map[string]crypto.PublicKey),
+		LegacySecret: legacySecret,
+		AcceptLegacy: acceptLegacy,
+	}
+
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			kid := strings.TrimSuffix(filepath.Base(file), ".pem")
+			err = loadKey(file, kid, &config)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", kid, err)
+			}
+		}
+	}
+
+	return NewKeySet(config)
+}
+
+func loadKey(file string, kid string, config *KeySetConfig) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return errors.New("not a PEM file")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return errors.New("unsupported private key")
+		}
+		config.PrivateKeys[kid] = signer
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		config.PrivateKeys[kid] = key
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		config.PublicKeys[kid] = key
+	default:
+		return fmt.Errorf("unsupported PEM block %s", block.Type)
+	}
+	return nil
+}
+
+func signingMethod(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, errors.New("only RSA and Ed25519 keys are supported")
+	}
+}
+
+func (ks *keySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.signer == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.legacySecret)
+	}
+
+	token := jwt.NewWithClaims(ks.signingMethod, claims)
+	token.Header["kid"] = ks.signingKeyID
+	return token.SignedString(ks.signer)
+}
+
+func (ks *keySet) VerificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && ks.acceptLegacy {
+			return ks.legacySecret, nil
+		}
+		return nil, errors.New("token has no key id")
+	}
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %s", kid)
+	}
+	// the algorithm comes from the key, never from the token
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.key, nil
+}
+
+func (ks *keySet) JWKS() domain.JWKS {
+	jwks := domain.JWKS{Keys: make([]domain.JWK, 0, len(ks.keys))}
+	for kid, key := range ks.keys {
+		jwk := domain.JWK{Use: "sig", Kid: kid, Alg: key.method.Alg()}
+		switch public := key.key.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].Kid < jwks.Keys[j].Kid })
+	return jwks
+}
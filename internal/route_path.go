@@ -0,0 +1,16 @@
+package internal
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RoutePath returns the path of the request inside the route tree it is
+// served by, e.g. /collection/42 for /v1/collection/42.
+func RoutePath(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		return rctx.RoutePath
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const secretTokenBytes = 32
+
+// GenerateSecretToken returns a random URL-safe token for links sent by mail.
+func GenerateSecretToken() (string, error) {
+	b := make([]byte, secretTokenBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashSecretToken is what gets stored instead of the token itself.
+func HashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,21 @@
+package internal
+
+import (
+	"main/domain"
+	"net/http"
+)
+
+// ServeObject streams the object with its Content-Type, Content-Length, ETag and Last-Modified
+// and closes it. Range requests get the requested part (206), conditional requests
+// with If-None-Match or If-Modified-Since get 304 when the object didn't change.
+func ServeObject(w http.ResponseWriter, r *http.Request, object domain.Object) {
+	defer object.Close()
+
+	w.Header().Set("Content-Type", object.ContentType)
+	// the objects are private to the users, the clients revalidate them with the ETag
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if object.ETag != "" {
+		w.Header().Set("ETag", `"`+object.ETag+`"`)
+	}
+	http.ServeContent(w, r, "", object.LastModified, object)
+}
@@ -0,0 +1,152 @@
+package tests_test
+
+import (
+	"encoding/binary"
+	"main/domain"
+	"main/internal"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeWAV is mono 16-bit PCM at 8 kHz of silence
+func encodeWAV(samples int) []byte {
+	format := binary.LittleEndian.AppendUint16(nil, 1)
+	format = binary.LittleEndian.AppendUint16(format, 1)
+	format = binary.LittleEndian.AppendUint32(format, 8000)
+	format = binary.LittleEndian.AppendUint32(format, 16000)
+	format = binary.LittleEndian.AppendUint16(format, 2)
+	format = binary.LittleEndian.AppendUint16(format, 16)
+
+	body := []byte("WAVE")
+	body = append(body, "fmt "...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(format)))
+	body = append(body, format...)
+	body = append(body, "data"...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(2*samples))
+	body = append(body, make([]byte, 2*samples)...)
+	return append(binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body))), body...)
+}
+
+// encodeMP3 is the frames of MPEG-1 layer III at 128 kbit/s and 44.1 kHz, 1152 samples each
+func encodeMP3(frames int, id3 bool) []byte {
+	var data []byte
+	if id3 {
+		// the tag of 129 bytes, the syncsafe size is 1<<7 + 1
+		data = append([]byte("ID3\x04\x00\x00\x00\x00\x01\x01"), make([]byte, 129)...)
+	}
+	for range frames {
+		// 144 * 128000 / 44100 bytes without the padding
+		frame := make([]byte, 417)
+		copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+		data = append(data, frame...)
+	}
+	return data
+}
+
+// oggPage is a page of the stream serial with one packet
+func oggPage(serial uint32, granule int64, packet []byte) []byte {
+	page := []byte("OggS\x00\x00")
+	page = binary.LittleEndian.AppendUint64(page, uint64(granule))
+	page = binary.LittleEndian.AppendUint32(page, serial)
+	page = append(page, make([]byte, 8)...)
+	var segments []byte
+	for size := len(packet); ; size -= 255 {
+		if size < 255 {
+			segments = append(segments, byte(size))
+			break
+		}
+		segments = append(segments, 255)
+	}
+	page = append(page, byte(len(segments)))
+	page = append(page, segments...)
+	return append(page, packet...)
+}
+
+func encodeVorbis(rate uint32, samples int64) []byte {
+	head := []byte("\x01vorbis\x00\x00\x00\x00\x02")
+	head = binary.LittleEndian.AppendUint32(head, rate)
+	head = append(head, make([]byte, 14)...)
+	data := oggPage(7, 0, head)
+	data = append(data, oggPage(7, samples/2, make([]byte, 300))...)
+	// the other stream doesn't count
+	data = append(data, oggPage(8, samples*10, make([]byte, 10))...)
+	return append(data, oggPage(7, samples, make([]byte, 300))...)
+}
+
+func encodeOpus(preSkip uint16, granule int64) []byte {
+	head := []byte("OpusHead\x01\x02")
+	head = binary.LittleEndian.AppendUint16(head, preSkip)
+	head = binary.LittleEndian.AppendUint32(head, 48000)
+	head = append(head, 0, 0, 0)
+	return append(oggPage(1, 0, head), oggPage(1, granule, make([]byte, 100))...)
+}
+
+func mp4Box(kind string, data []byte) []byte {
+	return append(append(binary.BigEndian.AppendUint32(nil, uint32(8+len(data))), kind...), data...)
+}
+
+func encodeM4A(brand string, timescale uint32, duration uint32) []byte {
+	mvhd := make([]byte, 12)
+	mvhd = binary.BigEndian.AppendUint32(mvhd, timescale)
+	mvhd = binary.BigEndian.AppendUint32(mvhd, duration)
+	mvhd = append(mvhd, make([]byte, 80)...)
+	data := mp4Box("ftyp", []byte(brand+"\x00\x00\x00\x00isom"))
+	data = append(data, mp4Box("moov", mp4Box("mvhd", mvhd))...)
+	return append(data, mp4Box("mdat", make([]byte, 64))...)
+}
+
+func TestCheckAudio(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		duration    time.Duration
+		err         *domain.Error
+	}{
+		{name: "wav", data: encodeWAV(12000), contentType: internal.ContentTypeWAV, duration: 1500 * time.Millisecond},
+		{name: "mp3", data: encodeMP3(3, false), contentType: internal.ContentTypeMP3, duration: 3 * 26122448},
+		{name: "mp3 with id3", data: encodeMP3(2, true), contentType: internal.ContentTypeMP3, duration: 2 * 26122448},
+		{name: "vorbis", data: encodeVorbis(44100, 88200), contentType: internal.ContentTypeOGG, duration: 2 * time.Second},
+		{name: "opus", data: encodeOpus(312, 48312), contentType: internal.ContentTypeOGG, duration: time.Second},
+		{
+			name:        "m4a",
+			data:        encodeM4A("M4A ", 1000, 2500),
+			contentType: internal.ContentTypeM4A,
+			duration:    2500 * time.Millisecond,
+		},
+		{name: "text", data: []byte("definitely not a sound"), err: domain.ErrInvalidAudioType},
+		{name: "empty", data: nil, err: domain.ErrInvalidAudioType},
+		{name: "one mp3 frame", data: encodeMP3(1, false), err: domain.ErrInvalidAudioType},
+		{name: "cut wav", data: encodeWAV(12000)[:100], err: domain.ErrInvalidAudioType},
+		{name: "empty wav", data: encodeWAV(0), err: domain.ErrInvalidAudioType},
+		{name: "ogg without audio", data: oggPage(1, 100, []byte("\x80theora")), err: domain.ErrInvalidAudioType},
+		{name: "mp4 video", data: encodeM4A("isom", 1000, 2500), err: domain.ErrInvalidAudioType},
+		{name: "jpeg", data: encodeJPEG(t, testImage(), nil), err: domain.ErrInvalidAudioType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, duration, err := internal.CheckAudio(tt.data)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.contentType, contentType)
+			assert.Equal(t, tt.duration, duration)
+		})
+	}
+}
+
+func TestCheckAudio_TooLong(t *testing.T) {
+	// the duration is told as it is, the limit is up to the caller
+	_, duration, err := internal.CheckAudio(encodeM4A("M4A ", 1, 10*60))
+	require.NoError(t, err)
+	assert.Greater(t, duration, domain.MaxAudioDuration)
+
+	// longer than a day is not a real duration
+	_, _, err = internal.CheckAudio(encodeM4A("M4A ", 1, 2*24*60*60))
+	assert.ErrorIs(t, err, domain.ErrInvalidAudioType)
+}
@@ -0,0 +1,212 @@
+package tests_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"main/domain"
+	"main/internal"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+// a lossless 1x1 WebP
+const testWebP = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+var (
+	red   = color.NRGBA{R: 255, A: 255}
+	blue  = color.NRGBA{B: 255, A: 255}
+	place = []byte("GPS 55.7558N 37.6173E")
+)
+
+// testImage is 4x2 and blue with the red top left corner
+func testImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := range 2 {
+		for x := range 4 {
+			img.Set(x, y, blue)
+		}
+	}
+	img.Set(0, 0, red)
+	return img
+}
+
+// exif is little-endian TIFF data with the orientation and the location after it
+func exif(orientation uint16) []byte {
+	tiff := []byte("II*\x00")
+	tiff = binary.LittleEndian.AppendUint32(tiff, 8)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint32(tiff, uint32(orientation))
+	tiff = binary.LittleEndian.AppendUint32(tiff, 0)
+	return append(tiff, place...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image, tiff []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	if tiff == nil {
+		return buf.Bytes()
+	}
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(segment)+2))
+	data := append([]byte{}, buf.Bytes()[:2]...)
+	data = append(data, app1...)
+	data = append(data, segment...)
+	return append(data, buf.Bytes()[2:]...)
+}
+
+func encodePNG(t *testing.T, img image.Image, tiff []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	// the eXIf chunk goes right after the signature (8 bytes) and IHDR (25 bytes)
+	const header = 33
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(tiff)))
+	chunk = append(chunk, "eXIf"...)
+	chunk = append(chunk, tiff...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	data := append([]byte{}, buf.Bytes()[:header]...)
+	data = append(data, chunk...)
+	return append(data, buf.Bytes()[header:]...)
+}
+
+func encodeGIF(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, gif.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// webpWithEXIF puts the 1x1 WebP into the extended container with the EXIF chunk
+func webpWithEXIF(t *testing.T, tiff []byte) []byte {
+	t.Helper()
+	simple, err := base64.StdEncoding.DecodeString(testWebP)
+	require.NoError(t, err)
+
+	chunk := func(id string, data []byte) []byte {
+		out := append([]byte(id), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+		out = append(out, data...)
+		if len(data)%2 == 1 {
+			out = append(out, 0)
+		}
+		return out
+	}
+	body := []byte("WEBP")
+	body = append(body, chunk("VP8X", []byte{0x08, 0, 0, 0, 0, 0, 0, 0, 0, 0})...)
+	body = append(body, simple[12:]...)
+	body = append(body, chunk("EXIF", tiff)...)
+	return append(append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...), body...)
+}
+
+func decode(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, _, err := image.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img
+}
+
+func TestCleanImage_Types(t *testing.T) {
+	webpData, err := base64.StdEncoding.DecodeString(testWebP)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		err         *domain.Error
+	}{
+		{name: "jpeg", data: encodeJPEG(t, testImage(), nil), contentType: internal.ContentTypeJPEG},
+		{name: "png", data: encodePNG(t, testImage(), exif(1)), contentType: internal.ContentTypePNG},
+		{name: "gif", data: encodeGIF(t, testImage()), contentType: internal.ContentTypeGIF},
+		{name: "webp", data: webpData, contentType: internal.ContentTypeWebP},
+		{name: "text", data: []byte("definitely not a picture"), err: domain.ErrInvalidImageType},
+		{name: "empty", data: nil, err: domain.ErrInvalidImageType},
+		{name: "broken jpeg", data: encodeJPEG(t, testImage(), nil)[:40], err: domain.ErrInvalidImageType},
+		{
+			name: "heic",
+			data: []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic"),
+			err:  domain.ErrUnsupportedImageType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clean, contentType, err := internal.CleanImage(tt.data)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.contentType, contentType)
+			decode(t, clean)
+		})
+	}
+}
+
+func TestCleanImage_TooManyPixels(t *testing.T) {
+	data := encodeGIF(t, testImage())
+	// the logical screen of 10000x10000
+	binary.LittleEndian.PutUint16(data[6:], 10000)
+	binary.LittleEndian.PutUint16(data[8:], 10000)
+
+	_, _, err := internal.CleanImage(data)
+	assert.ErrorIs(t, err, domain.ErrImageTooLarge)
+}
+
+func TestCleanImage_Orientation(t *testing.T) {
+	// 6 is rotated 90° clockwise to be displayed
+	clean, contentType, err := internal.CleanImage(encodeJPEG(t, testImage(), exif(6)))
+	require.NoError(t, err)
+	assert.Equal(t, internal.ContentTypeJPEG, contentType)
+	assert.Equal(t, image.Rect(0, 0, 2, 4), decode(t, clean).Bounds())
+	assert.NotContains(t, string(clean), "Exif")
+	assert.NotContains(t, string(clean), string(place))
+
+	clean, contentType, err = internal.CleanImage(encodePNG(t, testImage(), exif(6)))
+	require.NoError(t, err)
+	assert.Equal(t, internal.ContentTypePNG, contentType)
+	img := decode(t, clean)
+	require.Equal(t, image.Rect(0, 0, 2, 4), img.Bounds())
+	assert.Equal(t, red, color.NRGBAModel.Convert(img.At(1, 0)), "the corner is top right")
+	assert.Equal(t, blue, color.NRGBAModel.Convert(img.At(0, 0)))
+	assert.NotContains(t, string(clean), "eXIf")
+	assert.NotContains(t, string(clean), string(place))
+
+	// 3 is upside down
+	clean, _, err = internal.CleanImage(encodePNG(t, testImage(), exif(3)))
+	require.NoError(t, err)
+	img = decode(t, clean)
+	require.Equal(t, image.Rect(0, 0, 4, 2), img.Bounds())
+	assert.Equal(t, red, color.NRGBAModel.Convert(img.At(3, 1)), "the corner is bottom right")
+}
+
+func TestCleanImage_WebP(t *testing.T) {
+	clean, contentType, err := internal.CleanImage(webpWithEXIF(t, exif(1)))
+	require.NoError(t, err)
+	assert.Equal(t, internal.ContentTypeWebP, contentType)
+	assert.NotContains(t, string(clean), "EXIF")
+	assert.NotContains(t, string(clean), string(place))
+	assert.Equal(t, byte(0), clean[20]&0x08, "the EXIF flag of VP8X is cleared")
+	assert.Equal(t, uint32(len(clean)-8), binary.LittleEndian.Uint32(clean[4:]))
+	_, err = webp.Decode(bytes.NewReader(clean))
+	require.NoError(t, err)
+
+	// there is no WebP encoder to store the turned picture with
+	clean, contentType, err = internal.CleanImage(webpWithEXIF(t, exif(6)))
+	require.NoError(t, err)
+	assert.NotEqual(t, internal.ContentTypeWebP, contentType)
+	assert.NotContains(t, string(clean), string(place))
+	decode(t, clean)
+}
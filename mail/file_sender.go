@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileSender writes every message into its own file in dir,
+// so that local setups and tests can read what would have been sent.
+type fileSender struct {
+	dir string
+}
+
+func NewFileSender(dir string) (Sender, error) {
+	//nolint:mnd // rwx for owner only
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSender{dir: dir}, nil
+}
+
+func (fs *fileSender) Send(_ context.Context, message Message) error {
+	recipient := strings.NewReplacer("/", "_", "\\", "_").Replace(message.To)
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), recipient)
+	content := "To: " + message.To + "\r\nSubject: " + message.Subject + "\r\n\r\n" + message.Body
+
+	//nolint:mnd // rw for owner only
+	return os.WriteFile(filepath.Join(fs.dir, name), []byte(content), 0o600)
+}
@@ -0,0 +1,19 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/gookit/slog"
+)
+
+// logSender only prints messages; it is meant for local development.
+type logSender struct{}
+
+func NewLogSender() Sender {
+	return &logSender{}
+}
+
+func (ls *logSender) Send(_ context.Context, message Message) error {
+	slog.Infof("Mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+type smtpSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender sends messages through an SMTP relay;
+// PLAIN auth is used only when username is set.
+func NewSMTPSender(host string, port int, username string, password string, from string) Sender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpSender{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (ss *smtpSender) Send(ctx context.Context, message Message) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + ss.from + "\r\n")
+	b.WriteString("To: " + message.To + "\r\n")
+	b.WriteString("Subject: " + message.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	return smtp.SendMail(ss.addr, ss.auth, ss.from, []string{message.To}, []byte(b.String()))
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ApiKeyRepository is an autogenerated mock type for the ApiKeyRepository type
+type ApiKeyRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: c, apiKey
+func (_m *ApiKeyRepository) Create(c context.Context, apiKey *domain.ApiKey) (string, error) {
+	ret := _m.Called(c, apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ApiKey) (string, error)); ok {
+		return rf(c, apiKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ApiKey) string); ok {
+		r0 = rf(c, apiKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.ApiKey) error); ok {
+		r1 = rf(c, apiKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByID provides a mock function with given fields: c, userID, apiKeyID
+func (_m *ApiKeyRepository) DeleteByID(c context.Context, userID string, apiKeyID string) (int64, error) {
+	ret := _m.Called(c, userID, apiKeyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(c, userID, apiKeyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(c, userID, apiKeyID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, userID, apiKeyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByHash provides a mock function with given fields: c, hash
+func (_m *ApiKeyRepository) GetByHash(c context.Context, hash string) (domain.ApiKey, error) {
+	ret := _m.Called(c, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByHash")
+	}
+
+	var r0 domain.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.ApiKey, error)); ok {
+		return rf(c, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.ApiKey); ok {
+		r0 = rf(c, hash)
+	} else {
+		r0 = ret.Get(0).(domain.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: c, userID
+func (_m *ApiKeyRepository) GetByUserID(c context.Context, userID string) ([]domain.ApiKey, error) {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []domain.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.ApiKey, error)); ok {
+		return rf(c, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.ApiKey); ok {
+		r0 = rf(c, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLastUsed provides a mock function with given fields: c, apiKeyID, lastUsedAt
+func (_m *ApiKeyRepository) UpdateLastUsed(c context.Context, apiKeyID string, lastUsedAt int64) error {
+	ret := _m.Called(c, apiKeyID, lastUsedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(c, apiKeyID, lastUsedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewApiKeyRepository creates a new instance of ApiKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewApiKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ApiKeyRepository {
+	mock := &ApiKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
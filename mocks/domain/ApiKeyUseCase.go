@@ -0,0 +1,133 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ApiKeyUseCase is an autogenerated mock type for the ApiKeyUseCase type
+type ApiKeyUseCase struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: c, key
+func (_m *ApiKeyUseCase) Authenticate(c context.Context, key string) (domain.ApiKey, error) {
+	ret := _m.Called(c, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 domain.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.ApiKey, error)); ok {
+		return rf(c, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.ApiKey); ok {
+		r0 = rf(c, key)
+	} else {
+		r0 = ret.Get(0).(domain.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: c, userID, request
+func (_m *ApiKeyUseCase) Create(c context.Context, userID string, request *domain.CreateApiKeyRequest) (domain.CreateApiKeyResponse, error) {
+	ret := _m.Called(c, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.CreateApiKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.CreateApiKeyRequest) (domain.CreateApiKeyResponse, error)); ok {
+		return rf(c, userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.CreateApiKeyRequest) domain.CreateApiKeyResponse); ok {
+		r0 = rf(c, userID, request)
+	} else {
+		r0 = ret.Get(0).(domain.CreateApiKeyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.CreateApiKeyRequest) error); ok {
+		r1 = rf(c, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: c, userID, apiKeyID
+func (_m *ApiKeyUseCase) Delete(c context.Context, userID string, apiKeyID string) error {
+	ret := _m.Called(c, userID, apiKeyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, userID, apiKeyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByUserID provides a mock function with given fields: c, userID
+func (_m *ApiKeyUseCase) GetByUserID(c context.Context, userID string) ([]domain.ApiKey, error) {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []domain.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.ApiKey, error)); ok {
+		return rf(c, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.ApiKey); ok {
+		r0 = rf(c, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewApiKeyUseCase creates a new instance of ApiKeyUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewApiKeyUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ApiKeyUseCase {
+	mock := &ApiKeyUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,10 +4,13 @@ package mocks
 
 import (
 	context "context"
+	domain "main/domain"
 
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // CollectionStorage is an autogenerated mock type for the CollectionStorage type
@@ -15,25 +18,51 @@ type CollectionStorage struct {
 	mock.Mock
 }
 
+// GetAudio provides a mock function with given fields: c, objectName
+func (_m *CollectionStorage) GetAudio(c context.Context, objectName string) (domain.Object, error) {
+	ret := _m.Called(c, objectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAudio")
+	}
+
+	var r0 domain.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Object, error)); ok {
+		return rf(c, objectName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Object); ok {
+		r0 = rf(c, objectName)
+	} else {
+		r0 = ret.Get(0).(domain.Object)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, objectName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetObject provides a mock function with given fields: c, objectName
-func (_m *CollectionStorage) GetObject(c context.Context, objectName string) ([]byte, error) {
+func (_m *CollectionStorage) GetObject(c context.Context, objectName string) (domain.Object, error) {
 	ret := _m.Called(c, objectName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetObject")
 	}
 
-	var r0 []byte
+	var r0 domain.Object
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Object, error)); ok {
 		return rf(c, objectName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Object); ok {
 		r0 = rf(c, objectName)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+		r0 = ret.Get(0).(domain.Object)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -45,17 +74,109 @@ func (_m *CollectionStorage) GetObject(c context.Context, objectName string) ([]
 	return r0, r1
 }
 
-// PutObject provides a mock function with given fields: c, objectName, reader, objectSize
-func (_m *CollectionStorage) PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64) error {
-	ret := _m.Called(c, objectName, reader, objectSize)
+// GetPicture provides a mock function with given fields: c, objectName, size
+func (_m *CollectionStorage) GetPicture(c context.Context, objectName string, size domain.PictureSize) (domain.Object, error) {
+	ret := _m.Called(c, objectName, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPicture")
+	}
+
+	var r0 domain.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) (domain.Object, error)); ok {
+		return rf(c, objectName, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) domain.Object); ok {
+		r0 = rf(c, objectName, size)
+	} else {
+		r0 = ret.Get(0).(domain.Object)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.PictureSize) error); ok {
+		r1 = rf(c, objectName, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PresignGet provides a mock function with given fields: c, objectName, size, expires
+func (_m *CollectionStorage) PresignGet(c context.Context, objectName string, size domain.PictureSize, expires time.Duration) (string, error) {
+	ret := _m.Called(c, objectName, size, expires)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PresignGet")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize, time.Duration) (string, error)); ok {
+		return rf(c, objectName, size, expires)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize, time.Duration) string); ok {
+		r0 = rf(c, objectName, size, expires)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.PictureSize, time.Duration) error); ok {
+		r1 = rf(c, objectName, size, expires)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutAudio provides a mock function with given fields: c, objectName, reader, objectSize, contentType
+func (_m *CollectionStorage) PutAudio(c context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error {
+	ret := _m.Called(c, objectName, reader, objectSize, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutAudio")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64, string) error); ok {
+		r0 = rf(c, objectName, reader, objectSize, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutObject provides a mock function with given fields: c, objectName, reader, objectSize, contentType
+func (_m *CollectionStorage) PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error {
+	ret := _m.Called(c, objectName, reader, objectSize, contentType)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PutObject")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64) error); ok {
-		r0 = rf(c, objectName, reader, objectSize)
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64, string) error); ok {
+		r0 = rf(c, objectName, reader, objectSize, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveAudio provides a mock function with given fields: c, objectName
+func (_m *CollectionStorage) RemoveAudio(c context.Context, objectName string) error {
+	ret := _m.Called(c, objectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAudio")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, objectName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -81,6 +202,34 @@ func (_m *CollectionStorage) RemoveObject(c context.Context, objectName string)
 	return r0
 }
 
+// StatObject provides a mock function with given fields: c, objectName
+func (_m *CollectionStorage) StatObject(c context.Context, objectName string) (domain.ObjectInfo, error) {
+	ret := _m.Called(c, objectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatObject")
+	}
+
+	var r0 domain.ObjectInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.ObjectInfo, error)); ok {
+		return rf(c, objectName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.ObjectInfo); ok {
+		r0 = rf(c, objectName)
+	} else {
+		r0 = ret.Get(0).(domain.ObjectInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, objectName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCollectionStorage creates a new instance of CollectionStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCollectionStorage(t interface {
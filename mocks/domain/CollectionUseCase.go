@@ -44,6 +44,34 @@ func (_m *CollectionUseCase) AddCard(c context.Context, collectionID string, car
 	return r0, r1
 }
 
+// AddCardAttachment provides a mock function with given fields: c, userID, collectionID, cardID, side, picture, size, contentType
+func (_m *CollectionUseCase) AddCardAttachment(c context.Context, userID string, collectionID string, cardID int, side domain.CardSide, picture io.Reader, size int64, contentType string) (domain.CardAttachment, error) {
+	ret := _m.Called(c, userID, collectionID, cardID, side, picture, size, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCardAttachment")
+	}
+
+	var r0 domain.CardAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, domain.CardSide, io.Reader, int64, string) (domain.CardAttachment, error)); ok {
+		return rf(c, userID, collectionID, cardID, side, picture, size, contentType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, domain.CardSide, io.Reader, int64, string) domain.CardAttachment); ok {
+		r0 = rf(c, userID, collectionID, cardID, side, picture, size, contentType)
+	} else {
+		r0 = ret.Get(0).(domain.CardAttachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, domain.CardSide, io.Reader, int64, string) error); ok {
+		r1 = rf(c, userID, collectionID, cardID, side, picture, size, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddLike provides a mock function with given fields: c, collectionID, userID
 func (_m *CollectionUseCase) AddLike(c context.Context, collectionID string, userID string) (*domain.Collection, error) {
 	ret := _m.Called(c, collectionID, userID)
@@ -120,17 +148,17 @@ func (_m *CollectionUseCase) DeleteByID(c context.Context, collectionID string,
 	return r0
 }
 
-// DeleteCard provides a mock function with given fields: c, collectionID, cardLocalID
-func (_m *CollectionUseCase) DeleteCard(c context.Context, collectionID string, cardLocalID int) error {
-	ret := _m.Called(c, collectionID, cardLocalID)
+// DeleteCard provides a mock function with given fields: c, userID, collectionID, cardLocalID
+func (_m *CollectionUseCase) DeleteCard(c context.Context, userID string, collectionID string, cardLocalID int) error {
+	ret := _m.Called(c, userID, collectionID, cardLocalID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteCard")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
-		r0 = rf(c, collectionID, cardLocalID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(c, userID, collectionID, cardLocalID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -166,25 +194,23 @@ func (_m *CollectionUseCase) GetByID(c context.Context, collectionID string) (do
 	return r0, r1
 }
 
-// GetCardPhoto provides a mock function with given fields: c, objectName
-func (_m *CollectionUseCase) GetCardPhoto(c context.Context, objectName string) ([]byte, error) {
+// GetCardAudio provides a mock function with given fields: c, objectName
+func (_m *CollectionUseCase) GetCardAudio(c context.Context, objectName string) (domain.Object, error) {
 	ret := _m.Called(c, objectName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCardPhoto")
+		panic("no return value specified for GetCardAudio")
 	}
 
-	var r0 []byte
+	var r0 domain.Object
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Object, error)); ok {
 		return rf(c, objectName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Object); ok {
 		r0 = rf(c, objectName)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+		r0 = ret.Get(0).(domain.Object)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -196,6 +222,90 @@ func (_m *CollectionUseCase) GetCardPhoto(c context.Context, objectName string)
 	return r0, r1
 }
 
+// GetCardPhoto provides a mock function with given fields: c, objectName, size
+func (_m *CollectionUseCase) GetCardPhoto(c context.Context, objectName string, size domain.PictureSize) (domain.Object, error) {
+	ret := _m.Called(c, objectName, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCardPhoto")
+	}
+
+	var r0 domain.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) (domain.Object, error)); ok {
+		return rf(c, objectName, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) domain.Object); ok {
+		r0 = rf(c, objectName, size)
+	} else {
+		r0 = ret.Get(0).(domain.Object)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.PictureSize) error); ok {
+		r1 = rf(c, objectName, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCardPhotoURL provides a mock function with given fields: c, objectName, size
+func (_m *CollectionUseCase) GetCardPhotoURL(c context.Context, objectName string, size domain.PictureSize) (domain.PresignedURL, error) {
+	ret := _m.Called(c, objectName, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCardPhotoURL")
+	}
+
+	var r0 domain.PresignedURL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) (domain.PresignedURL, error)); ok {
+		return rf(c, objectName, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) domain.PresignedURL); ok {
+		r0 = rf(c, objectName, size)
+	} else {
+		r0 = ret.Get(0).(domain.PresignedURL)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.PictureSize) error); ok {
+		r1 = rf(c, objectName, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateAttachments provides a mock function with given fields: c
+func (_m *CollectionUseCase) MigrateAttachments(c context.Context) (int, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateAttachments")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PutByID provides a mock function with given fields: c, collectionID, collection
 func (_m *CollectionUseCase) PutByID(c context.Context, collectionID string, collection *domain.Collection) error {
 	ret := _m.Called(c, collectionID, collection)
@@ -214,17 +324,35 @@ func (_m *CollectionUseCase) PutByID(c context.Context, collectionID string, col
 	return r0
 }
 
-// RemoveCardPicture provides a mock function with given fields: c, userID, collectionID, cardID, objectName
-func (_m *CollectionUseCase) RemoveCardPicture(c context.Context, userID string, collectionID string, cardID int, objectName string) error {
-	ret := _m.Called(c, userID, collectionID, cardID, objectName)
+// RemoveCardAttachment provides a mock function with given fields: c, userID, collectionID, cardID, attachmentID
+func (_m *CollectionUseCase) RemoveCardAttachment(c context.Context, userID string, collectionID string, cardID int, attachmentID string) error {
+	ret := _m.Called(c, userID, collectionID, cardID, attachmentID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveCardPicture")
+		panic("no return value specified for RemoveCardAttachment")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, string) error); ok {
-		r0 = rf(c, userID, collectionID, cardID, objectName)
+		r0 = rf(c, userID, collectionID, cardID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveCardAudio provides a mock function with given fields: c, userID, collectionID, cardID, side, objectName
+func (_m *CollectionUseCase) RemoveCardAudio(c context.Context, userID string, collectionID string, cardID int, side domain.CardSide, objectName string) error {
+	ret := _m.Called(c, userID, collectionID, cardID, side, objectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCardAudio")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, domain.CardSide, string) error); ok {
+		r0 = rf(c, userID, collectionID, cardID, side, objectName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -262,6 +390,36 @@ func (_m *CollectionUseCase) RemoveLike(c context.Context, collectionID string,
 	return r0, r1
 }
 
+// ReorderCardAttachments provides a mock function with given fields: c, collectionID, cardID, ids
+func (_m *CollectionUseCase) ReorderCardAttachments(c context.Context, collectionID string, cardID int, ids []string) ([]domain.CardAttachment, error) {
+	ret := _m.Called(c, collectionID, cardID, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderCardAttachments")
+	}
+
+	var r0 []domain.CardAttachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, []string) ([]domain.CardAttachment, error)); ok {
+		return rf(c, collectionID, cardID, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, []string) []domain.CardAttachment); ok {
+		r0 = rf(c, collectionID, cardID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CardAttachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, []string) error); ok {
+		r1 = rf(c, collectionID, cardID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchPublic provides a mock function with given fields: c, text, count, offset, sortBy, category, userID
 func (_m *CollectionUseCase) SearchPublic(c context.Context, text string, count int, offset int, sortBy string, category string, userID string) ([]domain.Collection, error) {
 	ret := _m.Called(c, text, count, offset, sortBy, category, userID)
@@ -340,27 +498,27 @@ func (_m *CollectionUseCase) UpdateCard(c context.Context, collectionID string,
 	return r0
 }
 
-// UploadCardPhoto provides a mock function with given fields: c, userID, collectionID, cardID, picture, size
-func (_m *CollectionUseCase) UploadCardPhoto(c context.Context, userID string, collectionID string, cardID int, picture io.Reader, size int64) (string, error) {
-	ret := _m.Called(c, userID, collectionID, cardID, picture, size)
+// UploadCardAudio provides a mock function with given fields: c, userID, collectionID, cardID, side, audio, size, contentType
+func (_m *CollectionUseCase) UploadCardAudio(c context.Context, userID string, collectionID string, cardID int, side domain.CardSide, audio io.Reader, size int64, contentType string) (string, error) {
+	ret := _m.Called(c, userID, collectionID, cardID, side, audio, size, contentType)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UploadCardPhoto")
+		panic("no return value specified for UploadCardAudio")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, io.Reader, int64) (string, error)); ok {
-		return rf(c, userID, collectionID, cardID, picture, size)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, domain.CardSide, io.Reader, int64, string) (string, error)); ok {
+		return rf(c, userID, collectionID, cardID, side, audio, size, contentType)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, io.Reader, int64) string); ok {
-		r0 = rf(c, userID, collectionID, cardID, picture, size)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, domain.CardSide, io.Reader, int64, string) string); ok {
+		r0 = rf(c, userID, collectionID, cardID, side, audio, size, contentType)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, io.Reader, int64) error); ok {
-		r1 = rf(c, userID, collectionID, cardID, picture, size)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, domain.CardSide, io.Reader, int64, string) error); ok {
+		r1 = rf(c, userID, collectionID, cardID, side, audio, size, contentType)
 	} else {
 		r1 = ret.Error(1)
 	}
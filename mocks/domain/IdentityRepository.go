@@ -0,0 +1,133 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdentityRepository is an autogenerated mock type for the IdentityRepository type
+type IdentityRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: c, identity
+func (_m *IdentityRepository) Create(c context.Context, identity *domain.Identity) (string, error) {
+	ret := _m.Called(c, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Identity) (string, error)); ok {
+		return rf(c, identity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Identity) string); ok {
+		r0 = rf(c, identity)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Identity) error); ok {
+		r1 = rf(c, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByID provides a mock function with given fields: c, identityID
+func (_m *IdentityRepository) DeleteByID(c context.Context, identityID string) error {
+	ret := _m.Called(c, identityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, identityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: c, identityID
+func (_m *IdentityRepository) GetByID(c context.Context, identityID string) (domain.Identity, error) {
+	ret := _m.Called(c, identityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Identity, error)); ok {
+		return rf(c, identityID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Identity); ok {
+		r0 = rf(c, identityID)
+	} else {
+		r0 = ret.Get(0).(domain.Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, identityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: c, userID
+func (_m *IdentityRepository) GetByUserID(c context.Context, userID string) ([]domain.Identity, error) {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []domain.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Identity, error)); ok {
+		return rf(c, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Identity); ok {
+		r0 = rf(c, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIdentityRepository creates a new instance of IdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdentityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdentityRepository {
+	mock := &IdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,105 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "main/domain"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// KeySet is an autogenerated mock type for the KeySet type
+type KeySet struct {
+	mock.Mock
+}
+
+// JWKS provides a mock function with no fields
+func (_m *KeySet) JWKS() domain.JWKS {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 domain.JWKS
+	if rf, ok := ret.Get(0).(func() domain.JWKS); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.JWKS)
+	}
+
+	return r0
+}
+
+// Sign provides a mock function with given fields: claims
+func (_m *KeySet) Sign(claims jwt.Claims) (string, error) {
+	ret := _m.Called(claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(jwt.Claims) (string, error)); ok {
+		return rf(claims)
+	}
+	if rf, ok := ret.Get(0).(func(jwt.Claims) string); ok {
+		r0 = rf(claims)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(jwt.Claims) error); ok {
+		r1 = rf(claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerificationKey provides a mock function with given fields: token
+func (_m *KeySet) VerificationKey(token *jwt.Token) (interface{}, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerificationKey")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*jwt.Token) (interface{}, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(*jwt.Token) interface{}); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*jwt.Token) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewKeySet creates a new instance of KeySet. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKeySet(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KeySet {
+	mock := &KeySet{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
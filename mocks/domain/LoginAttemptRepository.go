@@ -0,0 +1,166 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LoginAttemptRepository is an autogenerated mock type for the LoginAttemptRepository type
+type LoginAttemptRepository struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: c, key
+func (_m *LoginAttemptRepository) Get(c context.Context, key string) (domain.LoginAttempt, error) {
+	ret := _m.Called(c, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 domain.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.LoginAttempt, error)); ok {
+		return rf(c, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.LoginAttempt); ok {
+		r0 = rf(c, key)
+	} else {
+		r0 = ret.Get(0).(domain.LoginAttempt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: c, key, until
+func (_m *LoginAttemptRepository) Lock(c context.Context, key string, until time.Time) error {
+	ret := _m.Called(c, key, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(c, key, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: c, key
+func (_m *LoginAttemptRepository) Release(c context.Context, key string) error {
+	ret := _m.Called(c, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: c, key, failures, at, window
+func (_m *LoginAttemptRepository) Reserve(c context.Context, key string, failures int, at time.Time, window time.Duration) (domain.LoginAttempt, bool, error) {
+	ret := _m.Called(c, key, failures, at, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 domain.LoginAttempt
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time, time.Duration) (domain.LoginAttempt, bool, error)); ok {
+		return rf(c, key, failures, at, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time, time.Duration) domain.LoginAttempt); ok {
+		r0 = rf(c, key, failures, at, window)
+	} else {
+		r0 = ret.Get(0).(domain.LoginAttempt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Time, time.Duration) bool); ok {
+		r1 = rf(c, key, failures, at, window)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, time.Time, time.Duration) error); ok {
+		r2 = rf(c, key, failures, at, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Reset provides a mock function with given fields: c, key
+func (_m *LoginAttemptRepository) Reset(c context.Context, key string) error {
+	ret := _m.Called(c, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Touch provides a mock function with given fields: c, key, at
+func (_m *LoginAttemptRepository) Touch(c context.Context, key string, at time.Time) error {
+	ret := _m.Called(c, key, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(c, key, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginAttemptRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginAttemptRepository {
+	mock := &LoginAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
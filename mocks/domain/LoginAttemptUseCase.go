@@ -0,0 +1,137 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginAttemptUseCase is an autogenerated mock type for the LoginAttemptUseCase type
+type LoginAttemptUseCase struct {
+	mock.Mock
+}
+
+// Fail provides a mock function with given fields: c, audit
+func (_m *LoginAttemptUseCase) Fail(c context.Context, audit *domain.LoginAudit) error {
+	ret := _m.Called(c, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoginAudit) error); ok {
+		r0 = rf(c, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailCode provides a mock function with given fields: c, userID
+func (_m *LoginAttemptUseCase) FailCode(c context.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: c, email, ip
+func (_m *LoginAttemptUseCase) Reserve(c context.Context, email string, ip string) error {
+	ret := _m.Called(c, email, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveCode provides a mock function with given fields: c, userID
+func (_m *LoginAttemptUseCase) ReserveCode(c context.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Succeed provides a mock function with given fields: c, email, ip
+func (_m *LoginAttemptUseCase) Succeed(c context.Context, email string, ip string) error {
+	ret := _m.Called(c, email, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Succeed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SucceedCode provides a mock function with given fields: c, userID
+func (_m *LoginAttemptUseCase) SucceedCode(c context.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SucceedCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLoginAttemptUseCase creates a new instance of LoginAttemptUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginAttemptUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginAttemptUseCase {
+	mock := &LoginAttemptUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
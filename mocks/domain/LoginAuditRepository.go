@@ -0,0 +1,57 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginAuditRepository is an autogenerated mock type for the LoginAuditRepository type
+type LoginAuditRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: c, audit
+func (_m *LoginAuditRepository) Create(c context.Context, audit *domain.LoginAudit) (string, error) {
+	ret := _m.Called(c, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoginAudit) (string, error)); ok {
+		return rf(c, audit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoginAudit) string); ok {
+		r0 = rf(c, audit)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.LoginAudit) error); ok {
+		r1 = rf(c, audit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLoginAuditRepository creates a new instance of LoginAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginAuditRepository {
+	mock := &LoginAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -16,9 +16,9 @@ type LoginUseCase struct {
 	mock.Mock
 }
 
-// CreateAccessToken provides a mock function with given fields: user, secret, expiry
-func (_m *LoginUseCase) CreateAccessToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
-	ret := _m.Called(user, secret, expiry)
+// CreateAccessToken provides a mock function with given fields: user, sessionID, keySet, expiry
+func (_m *LoginUseCase) CreateAccessToken(user *domain.User, sessionID string, keySet domain.KeySet, expiry int) (string, time.Time, error) {
+	ret := _m.Called(user, sessionID, keySet, expiry)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateAccessToken")
@@ -27,23 +27,23 @@ func (_m *LoginUseCase) CreateAccessToken(user *domain.User, secret string, expi
 	var r0 string
 	var r1 time.Time
 	var r2 error
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) (string, time.Time, error)); ok {
-		return rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(*domain.User, string, domain.KeySet, int) (string, time.Time, error)); ok {
+		return rf(user, sessionID, keySet, expiry)
 	}
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(*domain.User, string, domain.KeySet, int) string); ok {
+		r0 = rf(user, sessionID, keySet, expiry)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) time.Time); ok {
-		r1 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(1).(func(*domain.User, string, domain.KeySet, int) time.Time); ok {
+		r1 = rf(user, sessionID, keySet, expiry)
 	} else {
 		r1 = ret.Get(1).(time.Time)
 	}
 
-	if rf, ok := ret.Get(2).(func(*domain.User, string, int) error); ok {
-		r2 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(2).(func(*domain.User, string, domain.KeySet, int) error); ok {
+		r2 = rf(user, sessionID, keySet, expiry)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -51,9 +51,9 @@ func (_m *LoginUseCase) CreateAccessToken(user *domain.User, secret string, expi
 	return r0, r1, r2
 }
 
-// CreateRefreshToken provides a mock function with given fields: user, secret, expiry
-func (_m *LoginUseCase) CreateRefreshToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
-	ret := _m.Called(user, secret, expiry)
+// CreateRefreshToken provides a mock function with given fields: c, user, session, secret, expiry
+func (_m *LoginUseCase) CreateRefreshToken(c context.Context, user *domain.User, session *domain.Session, secret string, expiry int) (string, time.Time, error) {
+	ret := _m.Called(c, user, session, secret, expiry)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateRefreshToken")
@@ -62,23 +62,23 @@ func (_m *LoginUseCase) CreateRefreshToken(user *domain.User, secret string, exp
 	var r0 string
 	var r1 time.Time
 	var r2 error
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) (string, time.Time, error)); ok {
-		return rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, *domain.Session, string, int) (string, time.Time, error)); ok {
+		return rf(c, user, session, secret, expiry)
 	}
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, *domain.Session, string, int) string); ok {
+		r0 = rf(c, user, session, secret, expiry)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) time.Time); ok {
-		r1 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, *domain.Session, string, int) time.Time); ok {
+		r1 = rf(c, user, session, secret, expiry)
 	} else {
 		r1 = ret.Get(1).(time.Time)
 	}
 
-	if rf, ok := ret.Get(2).(func(*domain.User, string, int) error); ok {
-		r2 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(2).(func(context.Context, *domain.User, *domain.Session, string, int) error); ok {
+		r2 = rf(c, user, session, secret, expiry)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MediaRepository is an autogenerated mock type for the MediaRepository type
+type MediaRepository struct {
+	mock.Mock
+}
+
+// Acquire provides a mock function with given fields: c, media, userID
+func (_m *MediaRepository) Acquire(c context.Context, media *domain.Media, userID string) (bool, error) {
+	ret := _m.Called(c, media, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acquire")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Media, string) (bool, error)); ok {
+		return rf(c, media, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Media, string) bool); ok {
+		r0 = rf(c, media, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Media, string) error); ok {
+		r1 = rf(c, media, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: c, mediaID
+func (_m *MediaRepository) GetByID(c context.Context, mediaID string) (domain.Media, error) {
+	ret := _m.Called(c, mediaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Media
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Media, error)); ok {
+		return rf(c, mediaID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Media); ok {
+		r0 = rf(c, mediaID)
+	} else {
+		r0 = ret.Get(0).(domain.Media)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, mediaID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: c, mediaID
+func (_m *MediaRepository) Purge(c context.Context, mediaID string) error {
+	ret := _m.Called(c, mediaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, mediaID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: c, mediaID, userID
+func (_m *MediaRepository) Release(c context.Context, mediaID string, userID string) (domain.MediaRelease, error) {
+	ret := _m.Called(c, mediaID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 domain.MediaRelease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.MediaRelease, error)); ok {
+		return rf(c, mediaID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.MediaRelease); ok {
+		r0 = rf(c, mediaID, userID)
+	} else {
+		r0 = ret.Get(0).(domain.MediaRelease)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, mediaID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMediaRepository creates a new instance of MediaRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMediaRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MediaRepository {
+	mock := &MediaRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,115 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	database "main/database"
+
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OAuthStateRepository is an autogenerated mock type for the OAuthStateRepository type
+type OAuthStateRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: c, state
+func (_m *OAuthStateRepository) Create(c context.Context, state *domain.OAuthState) (string, error) {
+	ret := _m.Called(c, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OAuthState) (string, error)); ok {
+		return rf(c, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OAuthState) string); ok {
+		r0 = rf(c, state)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.OAuthState) error); ok {
+		r1 = rf(c, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: c, stateID
+func (_m *OAuthStateRepository) GetByID(c context.Context, stateID string) (domain.OAuthState, error) {
+	ret := _m.Called(c, stateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.OAuthState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.OAuthState, error)); ok {
+		return rf(c, stateID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.OAuthState); ok {
+		r0 = rf(c, stateID)
+	} else {
+		r0 = ret.Get(0).(domain.OAuthState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, stateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: c, filter, update
+func (_m *OAuthStateRepository) Update(c context.Context, filter interface{}, update interface{}) (database.UpdateResult, error) {
+	ret := _m.Called(c, filter, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 database.UpdateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}) (database.UpdateResult, error)); ok {
+		return rf(c, filter, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}) database.UpdateResult); ok {
+		r0 = rf(c, filter, update)
+	} else {
+		r0 = ret.Get(0).(database.UpdateResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, interface{}) error); ok {
+		r1 = rf(c, filter, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOAuthStateRepository creates a new instance of OAuthStateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOAuthStateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OAuthStateRepository {
+	mock := &OAuthStateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
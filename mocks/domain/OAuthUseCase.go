@@ -0,0 +1,135 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	url "net/url"
+)
+
+// OAuthUseCase is an autogenerated mock type for the OAuthUseCase type
+type OAuthUseCase struct {
+	mock.Mock
+}
+
+// Complete provides a mock function with given fields: c, provider, code, state, extra
+func (_m *OAuthUseCase) Complete(c context.Context, provider string, code string, state string, extra url.Values) (domain.User, error) {
+	ret := _m.Called(c, provider, code, state, extra)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, url.Values) (domain.User, error)); ok {
+		return rf(c, provider, code, state, extra)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, url.Values) domain.User); ok {
+		r0 = rf(c, provider, code, state, extra)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, url.Values) error); ok {
+		r1 = rf(c, provider, code, state, extra)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdentities provides a mock function with given fields: c, userID
+func (_m *OAuthUseCase) GetIdentities(c context.Context, userID string) ([]domain.Identity, error) {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdentities")
+	}
+
+	var r0 []domain.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Identity, error)); ok {
+		return rf(c, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Identity); ok {
+		r0 = rf(c, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Start provides a mock function with given fields: c, provider, linkUserID, expiry
+func (_m *OAuthUseCase) Start(c context.Context, provider string, linkUserID string, expiry int) (domain.OAuthStartResponse, error) {
+	ret := _m.Called(c, provider, linkUserID, expiry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 domain.OAuthStartResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (domain.OAuthStartResponse, error)); ok {
+		return rf(c, provider, linkUserID, expiry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) domain.OAuthStartResponse); ok {
+		r0 = rf(c, provider, linkUserID, expiry)
+	} else {
+		r0 = ret.Get(0).(domain.OAuthStartResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(c, provider, linkUserID, expiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unlink provides a mock function with given fields: c, userID, provider
+func (_m *OAuthUseCase) Unlink(c context.Context, userID string, provider string) error {
+	ret := _m.Called(c, userID, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, userID, provider)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOAuthUseCase creates a new instance of OAuthUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOAuthUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OAuthUseCase {
+	mock := &OAuthUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
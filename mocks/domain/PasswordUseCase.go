@@ -0,0 +1,111 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordUseCase is an autogenerated mock type for the PasswordUseCase type
+type PasswordUseCase struct {
+	mock.Mock
+}
+
+// GetUserByID provides a mock function with given fields: c, userID
+func (_m *PasswordUseCase) GetUserByID(c context.Context, userID string) (domain.User, error) {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return rf(c, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestReset provides a mock function with given fields: c, email, expiry
+func (_m *PasswordUseCase) RequestReset(c context.Context, email string, expiry int) error {
+	ret := _m.Called(c, email, expiry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(c, email, expiry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reset provides a mock function with given fields: c, token, hashedPassword
+func (_m *PasswordUseCase) Reset(c context.Context, token string, hashedPassword string) error {
+	ret := _m.Called(c, token, hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, token, hashedPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: c, userID, hashedPassword
+func (_m *PasswordUseCase) UpdatePassword(c context.Context, userID string, hashedPassword string) error {
+	ret := _m.Called(c, userID, hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, userID, hashedPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPasswordUseCase creates a new instance of PasswordUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPasswordUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PasswordUseCase {
+	mock := &PasswordUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -16,9 +16,9 @@ type RefreshTokenUseCase struct {
 	mock.Mock
 }
 
-// CreateAccessToken provides a mock function with given fields: user, secret, expiry
-func (_m *RefreshTokenUseCase) CreateAccessToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
-	ret := _m.Called(user, secret, expiry)
+// CreateAccessToken provides a mock function with given fields: user, sessionID, keySet, expiry
+func (_m *RefreshTokenUseCase) CreateAccessToken(user *domain.User, sessionID string, keySet domain.KeySet, expiry int) (string, time.Time, error) {
+	ret := _m.Called(user, sessionID, keySet, expiry)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateAccessToken")
@@ -27,23 +27,23 @@ func (_m *RefreshTokenUseCase) CreateAccessToken(user *domain.User, secret strin
 	var r0 string
 	var r1 time.Time
 	var r2 error
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) (string, time.Time, error)); ok {
-		return rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(*domain.User, string, domain.KeySet, int) (string, time.Time, error)); ok {
+		return rf(user, sessionID, keySet, expiry)
 	}
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(*domain.User, string, domain.KeySet, int) string); ok {
+		r0 = rf(user, sessionID, keySet, expiry)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) time.Time); ok {
-		r1 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(1).(func(*domain.User, string, domain.KeySet, int) time.Time); ok {
+		r1 = rf(user, sessionID, keySet, expiry)
 	} else {
 		r1 = ret.Get(1).(time.Time)
 	}
 
-	if rf, ok := ret.Get(2).(func(*domain.User, string, int) error); ok {
-		r2 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(2).(func(*domain.User, string, domain.KeySet, int) error); ok {
+		r2 = rf(user, sessionID, keySet, expiry)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -51,58 +51,53 @@ func (_m *RefreshTokenUseCase) CreateAccessToken(user *domain.User, secret strin
 	return r0, r1, r2
 }
 
-// CreateRefreshToken provides a mock function with given fields: user, secret, expiry
-func (_m *RefreshTokenUseCase) CreateRefreshToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
-	ret := _m.Called(user, secret, expiry)
+// GetUserByID provides a mock function with given fields: c, id
+func (_m *RefreshTokenUseCase) GetUserByID(c context.Context, id string) (domain.User, error) {
+	ret := _m.Called(c, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateRefreshToken")
+		panic("no return value specified for GetUserByID")
 	}
 
-	var r0 string
-	var r1 time.Time
-	var r2 error
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) (string, time.Time, error)); ok {
-		return rf(user, secret, expiry)
-	}
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
-	} else {
-		r0 = ret.Get(0).(string)
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return rf(c, id)
 	}
-
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) time.Time); ok {
-		r1 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = rf(c, id)
 	} else {
-		r1 = ret.Get(1).(time.Time)
+		r0 = ret.Get(0).(domain.User)
 	}
 
-	if rf, ok := ret.Get(2).(func(*domain.User, string, int) error); ok {
-		r2 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, id)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// ExtractIDFromToken provides a mock function with given fields: requestToken, secret
-func (_m *RefreshTokenUseCase) ExtractIDFromToken(requestToken string, secret string) (string, error) {
+// ParseRefreshToken provides a mock function with given fields: requestToken, secret
+func (_m *RefreshTokenUseCase) ParseRefreshToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
 	ret := _m.Called(requestToken, secret)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ExtractIDFromToken")
+		panic("no return value specified for ParseRefreshToken")
 	}
 
-	var r0 string
+	var r0 *domain.JwtCustomRefreshClaims
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string, string) (*domain.JwtCustomRefreshClaims, error)); ok {
 		return rf(requestToken, secret)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+	if rf, ok := ret.Get(0).(func(string, string) *domain.JwtCustomRefreshClaims); ok {
 		r0 = rf(requestToken, secret)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.JwtCustomRefreshClaims)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
@@ -114,32 +109,39 @@ func (_m *RefreshTokenUseCase) ExtractIDFromToken(requestToken string, secret st
 	return r0, r1
 }
 
-// GetUserByID provides a mock function with given fields: c, id
-func (_m *RefreshTokenUseCase) GetUserByID(c context.Context, id string) (domain.User, error) {
-	ret := _m.Called(c, id)
+// RotateRefreshToken provides a mock function with given fields: c, user, claims, secret, expiry
+func (_m *RefreshTokenUseCase) RotateRefreshToken(c context.Context, user *domain.User, claims *domain.JwtCustomRefreshClaims, secret string, expiry int) (string, time.Time, error) {
+	ret := _m.Called(c, user, claims, secret, expiry)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByID")
+		panic("no return value specified for RotateRefreshToken")
 	}
 
-	var r0 domain.User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
-		return rf(c, id)
+	var r0 string
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, *domain.JwtCustomRefreshClaims, string, int) (string, time.Time, error)); ok {
+		return rf(c, user, claims, secret, expiry)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
-		r0 = rf(c, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, *domain.JwtCustomRefreshClaims, string, int) string); ok {
+		r0 = rf(c, user, claims, secret, expiry)
 	} else {
-		r0 = ret.Get(0).(domain.User)
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(c, id)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, *domain.JwtCustomRefreshClaims, string, int) time.Time); ok {
+		r1 = rf(c, user, claims, secret, expiry)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(time.Time)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, *domain.User, *domain.JwtCustomRefreshClaims, string, int) error); ok {
+		r2 = rf(c, user, claims, secret, expiry)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // NewRefreshTokenUseCase creates a new instance of RefreshTokenUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -0,0 +1,181 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	database "main/database"
+
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionRepository is an autogenerated mock type for the SessionRepository type
+type SessionRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: c, session
+func (_m *SessionRepository) Create(c context.Context, session *domain.Session) (string, error) {
+	ret := _m.Called(c, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Session) (string, error)); ok {
+		return rf(c, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Session) string); ok {
+		r0 = rf(c, session)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Session) error); ok {
+		r1 = rf(c, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveByUserID provides a mock function with given fields: c, userID, now
+func (_m *SessionRepository) GetActiveByUserID(c context.Context, userID string, now int64) ([]domain.Session, error) {
+	ret := _m.Called(c, userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveByUserID")
+	}
+
+	var r0 []domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]domain.Session, error)); ok {
+		return rf(c, userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []domain.Session); ok {
+		r0 = rf(c, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(c, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: c, sessionID
+func (_m *SessionRepository) GetByID(c context.Context, sessionID string) (domain.Session, error) {
+	ret := _m.Called(c, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Session, error)); ok {
+		return rf(c, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Session); ok {
+		r0 = rf(c, sessionID)
+	} else {
+		r0 = ret.Get(0).(domain.Session)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeByID provides a mock function with given fields: c, sessionID
+func (_m *SessionRepository) RevokeByID(c context.Context, sessionID string) error {
+	ret := _m.Called(c, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeByUserID provides a mock function with given fields: c, userID, keep
+func (_m *SessionRepository) RevokeByUserID(c context.Context, userID string, keep string) error {
+	ret := _m.Called(c, userID, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, userID, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: c, filter, update
+func (_m *SessionRepository) Update(c context.Context, filter interface{}, update interface{}) (database.UpdateResult, error) {
+	ret := _m.Called(c, filter, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 database.UpdateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}) (database.UpdateResult, error)); ok {
+		return rf(c, filter, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}) database.UpdateResult); ok {
+		r0 = rf(c, filter, update)
+	} else {
+		r0 = ret.Get(0).(database.UpdateResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, interface{}) error); ok {
+		r1 = rf(c, filter, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSessionRepository creates a new instance of SessionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionRepository {
+	mock := &SessionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
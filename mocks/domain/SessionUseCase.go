@@ -0,0 +1,171 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionUseCase is an autogenerated mock type for the SessionUseCase type
+type SessionUseCase struct {
+	mock.Mock
+}
+
+// GetActiveByUserID provides a mock function with given fields: c, userID
+func (_m *SessionUseCase) GetActiveByUserID(c context.Context, userID string) ([]domain.Session, error) {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveByUserID")
+	}
+
+	var r0 []domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Session, error)); ok {
+		return rf(c, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Session); ok {
+		r0 = rf(c, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsActive provides a mock function with given fields: c, sessionID
+func (_m *SessionUseCase) IsActive(c context.Context, sessionID string) (bool, error) {
+	ret := _m.Called(c, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsActive")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(c, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(c, sessionID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseRefreshToken provides a mock function with given fields: requestToken, secret
+func (_m *SessionUseCase) ParseRefreshToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	ret := _m.Called(requestToken, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseRefreshToken")
+	}
+
+	var r0 *domain.JwtCustomRefreshClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*domain.JwtCustomRefreshClaims, error)); ok {
+		return rf(requestToken, secret)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *domain.JwtCustomRefreshClaims); ok {
+		r0 = rf(requestToken, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.JwtCustomRefreshClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(requestToken, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: c, userID, sessionID
+func (_m *SessionUseCase) Revoke(c context.Context, userID string, sessionID string) error {
+	ret := _m.Called(c, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAll provides a mock function with given fields: c, userID
+func (_m *SessionUseCase) RevokeAll(c context.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeOthers provides a mock function with given fields: c, userID, sessionID
+func (_m *SessionUseCase) RevokeOthers(c context.Context, userID string, sessionID string) error {
+	ret := _m.Called(c, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOthers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSessionUseCase creates a new instance of SessionUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionUseCase {
+	mock := &SessionUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorUseCase is an autogenerated mock type for the TwoFactorUseCase type
+type TwoFactorUseCase struct {
+	mock.Mock
+}
+
+// Confirm provides a mock function with given fields: c, userID, code
+func (_m *TwoFactorUseCase) Confirm(c context.Context, userID string, code string) ([]string, error) {
+	ret := _m.Called(c, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Confirm")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(c, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(c, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateChallenge provides a mock function with given fields: c, user, expiry
+func (_m *TwoFactorUseCase) CreateChallenge(c context.Context, user *domain.User, expiry int) (string, error) {
+	ret := _m.Called(c, user, expiry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChallenge")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, int) (string, error)); ok {
+		return rf(c, user, expiry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, int) string); ok {
+		r0 = rf(c, user, expiry)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, int) error); ok {
+		r1 = rf(c, user, expiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Disable provides a mock function with given fields: c, userID, code
+func (_m *TwoFactorUseCase) Disable(c context.Context, userID string, code string) error {
+	ret := _m.Called(c, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enroll provides a mock function with given fields: c, userID, issuer
+func (_m *TwoFactorUseCase) Enroll(c context.Context, userID string, issuer string) (domain.TOTPEnrollResponse, error) {
+	ret := _m.Called(c, userID, issuer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enroll")
+	}
+
+	var r0 domain.TOTPEnrollResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.TOTPEnrollResponse, error)); ok {
+		return rf(c, userID, issuer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.TOTPEnrollResponse); ok {
+		r0 = rf(c, userID, issuer)
+	} else {
+		r0 = ret.Get(0).(domain.TOTPEnrollResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, userID, issuer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyChallenge provides a mock function with given fields: c, token, code
+func (_m *TwoFactorUseCase) VerifyChallenge(c context.Context, token string, code string) (domain.User, error) {
+	ret := _m.Called(c, token, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyChallenge")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.User, error)); ok {
+		return rf(c, token, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.User); ok {
+		r0 = rf(c, token, code)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, token, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTwoFactorUseCase creates a new instance of TwoFactorUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorUseCase {
+	mock := &TwoFactorUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,219 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UploadRepository is an autogenerated mock type for the UploadRepository type
+type UploadRepository struct {
+	mock.Mock
+}
+
+// Claim provides a mock function with given fields: c, uploadID, now, until
+func (_m *UploadRepository) Claim(c context.Context, uploadID string, now int64, until int64) (bool, error) {
+	ret := _m.Called(c, uploadID, now, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) (bool, error)); ok {
+		return rf(c, uploadID, now, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) bool); ok {
+		r0 = rf(c, uploadID, now, until)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(c, uploadID, now, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: c, upload
+func (_m *UploadRepository) Create(c context.Context, upload *domain.Upload) (string, error) {
+	ret := _m.Called(c, upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Upload) (string, error)); ok {
+		return rf(c, upload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Upload) string); ok {
+		r0 = rf(c, upload)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Upload) error); ok {
+		r1 = rf(c, upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByID provides a mock function with given fields: c, uploadID
+func (_m *UploadRepository) DeleteByID(c context.Context, uploadID string) (bool, error) {
+	ret := _m.Called(c, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(c, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(c, uploadID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: c, uploadID
+func (_m *UploadRepository) GetByID(c context.Context, uploadID string) (domain.Upload, error) {
+	ret := _m.Called(c, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Upload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Upload, error)); ok {
+		return rf(c, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Upload); ok {
+		r0 = rf(c, uploadID)
+	} else {
+		r0 = ret.Get(0).(domain.Upload)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpired provides a mock function with given fields: c, now
+func (_m *UploadRepository) GetExpired(c context.Context, now int64) ([]domain.Upload, error) {
+	ret := _m.Called(c, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpired")
+	}
+
+	var r0 []domain.Upload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.Upload, error)); ok {
+		return rf(c, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.Upload); ok {
+		r0 = rf(c, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Upload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(c, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpiredByUserID provides a mock function with given fields: c, userID, now
+func (_m *UploadRepository) GetExpiredByUserID(c context.Context, userID string, now int64) ([]domain.Upload, error) {
+	ret := _m.Called(c, userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredByUserID")
+	}
+
+	var r0 []domain.Upload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]domain.Upload, error)); ok {
+		return rf(c, userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []domain.Upload); ok {
+		r0 = rf(c, userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Upload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(c, userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unclaim provides a mock function with given fields: c, uploadID
+func (_m *UploadRepository) Unclaim(c context.Context, uploadID string) error {
+	ret := _m.Called(c, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unclaim")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewUploadRepository creates a new instance of UploadRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUploadRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UploadRepository {
+	mock := &UploadRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
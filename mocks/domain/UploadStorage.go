@@ -0,0 +1,162 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UploadStorage is an autogenerated mock type for the UploadStorage type
+type UploadStorage struct {
+	mock.Mock
+}
+
+// GetObject provides a mock function with given fields: c, objectName
+func (_m *UploadStorage) GetObject(c context.Context, objectName string) (domain.Object, error) {
+	ret := _m.Called(c, objectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObject")
+	}
+
+	var r0 domain.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Object, error)); ok {
+		return rf(c, objectName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Object); ok {
+		r0 = rf(c, objectName)
+	} else {
+		r0 = ret.Get(0).(domain.Object)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, objectName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PresignPost provides a mock function with given fields: c, objectName, expires, maxSize
+func (_m *UploadStorage) PresignPost(c context.Context, objectName string, expires time.Duration, maxSize int64) (string, map[string]string, error) {
+	ret := _m.Called(c, objectName, expires, maxSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PresignPost")
+	}
+
+	var r0 string
+	var r1 map[string]string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) (string, map[string]string, error)); ok {
+		return rf(c, objectName, expires, maxSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) string); ok {
+		r0 = rf(c, objectName, expires, maxSize)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, int64) map[string]string); ok {
+		r1 = rf(c, objectName, expires, maxSize)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration, int64) error); ok {
+		r2 = rf(c, objectName, expires, maxSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PutObject provides a mock function with given fields: c, objectName, reader, objectSize, contentType
+func (_m *UploadStorage) PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64, contentType string) error {
+	ret := _m.Called(c, objectName, reader, objectSize, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutObject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64, string) error); ok {
+		r0 = rf(c, objectName, reader, objectSize, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveObject provides a mock function with given fields: c, objectName
+func (_m *UploadStorage) RemoveObject(c context.Context, objectName string) error {
+	ret := _m.Called(c, objectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveObject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, objectName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StatObject provides a mock function with given fields: c, objectName
+func (_m *UploadStorage) StatObject(c context.Context, objectName string) (domain.ObjectInfo, error) {
+	ret := _m.Called(c, objectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatObject")
+	}
+
+	var r0 domain.ObjectInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.ObjectInfo, error)); ok {
+		return rf(c, objectName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.ObjectInfo); ok {
+		r0 = rf(c, objectName)
+	} else {
+		r0 = ret.Get(0).(domain.ObjectInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, objectName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUploadStorage creates a new instance of UploadStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUploadStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UploadStorage {
+	mock := &UploadStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
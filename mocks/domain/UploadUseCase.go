@@ -0,0 +1,123 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "main/domain"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UploadUseCase is an autogenerated mock type for the UploadUseCase type
+type UploadUseCase struct {
+	mock.Mock
+}
+
+// Complete provides a mock function with given fields: c, userID, uploadID, target, store
+func (_m *UploadUseCase) Complete(c context.Context, userID string, uploadID string, target domain.UploadTarget, store func(context.Context, domain.Upload, []byte) error) error {
+	ret := _m.Called(c, userID, uploadID, target, store)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.UploadTarget, func(context.Context, domain.Upload, []byte) error) error); ok {
+		r0 = rf(c, userID, uploadID, target, store)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Put provides a mock function with given fields: c, userID, uploadID, target, file, size
+func (_m *UploadUseCase) Put(c context.Context, userID string, uploadID string, target domain.UploadTarget, file io.Reader, size int64) error {
+	ret := _m.Called(c, userID, uploadID, target, file, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.UploadTarget, io.Reader, int64) error); ok {
+		r0 = rf(c, userID, uploadID, target, file, size)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveExpired provides a mock function with given fields: c
+func (_m *UploadUseCase) RemoveExpired(c context.Context) (int, error) {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpired")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Start provides a mock function with given fields: c, upload
+func (_m *UploadUseCase) Start(c context.Context, upload *domain.Upload) (domain.UploadTicket, error) {
+	ret := _m.Called(c, upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 domain.UploadTicket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Upload) (domain.UploadTicket, error)); ok {
+		return rf(c, upload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Upload) domain.UploadTicket); ok {
+		r0 = rf(c, upload)
+	} else {
+		r0 = ret.Get(0).(domain.UploadTicket)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Upload) error); ok {
+		r1 = rf(c, upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUploadUseCase creates a new instance of UploadUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUploadUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UploadUseCase {
+	mock := &UploadUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	database "main/database"
+
+	domain "main/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserTokenRepository is an autogenerated mock type for the UserTokenRepository type
+type UserTokenRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: c, token
+func (_m *UserTokenRepository) Create(c context.Context, token *domain.UserToken) (string, error) {
+	ret := _m.Called(c, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserToken) (string, error)); ok {
+		return rf(c, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserToken) string); ok {
+		r0 = rf(c, token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.UserToken) error); ok {
+		r1 = rf(c, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: c, tokenID
+func (_m *UserTokenRepository) GetByID(c context.Context, tokenID string) (domain.UserToken, error) {
+	ret := _m.Called(c, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.UserToken, error)); ok {
+		return rf(c, tokenID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.UserToken); ok {
+		r0 = rf(c, tokenID)
+	} else {
+		r0 = ret.Get(0).(domain.UserToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestByUserID provides a mock function with given fields: c, userID, purpose
+func (_m *UserTokenRepository) GetLatestByUserID(c context.Context, userID string, purpose string) (domain.UserToken, error) {
+	ret := _m.Called(c, userID, purpose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestByUserID")
+	}
+
+	var r0 domain.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.UserToken, error)); ok {
+		return rf(c, userID, purpose)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.UserToken); ok {
+		r0 = rf(c, userID, purpose)
+	} else {
+		r0 = ret.Get(0).(domain.UserToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, userID, purpose)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: c, filter, update
+func (_m *UserTokenRepository) Update(c context.Context, filter interface{}, update interface{}) (database.UpdateResult, error) {
+	ret := _m.Called(c, filter, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 database.UpdateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}) (database.UpdateResult, error)); ok {
+		return rf(c, filter, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}) database.UpdateResult); ok {
+		r0 = rf(c, filter, update)
+	} else {
+		r0 = ret.Get(0).(database.UpdateResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, interface{}) error); ok {
+		r1 = rf(c, filter, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUserTokenRepository creates a new instance of UserTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserTokenRepository {
+	mock := &UserTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
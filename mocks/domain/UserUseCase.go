@@ -62,29 +62,55 @@ func (_m *UserUseCase) GetByID(c context.Context, userID string) (domain.User, e
 	return r0, r1
 }
 
-// GetProfilePicture provides a mock function with given fields: c, userID
-func (_m *UserUseCase) GetProfilePicture(c context.Context, userID string) ([]byte, error) {
-	ret := _m.Called(c, userID)
+// GetProfilePicture provides a mock function with given fields: c, userID, size
+func (_m *UserUseCase) GetProfilePicture(c context.Context, userID string, size domain.PictureSize) (domain.Object, error) {
+	ret := _m.Called(c, userID, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetProfilePicture")
 	}
 
-	var r0 []byte
+	var r0 domain.Object
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
-		return rf(c, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) (domain.Object, error)); ok {
+		return rf(c, userID, size)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
-		r0 = rf(c, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) domain.Object); ok {
+		r0 = rf(c, userID, size)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+		r0 = ret.Get(0).(domain.Object)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(c, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.PictureSize) error); ok {
+		r1 = rf(c, userID, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfilePictureURL provides a mock function with given fields: c, userID, size
+func (_m *UserUseCase) GetProfilePictureURL(c context.Context, userID string, size domain.PictureSize) (domain.PresignedURL, error) {
+	ret := _m.Called(c, userID, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfilePictureURL")
+	}
+
+	var r0 domain.PresignedURL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) (domain.PresignedURL, error)); ok {
+		return rf(c, userID, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PictureSize) domain.PresignedURL); ok {
+		r0 = rf(c, userID, size)
+	} else {
+		r0 = ret.Get(0).(domain.PresignedURL)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.PictureSize) error); ok {
+		r1 = rf(c, userID, size)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -93,21 +119,31 @@ func (_m *UserUseCase) GetProfilePicture(c context.Context, userID string) ([]by
 }
 
 // PutByID provides a mock function with given fields: c, userID, user
-func (_m *UserUseCase) PutByID(c context.Context, userID string, user *domain.User) error {
+func (_m *UserUseCase) PutByID(c context.Context, userID string, user *domain.User) (bool, error) {
 	ret := _m.Called(c, userID, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PutByID")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.User) error); ok {
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.User) (bool, error)); ok {
+		return rf(c, userID, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.User) bool); ok {
 		r0 = rf(c, userID, user)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.User) error); ok {
+		r1 = rf(c, userID, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // RemoveProfilePicture provides a mock function with given fields: c, userID
@@ -128,17 +164,17 @@ func (_m *UserUseCase) RemoveProfilePicture(c context.Context, userID string) er
 	return r0
 }
 
-// UploadProfilePicture provides a mock function with given fields: c, userID, picture, size
-func (_m *UserUseCase) UploadProfilePicture(c context.Context, userID string, picture io.Reader, size int64) error {
-	ret := _m.Called(c, userID, picture, size)
+// UploadProfilePicture provides a mock function with given fields: c, userID, picture, size, contentType
+func (_m *UserUseCase) UploadProfilePicture(c context.Context, userID string, picture io.Reader, size int64, contentType string) error {
+	ret := _m.Called(c, userID, picture, size, contentType)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UploadProfilePicture")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64) error); ok {
-		r0 = rf(c, userID, picture, size)
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64, string) error); ok {
+		r0 = rf(c, userID, picture, size, contentType)
 	} else {
 		r0 = ret.Error(0)
 	}
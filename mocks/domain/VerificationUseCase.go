@@ -0,0 +1,82 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VerificationUseCase is an autogenerated mock type for the VerificationUseCase type
+type VerificationUseCase struct {
+	mock.Mock
+}
+
+// ResendVerification provides a mock function with given fields: c, userID, expiry, cooldown
+func (_m *VerificationUseCase) ResendVerification(c context.Context, userID string, expiry int, cooldown int) error {
+	ret := _m.Called(c, userID, expiry, cooldown)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) error); ok {
+		r0 = rf(c, userID, expiry, cooldown)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendVerification provides a mock function with given fields: c, userID, expiry
+func (_m *VerificationUseCase) SendVerification(c context.Context, userID string, expiry int) error {
+	ret := _m.Called(c, userID, expiry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(c, userID, expiry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Verify provides a mock function with given fields: c, token
+func (_m *VerificationUseCase) Verify(c context.Context, token string) error {
+	ret := _m.Called(c, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewVerificationUseCase creates a new instance of VerificationUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVerificationUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VerificationUseCase {
+	mock := &VerificationUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,6 +4,8 @@ package mocks
 
 import (
 	context "context"
+	domain "main/domain"
+
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
@@ -43,24 +45,22 @@ func (_m *Client) BucketExists(ctx context.Context, bucketName string) (bool, er
 }
 
 // GetObject provides a mock function with given fields: ctx, bucketName, objectName
-func (_m *Client) GetObject(ctx context.Context, bucketName string, objectName string) ([]byte, error) {
+func (_m *Client) GetObject(ctx context.Context, bucketName string, objectName string) (domain.Object, error) {
 	ret := _m.Called(ctx, bucketName, objectName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetObject")
 	}
 
-	var r0 []byte
+	var r0 domain.Object
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]byte, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Object, error)); ok {
 		return rf(ctx, bucketName, objectName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Object); ok {
 		r0 = rf(ctx, bucketName, objectName)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+		r0 = ret.Get(0).(domain.Object)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
@@ -90,17 +90,17 @@ func (_m *Client) MakeBucket(ctx context.Context, bucketName string) error {
 	return r0
 }
 
-// PutObject provides a mock function with given fields: ctx, bucketName, objectName, reader, objectSize
-func (_m *Client) PutObject(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64) error {
-	ret := _m.Called(ctx, bucketName, objectName, reader, objectSize)
+// PutObject provides a mock function with given fields: ctx, bucketName, objectName, reader, objectSize, contentType
+func (_m *Client) PutObject(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, contentType string) error {
+	ret := _m.Called(ctx, bucketName, objectName, reader, objectSize, contentType)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PutObject")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader, int64) error); ok {
-		r0 = rf(ctx, bucketName, objectName, reader, objectSize)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader, int64, string) error); ok {
+		r0 = rf(ctx, bucketName, objectName, reader, objectSize, contentType)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -126,6 +126,34 @@ func (_m *Client) RemoveObject(ctx context.Context, bucketName string, objectNam
 	return r0
 }
 
+// StatObject provides a mock function with given fields: ctx, bucketName, objectName
+func (_m *Client) StatObject(ctx context.Context, bucketName string, objectName string) (domain.ObjectInfo, error) {
+	ret := _m.Called(ctx, bucketName, objectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatObject")
+	}
+
+	var r0 domain.ObjectInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.ObjectInfo, error)); ok {
+		return rf(ctx, bucketName, objectName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.ObjectInfo); ok {
+		r0 = rf(ctx, bucketName, objectName)
+	} else {
+		r0 = ret.Get(0).(domain.ObjectInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bucketName, objectName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
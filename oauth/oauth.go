@@ -0,0 +1,26 @@
+package oauth
+
+import (
+	"context"
+	"net/url"
+)
+
+// UserInfo is what we learn about the user from an identity provider.
+type UserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type Provider interface {
+	// AuthCodeURL returns the page the user has to be sent to;
+	// codeChallenge is the S256 PKCE challenge.
+	AuthCodeURL(state string, codeChallenge string) string
+	// Exchange trades the authorization code for the user's info;
+	// extra holds provider specific parameters passed back with the code.
+	Exchange(ctx context.Context, code string, codeVerifier string, extra url.Values) (UserInfo, error)
+}
+
+// Providers maps a provider name used in routes (google, yandex, vk) to its client.
+type Providers map[string]Provider
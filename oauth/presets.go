@@ -0,0 +1,61 @@
+package oauth
+
+// Google is a plain OpenID Connect provider.
+func Google(clientID string, clientSecret string, redirectURL string) Config {
+	return Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:     "https://oauth2.googleapis.com/token",
+		UserInfoURL:  "https://openidconnect.googleapis.com/v1/userinfo",
+		Scopes:       []string{"openid", "email", "profile"},
+		Claims: Claims{
+			Subject:       "sub",
+			Email:         "email",
+			EmailVerified: "email_verified",
+			Name:          "name",
+		},
+	}
+}
+
+// Yandex ID only hands out the mailbox the account is registered with.
+func Yandex(clientID string, clientSecret string, redirectURL string) Config {
+	return Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      "https://oauth.yandex.ru/authorize",
+		TokenURL:     "https://oauth.yandex.ru/token",
+		UserInfoURL:  "https://login.yandex.ru/info?format=json",
+		Scopes:       []string{"login:email", "login:info"},
+		Claims: Claims{
+			Subject: "id",
+			Email:   "default_email",
+			Name:    "real_name",
+		},
+		AuthScheme: "OAuth",
+		TrustEmail: true,
+	}
+}
+
+// VK ID expects the device_id it returned together with the code
+// to be passed back on exchange.
+func VK(clientID string, clientSecret string, redirectURL string) Config {
+	return Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      "https://id.vk.com/authorize",
+		TokenURL:     "https://id.vk.com/oauth2/auth",
+		UserInfoURL:  "https://id.vk.com/oauth2/user_info",
+		Scopes:       []string{"email"},
+		Claims: Claims{
+			Subject: "user.user_id",
+			Email:   "user.email",
+			Name:    "user.first_name",
+		},
+		UserInfoInBody: true,
+		TrustEmail:     true,
+	}
+}
@@ -0,0 +1,202 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	exchangeTimeout  = 10 * time.Second
+	maxResponseBytes = 1 << 20
+)
+
+// Claims are dot separated paths to the user info fields in the userinfo response.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified string
+	Name          string
+}
+
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+	Claims       Claims
+	// AuthScheme is used for the userinfo request, "Bearer" by default.
+	AuthScheme string
+	// UserInfoInBody sends the access token as a form value instead of a header.
+	UserInfoInBody bool
+	// TrustEmail means the provider only hands out confirmed emails,
+	// so there is no email_verified claim to look at.
+	TrustEmail bool
+}
+
+type provider struct {
+	config Config
+	client *http.Client
+}
+
+// NewProvider returns a client for an authorization code flow with PKCE
+// which reads the user from the userinfo endpoint.
+func NewProvider(config Config) Provider {
+	if config.AuthScheme == "" {
+		config.AuthScheme = "Bearer"
+	}
+	return &provider{
+		config: config,
+		client: &http.Client{Timeout: exchangeTimeout},
+	}
+}
+
+func (p *provider) AuthCodeURL(state string, codeChallenge string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.config.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.config.AuthURL + separator + query.Encode()
+}
+
+func (p *provider) Exchange(
+	ctx context.Context,
+	code string,
+	codeVerifier string,
+	extra url.Values,
+) (UserInfo, error) {
+	form := url.Values{}
+	for key, values := range extra {
+		form[key] = values
+	}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("client_secret", p.config.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	err := p.do(ctx, http.MethodPost, p.config.TokenURL, form, "", &token)
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("token exchange: %w", err)
+	}
+	if token.AccessToken == "" {
+		return UserInfo{}, errors.New("token exchange: no access token")
+	}
+
+	var claims map[string]interface{}
+	if p.config.UserInfoInBody {
+		form = url.Values{}
+		form.Set("client_id", p.config.ClientID)
+		form.Set("access_token", token.AccessToken)
+		err = p.do(ctx, http.MethodPost, p.config.UserInfoURL, form, "", &claims)
+	} else {
+		authorization := p.config.AuthScheme + " " + token.AccessToken
+		err = p.do(ctx, http.MethodGet, p.config.UserInfoURL, nil, authorization, &claims)
+	}
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("userinfo: %w", err)
+	}
+
+	info := UserInfo{
+		Subject: claimString(claims, p.config.Claims.Subject),
+		Email:   claimString(claims, p.config.Claims.Email),
+		Name:    claimString(claims, p.config.Claims.Name),
+	}
+	if info.Subject == "" {
+		return UserInfo{}, errors.New("userinfo: no subject")
+	}
+	if p.config.TrustEmail {
+		info.EmailVerified = info.Email != ""
+	} else {
+		verified, _ := claim(claims, p.config.Claims.EmailVerified).(bool)
+		info.EmailVerified = verified
+	}
+	return info, nil
+}
+
+func (p *provider) do(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	form url.Values,
+	authorization string,
+	result interface{},
+) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("provider responded with %s", res.Status)
+	}
+	return json.NewDecoder(io.LimitReader(res.Body, maxResponseBytes)).Decode(result)
+}
+
+func claim(claims map[string]interface{}, path string) interface{} {
+	if path == "" {
+		return nil
+	}
+
+	var value interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+// claimString also accepts numbers, since some providers return numeric user ids.
+func claimString(claims map[string]interface{}, path string) string {
+	switch value := claim(claims, path).(type) {
+	case string:
+		return value
+	case float64:
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"main/domain"
+	"net/http"
+)
+
+// loginResponse is either domain.LoginResponse or domain.MFAChallengeResponse.
+type loginResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	MFARequired  bool   `json:"mfa_required"`
+	MFAToken     string `json:"mfa_token"`
+}
+
+// SignUp creates a user and signs the client in as this user.
+func (c *Client) SignUp(ctx context.Context, request domain.SignupRequest) (domain.SignupResponse, error) {
+	var resp domain.SignupResponse
+	req, err := jsonRequest(http.MethodPost, "/public/signup", request)
+	if err != nil {
+		return resp, err
+	}
+	req.public = true
+
+	err = c.do(ctx, req, &resp)
+	if err != nil {
+		return resp, err
+	}
+	c.SetTokens(Tokens{AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken})
+	return resp, nil
+}
+
+// Login signs the client in. For a user with two-factor authentication the client
+// is not signed in yet: the returned challenge has MFARequired and its MFAToken
+// is passed to LoginMFA with the code.
+func (c *Client) Login(ctx context.Context, request domain.LoginRequest) (domain.MFAChallengeResponse, error) {
+	var challenge domain.MFAChallengeResponse
+	req, err := jsonRequest(http.MethodPost, "/public/login", request)
+	if err != nil {
+		return challenge, err
+	}
+	req.public = true
+
+	var resp loginResponse
+	err = c.do(ctx, req, &resp)
+	if err != nil {
+		return challenge, err
+	}
+	if resp.MFARequired {
+		challenge.MFARequired = true
+		challenge.MFAToken = resp.MFAToken
+		return challenge, nil
+	}
+	c.SetTokens(Tokens{AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken})
+	return challenge, nil
+}
+
+func (c *Client) LoginMFA(ctx context.Context, request domain.LoginMFARequest) error {
+	req, err := jsonRequest(http.MethodPost, "/public/login/mfa", request)
+	if err != nil {
+		return err
+	}
+	req.public = true
+
+	var resp domain.LoginResponse
+	err = c.do(ctx, req, &resp)
+	if err != nil {
+		return err
+	}
+	c.SetTokens(Tokens{AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken})
+	return nil
+}
+
+// Refresh exchanges the refresh token for a new pair of tokens. The client calls it
+// by itself when the access token expires, so it is rarely needed.
+func (c *Client) Refresh(ctx context.Context) (Tokens, error) {
+	tokens := c.Tokens()
+	if tokens.RefreshToken == "" {
+		return tokens, ErrNotSignedIn
+	}
+	req, err := jsonRequest(http.MethodPost, "/public/refreshToken", domain.RefreshTokenRequest{
+		RefreshToken: tokens.RefreshToken,
+	})
+	if err != nil {
+		return tokens, err
+	}
+	req.public = true
+
+	var resp domain.RefreshTokenResponse
+	err = c.do(ctx, req, &resp)
+	if err != nil {
+		return tokens, err
+	}
+	tokens = Tokens{AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken}
+	c.SetTokens(tokens)
+	return tokens, nil
+}
+
+// Logout closes the session of the client and forgets the tokens.
+func (c *Client) Logout(ctx context.Context) error {
+	req, err := jsonRequest(http.MethodPost, "/auth/logout", domain.LogoutRequest{
+		RefreshToken: c.Tokens().RefreshToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+	c.SetTokens(Tokens{})
+	return nil
+}
+
+// LogoutAll closes all the sessions of the user.
+func (c *Client) LogoutAll(ctx context.Context) error {
+	err := c.do(ctx, request{method: http.MethodPost, path: "/auth/logout-all"}, nil)
+	if err != nil {
+		return err
+	}
+	c.SetTokens(Tokens{})
+	return nil
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"main/domain"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AddCard adds the card to the collection, the server assigns its local_id.
+func (c *Client) AddCard(ctx context.Context, collectionID string, card domain.Card) (domain.Card, error) {
+	var created domain.Card
+	req, err := jsonRequest(http.MethodPost, collectionPath(collectionID)+"/card", withOtherAnswers(card))
+	if err != nil {
+		return created, err
+	}
+	err = c.do(ctx, req, &created)
+	return created, err
+}
+
+// UpdateCard changes the question and the answers of the card with card.LocalID.
+func (c *Client) UpdateCard(ctx context.Context, collectionID string, card domain.Card) error {
+	req, err := jsonRequest(http.MethodPut, cardPath(collectionID, card.LocalID), withOtherAnswers(card))
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteCard deletes the card, its attachments and its audio.
+func (c *Client) DeleteCard(ctx context.Context, collectionID string, cardID int) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: cardPath(collectionID, cardID)}, nil)
+}
+
+// UploadCardPicture replaces the first picture of the question side of the card and returns
+// the ID of the new attachment. The server accepts JPEG, PNG, WebP and GIF files
+// up to 5 MB and stores them without the metadata.
+//
+// Deprecated: use AddCardAttachment.
+func (c *Client) UploadCardPicture(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	filename string,
+	picture io.Reader,
+) (string, error) {
+	req, err := imageRequest(http.MethodPut, cardPath(collectionID, cardID)+"/picture", filename, picture)
+	if err != nil {
+		return "", err
+	}
+	var result domain.UploadCardPhotoResult
+	err = c.do(ctx, req, &result)
+	return result.ObjectName, err
+}
+
+func (c *Client) GetCardPicture(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	objectName string,
+) ([]byte, error) {
+	return c.GetCardPictureSize(ctx, collectionID, cardID, objectName, domain.PictureSizeOriginal)
+}
+
+// GetCardPictureSize returns the variant of the picture of the card, see domain.PictureSides.
+func (c *Client) GetCardPictureSize(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	objectName string,
+	size domain.PictureSize,
+) ([]byte, error) {
+	var picture []byte
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   cardPath(collectionID, cardID) + "/picture",
+		query:  url.Values{"object_name": {objectName}, "size": {string(size)}},
+	}, &picture)
+	return picture, err
+}
+
+func (c *Client) RemoveCardPicture(ctx context.Context, collectionID string, cardID int, objectName string) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   cardPath(collectionID, cardID) + "/picture",
+		query:  url.Values{"object_name": {objectName}},
+	}, nil)
+}
+
+// UploadCardAudio replaces the audio of the side of the card. The server accepts MP3, OGG, WAV
+// and M4A files up to 10 MB and 5 minutes and stores them as they are.
+func (c *Client) UploadCardAudio(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	side domain.CardSide,
+	filename string,
+	audio io.Reader,
+) (domain.UploadCardAudioResult, error) {
+	var result domain.UploadCardAudioResult
+	req, err := fileRequest(http.MethodPut, cardPath(collectionID, cardID)+"/audio", "audio", filename, audio)
+	if err != nil {
+		return result, err
+	}
+	req.query = url.Values{"side": {string(side)}}
+	err = c.do(ctx, req, &result)
+	return result, err
+}
+
+func (c *Client) GetCardAudio(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	side domain.CardSide,
+) ([]byte, error) {
+	var audio []byte
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   cardPath(collectionID, cardID) + "/audio",
+		query:  url.Values{"side": {string(side)}},
+	}, &audio)
+	return audio, err
+}
+
+func (c *Client) RemoveCardAudio(ctx context.Context, collectionID string, cardID int, side domain.CardSide) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   cardPath(collectionID, cardID) + "/audio",
+		query:  url.Values{"side": {string(side)}},
+	}, nil)
+}
+
+// AddCardAttachment adds the picture to the side of the card after its other attachments,
+// see UploadCardPicture for the accepted files.
+func (c *Client) AddCardAttachment(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	side domain.CardSide,
+	filename string,
+	picture io.Reader,
+) (domain.CardAttachment, error) {
+	var attachment domain.CardAttachment
+	req, err := imageRequest(http.MethodPost, cardPath(collectionID, cardID)+"/attachment", filename, picture)
+	if err != nil {
+		return attachment, err
+	}
+	req.query = url.Values{"side": {string(side)}}
+	err = c.do(ctx, req, &attachment)
+	return attachment, err
+}
+
+// GetCardAttachment returns the variant of the attachment of the card, see domain.PictureSides.
+func (c *Client) GetCardAttachment(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	attachmentID string,
+	size domain.PictureSize,
+) ([]byte, error) {
+	var picture []byte
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   cardPath(collectionID, cardID) + "/attachment/" + url.PathEscape(attachmentID),
+		query:  url.Values{"size": {string(size)}},
+	}, &picture)
+	return picture, err
+}
+
+// GetCardAttachmentURL returns the short-lived URL the variant of the attachment is got from the storage by.
+// If the URL is a proxy one, it is the path of GetCardAttachment.
+func (c *Client) GetCardAttachmentURL(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	attachmentID string,
+	size domain.PictureSize,
+) (domain.PresignedURL, error) {
+	var presigned domain.PresignedURL
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   cardPath(collectionID, cardID) + "/attachment/" + url.PathEscape(attachmentID) + "/url",
+		query:  url.Values{"size": {string(size)}},
+	}, &presigned)
+	return presigned, err
+}
+
+// ReorderCardAttachments sets the order of the attachments of the card,
+// ids are the IDs of all its attachments in the new order.
+func (c *Client) ReorderCardAttachments(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	ids []string,
+) ([]domain.CardAttachment, error) {
+	var attachments []domain.CardAttachment
+	req, err := jsonRequest(http.MethodPut, cardPath(collectionID, cardID)+"/attachment/order",
+		domain.ReorderAttachmentsRequest{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	err = c.do(ctx, req, &attachments)
+	return attachments, err
+}
+
+func (c *Client) RemoveCardAttachment(ctx context.Context, collectionID string, cardID int, attachmentID string) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   cardPath(collectionID, cardID) + "/attachment/" + url.PathEscape(attachmentID),
+	}, nil)
+}
+
+func cardPath(collectionID string, cardID int) string {
+	return collectionPath(collectionID) + "/card/" + strconv.Itoa(cardID)
+}
+
+// withOtherAnswers fills the count of the other answers, the server requires it.
+func withOtherAnswers(card domain.Card) domain.Card {
+	if card.OtherAnswers.Items == nil {
+		card.OtherAnswers.Items = make([]string, 0)
+	}
+	card.OtherAnswers.Count = len(card.OtherAnswers.Items)
+	return card
+}
+
+// imageRequest is a multipart form with the picture in the "image" field.
+func imageRequest(method string, path string, filename string, picture io.Reader) (request, error) {
+	return fileRequest(method, path, "image", filename, picture)
+}
+
+// fileRequest is a multipart form with the file in field.
+func fileRequest(method string, path string, field string, filename string, file io.Reader) (request, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		return request{}, err
+	}
+	_, err = io.Copy(part, file)
+	if err != nil {
+		return request{}, err
+	}
+	err = mw.Close()
+	if err != nil {
+		return request{}, err
+	}
+	return request{method: method, path: path, body: body.Bytes(), contentType: mw.FormDataContentType()}, nil
+}
@@ -0,0 +1,296 @@
+// Package client is a Go client of the T-Prep API (/v1). It keeps the tokens
+// of the signed in user and refreshes the access token when it is about to
+// expire or is rejected by the server.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"main/domain"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	apiVersion = "/v1"
+
+	defaultTimeout       = 30 * time.Second
+	defaultRefreshBefore = time.Minute
+)
+
+// ErrNotSignedIn is returned by the methods that need a user when the client has no tokens.
+var ErrNotSignedIn = errors.New("client: not signed in")
+
+// Tokens are the tokens of the signed in user.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+type Client struct {
+	baseURL       string
+	httpClient    *http.Client
+	language      string
+	refreshBefore time.Duration
+	onTokens      func(Tokens)
+
+	mu     sync.Mutex
+	tokens Tokens
+	// refreshing makes the concurrent requests wait for a single refresh
+	refreshing sync.Mutex
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTokens restores the tokens of a previous run.
+func WithTokens(tokens Tokens) Option {
+	return func(c *Client) {
+		c.tokens = tokens
+	}
+}
+
+// WithLanguage sets Accept-Language, the messages of the errors are in this language.
+func WithLanguage(language string) Option {
+	return func(c *Client) {
+		c.language = language
+	}
+}
+
+// WithRefreshBefore sets how long before the expiry the access token is refreshed.
+func WithRefreshBefore(d time.Duration) Option {
+	return func(c *Client) {
+		c.refreshBefore = d
+	}
+}
+
+// WithTokensHandler sets a function that is called with the new tokens after
+// every sign in and refresh, e.g. to save them.
+func WithTokensHandler(handler func(Tokens)) Option {
+	return func(c *Client) {
+		c.onTokens = handler
+	}
+}
+
+// New creates a client of the server at baseURL, e.g. "https://t-prep.ru",
+// the version prefix of the API is added by the client.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:       strings.TrimSuffix(baseURL, "/") + apiVersion,
+		httpClient:    &http.Client{Timeout: defaultTimeout},
+		refreshBefore: defaultRefreshBefore,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) Tokens() Tokens {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens
+}
+
+func (c *Client) SetTokens(tokens Tokens) {
+	c.mu.Lock()
+	c.tokens = tokens
+	c.mu.Unlock()
+
+	if c.onTokens != nil && tokens.AccessToken != "" {
+		c.onTokens(tokens)
+	}
+}
+
+// APIError is an error response of the server. errors.Is matches it
+// with the domain error of the same code, e.g. domain.ErrCollectionNotFound.
+type APIError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	domain.ErrorResponse
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*domain.Error)
+	return ok && t.Code == e.Code
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+	// public requests are sent without the access token
+	public bool
+}
+
+func jsonRequest(method string, path string, body interface{}) (request, error) {
+	req := request{method: method, path: path}
+	if body == nil {
+		return req, nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return req, err
+	}
+	req.body = data
+	req.contentType = "application/json"
+	return req, nil
+}
+
+// do sends the request and decodes the response into out: *[]byte gets the body as is,
+// anything else is decoded from JSON. A request rejected for an invalid access token
+// is sent once more after the refresh.
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	var accessToken string
+	if !req.public {
+		var err error
+		accessToken, err = c.accessToken(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.send(ctx, req, accessToken)
+	if err != nil {
+		return err
+	}
+	if !req.public && resp.err != nil && errors.Is(resp.err, domain.ErrInvalidToken) {
+		err = c.refresh(ctx, accessToken)
+		if err != nil {
+			return err
+		}
+		resp, err = c.send(ctx, req, c.Tokens().AccessToken)
+		if err != nil {
+			return err
+		}
+	}
+	return resp.decode(out)
+}
+
+type response struct {
+	body []byte
+	err  *APIError
+}
+
+func (r *response) decode(out interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	switch o := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*o = r.body
+		return nil
+	}
+	return json.Unmarshal(r.body, out)
+}
+
+func (c *Client) send(ctx context.Context, req request, accessToken string) (*response, error) {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, bytes.NewReader(req.body))
+	if err != nil {
+		return nil, err
+	}
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if c.language != "" {
+		httpReq.Header.Set("Accept-Language", c.language)
+	}
+	if accessToken != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &response{body: body}
+	if httpResp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+
+	resp.err = &APIError{StatusCode: httpResp.StatusCode}
+	if json.Unmarshal(body, &resp.err.ErrorResponse) != nil || resp.err.Code == "" {
+		resp.err.Code = "unknown"
+		resp.err.Message = strings.TrimSpace(string(body))
+	}
+	if seconds, err := strconv.Atoi(httpResp.Header.Get("Retry-After")); err == nil {
+		resp.err.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return resp, nil
+}
+
+// accessToken returns the access token, refreshing it if it is about to expire.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	tokens := c.Tokens()
+	if tokens.AccessToken == "" {
+		return "", ErrNotSignedIn
+	}
+	if tokens.RefreshToken == "" || !c.expiresSoon(tokens.AccessToken) {
+		return tokens.AccessToken, nil
+	}
+
+	err := c.refresh(ctx, tokens.AccessToken)
+	if err != nil {
+		return "", err
+	}
+	return c.Tokens().AccessToken, nil
+}
+
+func (c *Client) expiresSoon(accessToken string) bool {
+	var claims jwt.RegisteredClaims
+	// the client can't verify the signature, the token is checked by the server
+	_, _, err := jwt.NewParser().ParseUnverified(accessToken, &claims)
+	if err != nil || claims.ExpiresAt == nil {
+		return false
+	}
+	return time.Until(claims.ExpiresAt.Time) < c.refreshBefore
+}
+
+// refresh replaces the expired access token, unless another request has already done it.
+func (c *Client) refresh(ctx context.Context, expired string) error {
+	c.refreshing.Lock()
+	defer c.refreshing.Unlock()
+
+	tokens := c.Tokens()
+	if tokens.AccessToken != expired {
+		return nil
+	}
+	if tokens.RefreshToken == "" {
+		return ErrNotSignedIn
+	}
+	_, err := c.Refresh(ctx)
+	return err
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"main/domain"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchParams are the parameters of SearchCollections, the zero values
+// are the defaults of the server.
+type SearchParams struct {
+	Name   string
+	Count  int
+	Offset int
+	// SortBy is "likes" or "trainings"
+	SortBy string
+	// Category is "favourite" to search in the favourite collections
+	Category string
+}
+
+const defaultSearchCount = 10
+
+type likesResponse struct {
+	Likes int `json:"likes"`
+}
+
+// CreateCollection creates a collection of the user, only the name and is_public are used.
+func (c *Client) CreateCollection(ctx context.Context, collection domain.Collection) (domain.CollectionInfo, error) {
+	var info domain.CollectionInfo
+	req, err := jsonRequest(http.MethodPost, "/collection", collection)
+	if err != nil {
+		return info, err
+	}
+	err = c.do(ctx, req, &info)
+	return info, err
+}
+
+func (c *Client) GetCollection(ctx context.Context, collectionID string) (domain.CollectionInfo, error) {
+	var info domain.CollectionInfo
+	err := c.do(ctx, request{method: http.MethodGet, path: collectionPath(collectionID)}, &info)
+	return info, err
+}
+
+// UpdateCollection changes the name and is_public of the collection.
+func (c *Client) UpdateCollection(ctx context.Context, collectionID string, collection domain.Collection) error {
+	req, err := jsonRequest(http.MethodPut, collectionPath(collectionID), collection)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, nil)
+}
+
+func (c *Client) DeleteCollection(ctx context.Context, collectionID string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: collectionPath(collectionID)}, nil)
+}
+
+// SearchCollections searches the public collections, or the favourite ones of the user.
+// Nothing found is an empty result, not an error.
+func (c *Client) SearchCollections(ctx context.Context, params SearchParams) (domain.CollectionPreviewArray, error) {
+	count := params.Count
+	if count == 0 {
+		count = defaultSearchCount
+	}
+	query := url.Values{}
+	query.Set("count", strconv.Itoa(count))
+	query.Set("offset", strconv.Itoa(params.Offset))
+	if params.Name != "" {
+		query.Set("name", params.Name)
+	}
+	if params.SortBy != "" {
+		query.Set("sort_by", params.SortBy)
+	}
+	if params.Category != "" {
+		query.Set("category", params.Category)
+	}
+
+	result := domain.CollectionPreviewArray{Items: make([]domain.CollectionPreview, 0)}
+	err := c.do(ctx, request{method: http.MethodGet, path: "/collection/search", query: query}, &result)
+	if errors.Is(err, domain.ErrNothingFound) {
+		return domain.CollectionPreviewArray{Items: make([]domain.CollectionPreview, 0)}, nil
+	}
+	return result, err
+}
+
+// Like adds the collection to the favourites of the user and returns its likes.
+func (c *Client) Like(ctx context.Context, collectionID string) (int, error) {
+	var resp likesResponse
+	err := c.do(ctx, request{method: http.MethodPut, path: collectionPath(collectionID) + "/like"}, &resp)
+	return resp.Likes, err
+}
+
+// Unlike removes the collection from the favourites of the user and returns its likes.
+func (c *Client) Unlike(ctx context.Context, collectionID string) (int, error) {
+	var resp likesResponse
+	err := c.do(ctx, request{method: http.MethodPut, path: collectionPath(collectionID) + "/unlike"}, &resp)
+	return resp.Likes, err
+}
+
+func collectionPath(collectionID string) string {
+	return "/collection/" + url.PathEscape(collectionID)
+}
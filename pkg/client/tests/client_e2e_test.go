@@ -0,0 +1,404 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"main/api/route"
+	"main/bootstrap"
+	"main/database"
+	"main/domain"
+	"main/internal"
+	"main/mail"
+	"main/oauth"
+	"main/pkg/client"
+	"main/repository"
+	"main/storage"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contractPath = "../../../api/contract.yaml"
+
+const testPassword = "qwerty123"
+
+// testJPEG is a small picture, the server stores it re-encoded without the metadata
+var testJPEG = func() []byte {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 8, 8)), nil)
+	if err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}()
+
+// newTestServer runs the server with the in-memory database and storage,
+// the responses are validated against the contract.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	db := database.NewMemoryClient()
+	repository.SetClient(db)
+
+	s := storage.NewMemoryClient()
+	for _, bucket := range []string{domain.UserBucket, domain.CollectionBucket, domain.UploadBucket} {
+		require.NoError(t, s.MakeBucket(context.Background(), bucket))
+	}
+
+	keySet, err := internal.NewKeySet(internal.KeySetConfig{LegacySecret: "access-secret"})
+	require.NoError(t, err)
+	contract, err := internal.LoadContract(contractPath)
+	require.NoError(t, err)
+
+	env := &bootstrap.Env{
+		AccessTokenExpiryHour:       2,
+		RefreshTokenSecret:          "refresh-secret",
+		RefreshTokenExpiryHour:      168,
+		VerificationTokenExpiryHour: 24,
+		LoginFreeAttempts:           3,
+		LoginIPFreeAttempts:         20,
+		LoginBackoffBaseSecond:      1,
+		LoginBackoffMaxSecond:       60,
+		LoginLockoutThreshold:       10,
+		LoginLockoutMinute:          15,
+		LoginAttemptWindowMinute:    60,
+		ContractValidateResponses:   true,
+	}
+
+	r := chi.NewRouter()
+	route.Setup(env, 5*time.Second, db.Database("tprep"), s, mail.NewLogSender(), oauth.Providers{}, keySet, contract, r)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func signUp(t *testing.T, c *client.Client, name string) domain.SignupResponse {
+	t.Helper()
+	resp, err := c.SignUp(context.Background(), domain.SignupRequest{
+		Username: name,
+		Email:    name + "@t-prep.test",
+		Password: testPassword,
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestClient_Collections(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+	c := client.New(server.URL)
+	signup := signUp(t, c, "author")
+
+	me, err := c.GetMe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, signup.UserID, me.ID)
+	assert.Equal(t, "author", me.Username)
+
+	coll, err := c.CreateCollection(ctx, domain.Collection{Name: "Capitals of Europe", IsPublic: true})
+	require.NoError(t, err)
+	assert.Equal(t, signup.UserID, coll.Author)
+
+	card, err := c.AddCard(ctx, coll.ID, domain.Card{
+		Question:     "Capital of France",
+		Answer:       "Paris",
+		OtherAnswers: domain.OtherAnswers{Items: []string{"Lyon", "Nice"}},
+	})
+	require.NoError(t, err)
+	second, err := c.AddCard(ctx, coll.ID, domain.Card{Question: "Capital of Italy", Answer: "Rome"})
+	require.NoError(t, err)
+	assert.NotEqual(t, card.LocalID, second.LocalID)
+
+	card.Answer = "Paris, France"
+	require.NoError(t, c.UpdateCard(ctx, coll.ID, card))
+	require.NoError(t, c.DeleteCard(ctx, coll.ID, second.LocalID))
+
+	got, err := c.GetCollection(ctx, coll.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Cards, 1)
+	assert.Equal(t, "Paris, France", got.Cards[0].Answer)
+	assert.Equal(t, []string{"Lyon", "Nice"}, got.Cards[0].OtherAnswers.Items)
+
+	require.NoError(t, c.UpdateCollection(ctx, coll.ID, domain.Collection{Name: "European capitals", IsPublic: true}))
+
+	// another user finds the collection and likes it
+	reader := client.New(server.URL)
+	signUp(t, reader, "reader")
+
+	found, err := reader.SearchCollections(ctx, client.SearchParams{Name: "capitals"})
+	require.NoError(t, err)
+	require.Equal(t, 1, found.Count)
+	assert.Equal(t, coll.ID, found.Items[0].ID)
+	assert.Equal(t, 1, found.Items[0].CardsCount)
+
+	likes, err := reader.Like(ctx, coll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, likes)
+	_, err = reader.Like(ctx, coll.ID)
+	assert.ErrorIs(t, err, domain.ErrAlreadyInFavourites)
+
+	favourite, err := reader.SearchCollections(ctx, client.SearchParams{Category: "favourite"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, favourite.Count)
+
+	likes, err = reader.Unlike(ctx, coll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, likes)
+
+	nothing, err := reader.SearchCollections(ctx, client.SearchParams{Name: "dinosaurs"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, nothing.Count)
+
+	// only the author changes the collection
+	err = reader.DeleteCollection(ctx, coll.ID)
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 403, apiErr.StatusCode)
+	assert.ErrorIs(t, err, domain.ErrNotCollectionOwner)
+
+	require.NoError(t, c.DeleteCollection(ctx, coll.ID))
+	_, err = c.GetCollection(ctx, coll.ID)
+	assert.ErrorIs(t, err, domain.ErrCollectionNotFound)
+}
+
+func TestClient_Pictures(t *testing.T) {
+	ctx := context.Background()
+	c := client.New(newTestServer(t).URL)
+	signUp(t, c, "painter")
+
+	coll, err := c.CreateCollection(ctx, domain.Collection{Name: "Paintings"})
+	require.NoError(t, err)
+	card, err := c.AddCard(ctx, coll.ID, domain.Card{Question: "Mona Lisa", Answer: "Leonardo"})
+	require.NoError(t, err)
+
+	objectName, err := c.UploadCardPicture(ctx, coll.ID, card.LocalID, "mona.jpg", bytes.NewReader(testJPEG))
+	require.NoError(t, err)
+	picture, err := c.GetCardPicture(ctx, coll.ID, card.LocalID, objectName)
+	require.NoError(t, err)
+	stored, _, err := internal.CleanImage(testJPEG)
+	require.NoError(t, err)
+	assert.Equal(t, stored, picture)
+	thumb, err := c.GetCardPictureSize(ctx, coll.ID, card.LocalID, objectName, domain.PictureSizeThumb)
+	require.NoError(t, err)
+	assert.Equal(t, stored, thumb, "the picture is smaller than the thumb")
+
+	require.NoError(t, c.RemoveCardPicture(ctx, coll.ID, card.LocalID, objectName))
+	_, err = c.GetCardPicture(ctx, coll.ID, card.LocalID, objectName)
+	assert.ErrorIs(t, err, domain.ErrCardPictureNotFound)
+
+	_, err = c.UploadCardPicture(ctx, coll.ID, card.LocalID, "mona.jpg", bytes.NewReader([]byte("not a picture")))
+	assert.ErrorIs(t, err, domain.ErrInvalidImageType)
+
+	require.NoError(t, c.UploadProfilePicture(ctx, "me.jpeg", bytes.NewReader(testJPEG)))
+	picture, err = c.GetProfilePicture(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, stored, picture)
+	me, err := c.GetMe(ctx)
+	require.NoError(t, err)
+	assert.True(t, me.HasPicture)
+
+	require.NoError(t, c.RemoveProfilePicture(ctx))
+	_, err = c.GetProfilePicture(ctx, "")
+	assert.ErrorIs(t, err, domain.ErrUserPictureNotFound)
+}
+
+func TestClient_CardAttachments(t *testing.T) {
+	ctx := context.Background()
+	c := client.New(newTestServer(t).URL)
+	signUp(t, c, "painter")
+
+	coll, err := c.CreateCollection(ctx, domain.Collection{Name: "Paintings"})
+	require.NoError(t, err)
+	card, err := c.AddCard(ctx, coll.ID, domain.Card{Question: "Mona Lisa", Answer: "Leonardo"})
+	require.NoError(t, err)
+
+	first, err := c.AddCardAttachment(ctx, coll.ID, card.LocalID, domain.CardSideQuestion, "mona.jpg",
+		bytes.NewReader(testJPEG))
+	require.NoError(t, err)
+	second, err := c.AddCardAttachment(ctx, coll.ID, card.LocalID, domain.CardSideAnswer, "leonardo.jpg",
+		bytes.NewReader(testJPEG))
+	require.NoError(t, err)
+	picture, err := c.GetCardAttachment(ctx, coll.ID, card.LocalID, second.ID, domain.PictureSizeOriginal)
+	require.NoError(t, err)
+	stored, _, err := internal.CleanImage(testJPEG)
+	require.NoError(t, err)
+	assert.Equal(t, stored, picture)
+
+	reordered, err := c.ReorderCardAttachments(ctx, coll.ID, card.LocalID, []string{second.ID, first.ID})
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, reordered[0].ID)
+	got, err := c.GetCollection(ctx, coll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, reordered, got.Cards[0].Attachments)
+
+	require.NoError(t, c.RemoveCardAttachment(ctx, coll.ID, card.LocalID, second.ID))
+	_, err = c.GetCardAttachment(ctx, coll.ID, card.LocalID, second.ID, domain.PictureSizeOriginal)
+	assert.ErrorIs(t, err, domain.ErrCardPictureNotFound)
+}
+
+func TestClient_DirectUpload(t *testing.T) {
+	ctx := context.Background()
+	c := client.New(newTestServer(t).URL)
+	signUp(t, c, "painter")
+	stored, _, err := internal.CleanImage(testJPEG)
+	require.NoError(t, err)
+
+	coll, err := c.CreateCollection(ctx, domain.Collection{Name: "Paintings"})
+	require.NoError(t, err)
+	card, err := c.AddCard(ctx, coll.ID, domain.Card{Question: "Mona Lisa", Answer: "Leonardo"})
+	require.NoError(t, err)
+
+	// the in-memory storage can't presign, the picture goes through the server
+	attachment, err := c.AddCardAttachmentDirect(ctx, coll.ID, card.LocalID, domain.CardSideAnswer,
+		bytes.NewReader(testJPEG))
+	require.NoError(t, err)
+	assert.Equal(t, domain.CardSideAnswer, attachment.Side)
+	presigned, err := c.GetCardAttachmentURL(ctx, coll.ID, card.LocalID, attachment.ID, domain.PictureSizeThumb)
+	require.NoError(t, err)
+	assert.True(t, presigned.Proxy)
+	picture, err := c.GetCardAttachment(ctx, coll.ID, card.LocalID, attachment.ID, domain.PictureSizeOriginal)
+	require.NoError(t, err)
+	assert.Equal(t, stored, picture)
+
+	require.NoError(t, c.UploadProfilePictureDirect(ctx, bytes.NewReader(testJPEG)))
+	picture, err = c.GetProfilePicture(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, stored, picture)
+	err = c.UploadProfilePictureDirect(ctx, bytes.NewReader([]byte("definitely not a picture")))
+	assert.ErrorIs(t, err, domain.ErrInvalidImageType)
+}
+
+func TestClient_CardAudio(t *testing.T) {
+	ctx := context.Background()
+	c := client.New(newTestServer(t).URL)
+	signUp(t, c, "birder")
+
+	coll, err := c.CreateCollection(ctx, domain.Collection{Name: "Birds"})
+	require.NoError(t, err)
+	card, err := c.AddCard(ctx, coll.ID, domain.Card{Question: "Nightingale", Answer: "Luscinia megarhynchos"})
+	require.NoError(t, err)
+
+	// a second of silence in mono 8-bit PCM at 8 kHz
+	format := []byte{1, 0, 1, 0, 0x40, 0x1F, 0, 0, 0x40, 0x1F, 0, 0, 1, 0, 8, 0}
+	body := append([]byte("WAVEfmt \x10\x00\x00\x00"), format...)
+	body = append(append(body, "data\x40\x1F\x00\x00"...), bytes.Repeat([]byte{0x80}, 8000)...)
+	song := append(binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body))), body...)
+
+	result, err := c.UploadCardAudio(ctx, coll.ID, card.LocalID, domain.CardSideQuestion, "song.wav",
+		bytes.NewReader(song))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), result.DurationMs)
+	audio, err := c.GetCardAudio(ctx, coll.ID, card.LocalID, domain.CardSideQuestion)
+	require.NoError(t, err)
+	assert.Equal(t, song, audio)
+	_, err = c.GetCardAudio(ctx, coll.ID, card.LocalID, domain.CardSideAnswer)
+	assert.ErrorIs(t, err, domain.ErrCardAudioNotFound)
+
+	require.NoError(t, c.RemoveCardAudio(ctx, coll.ID, card.LocalID, domain.CardSideQuestion))
+	_, err = c.GetCardAudio(ctx, coll.ID, card.LocalID, domain.CardSideQuestion)
+	assert.ErrorIs(t, err, domain.ErrCardAudioNotFound)
+}
+
+func TestClient_TrainingHistory(t *testing.T) {
+	ctx := context.Background()
+	c := client.New(newTestServer(t).URL)
+	signUp(t, c, "student")
+
+	coll, err := c.CreateCollection(ctx, domain.Collection{Name: "Verbs"})
+	require.NoError(t, err)
+
+	for _, at := range []int{1000, 2000} {
+		err = c.AddTraining(ctx, domain.HistoryItem{
+			CollectionID:   coll.ID,
+			CollectionName: coll.Name,
+			Time:           at,
+			AllCardsCount:  2,
+			CorrectCards:   []int{0},
+			IncorrectCards: []int{1},
+		})
+		require.NoError(t, err)
+	}
+
+	history, err := c.GetHistory(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, history.Count)
+
+	recent, err := c.GetHistory(ctx, 1500)
+	require.NoError(t, err)
+	require.Equal(t, 1, recent.Count)
+	assert.Equal(t, 2000, recent.Items[0].Time)
+
+	got, err := c.GetCollection(ctx, coll.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Trainings)
+
+	me, err := c.GetMe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, me.Statistics.TotalTrainings)
+}
+
+func TestClient_RefreshesToken(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+
+	var saved []client.Tokens
+	c := client.New(server.URL, client.WithTokensHandler(func(tokens client.Tokens) {
+		saved = append(saved, tokens)
+	}))
+	signUp(t, c, "sleeper")
+	require.Len(t, saved, 1)
+
+	// a rejected access token is refreshed and the request is repeated
+	tokens := c.Tokens()
+	c.SetTokens(client.Tokens{AccessToken: "expired", RefreshToken: tokens.RefreshToken})
+	me, err := c.GetMe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "sleeper", me.Username)
+	assert.NotEqual(t, tokens.RefreshToken, c.Tokens().RefreshToken, "the refresh token is rotated")
+	assert.Equal(t, c.Tokens(), saved[len(saved)-1])
+
+	// the access token that is about to expire is refreshed before the request
+	early := client.New(server.URL, client.WithTokens(c.Tokens()), client.WithRefreshBefore(3*time.Hour))
+	_, err = early.GetMe(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, c.Tokens(), early.Tokens())
+
+	// without a valid refresh token the client has to sign in again
+	c.SetTokens(client.Tokens{AccessToken: "expired", RefreshToken: "invalid"})
+	_, err = c.GetMe(ctx)
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestClient_Login(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+	c := client.New(server.URL, client.WithLanguage("ru"))
+	signUp(t, c, "returning")
+
+	require.NoError(t, c.Logout(ctx))
+	_, err := c.GetMe(ctx)
+	assert.True(t, errors.Is(err, client.ErrNotSignedIn))
+
+	_, err = c.Login(ctx, domain.LoginRequest{Email: "returning@t-prep.test", Password: "wrong-password"})
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, domain.ErrInvalidCredentials.Code, apiErr.Code)
+	assert.Equal(t, "Неверный логин или пароль", apiErr.Message)
+
+	challenge, err := c.Login(ctx, domain.LoginRequest{Email: "returning@t-prep.test", Password: testPassword})
+	require.NoError(t, err)
+	assert.False(t, challenge.MFARequired)
+	me, err := c.GetMe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "returning", me.Username)
+
+	_, err = c.SignUp(ctx, domain.SignupRequest{Username: "twin", Email: "returning@t-prep.test", Password: "x"})
+	assert.ErrorIs(t, err, domain.ErrUserExists)
+}
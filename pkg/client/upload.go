@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"main/domain"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AddCardAttachmentDirect adds the picture to the side of the card as AddCardAttachment does,
+// but the picture is put to the storage by the presigned URL, so it doesn't go through the server.
+// If the storage of the server can't presign, the picture goes through the server anyway.
+func (c *Client) AddCardAttachmentDirect(
+	ctx context.Context,
+	collectionID string,
+	cardID int,
+	side domain.CardSide,
+	picture io.Reader,
+) (domain.CardAttachment, error) {
+	var attachment domain.CardAttachment
+	path := cardPath(collectionID, cardID) + "/attachment/upload"
+	var ticket domain.UploadTicket
+	err := c.do(ctx, request{method: http.MethodPost, path: path, query: url.Values{"side": {string(side)}}}, &ticket)
+	if err != nil {
+		return attachment, err
+	}
+	err = c.putUpload(ctx, ticket, picture)
+	if err != nil {
+		return attachment, err
+	}
+	complete := request{method: http.MethodPost, path: path + "/" + url.PathEscape(ticket.ID) + "/complete"}
+	err = c.do(ctx, complete, &attachment)
+	return attachment, err
+}
+
+// UploadProfilePictureDirect replaces the picture of the user as UploadProfilePicture does,
+// the picture is put to the storage by the presigned URL as in AddCardAttachmentDirect.
+func (c *Client) UploadProfilePictureDirect(ctx context.Context, picture io.Reader) error {
+	var ticket domain.UploadTicket
+	err := c.do(ctx, request{method: http.MethodPost, path: "/user/picture/upload"}, &ticket)
+	if err != nil {
+		return err
+	}
+	err = c.putUpload(ctx, ticket, picture)
+	if err != nil {
+		return err
+	}
+	complete := request{method: http.MethodPost, path: "/user/picture/upload/" + url.PathEscape(ticket.ID) + "/complete"}
+	return c.do(ctx, complete, nil)
+}
+
+// putUpload sends the picture to the URL of the ticket: a proxy URL is a path of the API the picture is put to,
+// a presigned one takes the form with the fields of the ticket.
+func (c *Client) putUpload(ctx context.Context, ticket domain.UploadTicket, picture io.Reader) error {
+	data, err := io.ReadAll(picture)
+	if err != nil {
+		return err
+	}
+	if ticket.Proxy {
+		return c.do(ctx, request{
+			method:      ticket.Method,
+			path:        strings.TrimPrefix(ticket.URL, apiVersion),
+			body:        data,
+			contentType: "application/octet-stream",
+		}, nil)
+	}
+
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	for name, value := range ticket.Fields {
+		err = writer.WriteField(name, value)
+		if err != nil {
+			return err
+		}
+	}
+	// the storage takes the fields before the file only
+	part, err := writer.CreateFormFile("file", "picture")
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(data)
+	if err != nil {
+		return err
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	// the URL is signed, it is sent without the access token
+	req, err := http.NewRequestWithContext(ctx, ticket.Method, ticket.URL, &form)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("client: post the upload to the storage: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"io"
+	"main/domain"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GetMe returns the profile of the signed in user.
+func (c *Client) GetMe(ctx context.Context) (domain.UserInfo, error) {
+	var info domain.UserInfo
+	err := c.do(ctx, request{method: http.MethodGet, path: "/user"}, &info)
+	return info, err
+}
+
+// GetUser returns the public profile of another user.
+func (c *Client) GetUser(ctx context.Context, userID string) (domain.PublicUserInfo, error) {
+	var info domain.PublicUserInfo
+	err := c.do(ctx, request{method: http.MethodGet, path: "/user", query: url.Values{"id": {userID}}}, &info)
+	return info, err
+}
+
+// UploadProfilePicture replaces the picture of the user, the server accepts JPEG, PNG, WebP
+// and GIF files up to 5 MB and stores them without the metadata.
+func (c *Client) UploadProfilePicture(ctx context.Context, filename string, picture io.Reader) error {
+	req, err := imageRequest(http.MethodPut, "/user/picture", filename, picture)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetProfilePicture returns the picture of the user, an empty userID is the signed in user.
+func (c *Client) GetProfilePicture(ctx context.Context, userID string) ([]byte, error) {
+	return c.GetProfilePictureSize(ctx, userID, domain.PictureSizeOriginal)
+}
+
+// GetProfilePictureSize returns the variant of the picture of the user, see domain.PictureSides.
+func (c *Client) GetProfilePictureSize(ctx context.Context, userID string, size domain.PictureSize) ([]byte, error) {
+	req := request{method: http.MethodGet, path: "/user/picture", query: url.Values{"size": {string(size)}}}
+	if userID != "" {
+		req.query.Set("id", userID)
+	}
+	var picture []byte
+	err := c.do(ctx, req, &picture)
+	return picture, err
+}
+
+// GetProfilePictureURL returns the short-lived URL the variant of the picture of the user is got
+// from the storage by, an empty userID is the signed in user.
+func (c *Client) GetProfilePictureURL(
+	ctx context.Context,
+	userID string,
+	size domain.PictureSize,
+) (domain.PresignedURL, error) {
+	req := request{method: http.MethodGet, path: "/user/picture/url", query: url.Values{"size": {string(size)}}}
+	if userID != "" {
+		req.query.Set("id", userID)
+	}
+	var presigned domain.PresignedURL
+	err := c.do(ctx, req, &presigned)
+	return presigned, err
+}
+
+func (c *Client) RemoveProfilePicture(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/user/picture"}, nil)
+}
+
+// AddTraining saves the result of a training of the collection.
+func (c *Client) AddTraining(ctx context.Context, item domain.HistoryItem) error {
+	if item.Errors == nil {
+		item.Errors = make([]domain.ErrorItem, 0)
+	}
+	if item.RightAnswers == nil {
+		item.RightAnswers = make([]domain.RightAnswerItem, 0)
+	}
+	if item.CorrectCards == nil {
+		item.CorrectCards = make([]int, 0)
+	}
+	if item.IncorrectCards == nil {
+		item.IncorrectCards = make([]int, 0)
+	}
+
+	req, err := jsonRequest(http.MethodPost, "/collection/training", item)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetHistory returns the trainings of the user since fromTime (unix seconds), 0 is all of them.
+func (c *Client) GetHistory(ctx context.Context, fromTime int) (domain.UserHistoryArray, error) {
+	req := request{method: http.MethodGet, path: "/user/history"}
+	if fromTime > 0 {
+		req.query = url.Values{"from_time": {strconv.Itoa(fromTime)}}
+	}
+	var history domain.UserHistoryArray
+	err := c.do(ctx, req, &history)
+	return history, err
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+	"main/internal"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type apiKeyRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewApiKeyRepository(db database.Database, collection string) domain.ApiKeyRepository {
+	return &apiKeyRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (ar *apiKeyRepository) Create(c context.Context, apiKey *domain.ApiKey) (string, error) {
+	collection := ar.database.Collection(ar.collection)
+	apiKey.ID = internal.GenerateUUID()
+	id, err := collection.InsertOne(c, apiKey)
+	return id, err
+}
+
+func (ar *apiKeyRepository) GetByHash(c context.Context, hash string) (domain.ApiKey, error) {
+	var apiKey domain.ApiKey
+	collection := ar.database.Collection(ar.collection)
+	filter := bson.D{{Key: "hash", Value: hash}}
+	err := collection.FindOne(c, filter).Decode(&apiKey)
+	return apiKey, err
+}
+
+func (ar *apiKeyRepository) GetByUserID(c context.Context, userID string) ([]domain.ApiKey, error) {
+	apiKeys := make([]domain.ApiKey, 0)
+	collection := ar.database.Collection(ar.collection)
+	filter := bson.D{{Key: "user_id", Value: userID}}
+
+	op := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := collection.Find(c, filter, op)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(c, &apiKeys)
+	if err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+func (ar *apiKeyRepository) UpdateLastUsed(c context.Context, apiKeyID string, lastUsedAt int64) error {
+	collection := ar.database.Collection(ar.collection)
+	filter := bson.D{{Key: "_id", Value: apiKeyID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "last_used_at", Value: lastUsedAt}}}}
+	_, err := collection.UpdateOne(c, filter, update)
+	return err
+}
+
+func (ar *apiKeyRepository) DeleteByID(c context.Context, userID string, apiKeyID string) (int64, error) {
+	collection := ar.database.Collection(ar.collection)
+	filter := bson.D{
+		{Key: "_id", Value: apiKeyID},
+		{Key: "user_id", Value: userID},
+	}
+	return collection.DeleteOne(c, filter)
+}
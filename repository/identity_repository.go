@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type identityRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewIdentityRepository(db database.Database, collection string) domain.IdentityRepository {
+	return &identityRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (ir *identityRepository) Create(c context.Context, identity *domain.Identity) (string, error) {
+	collection := ir.database.Collection(ir.collection)
+	id, err := collection.InsertOne(c, identity)
+	return id, err
+}
+
+func (ir *identityRepository) GetByID(c context.Context, identityID string) (domain.Identity, error) {
+	var identity domain.Identity
+	collection := ir.database.Collection(ir.collection)
+	filter := bson.D{{Key: "_id", Value: identityID}}
+	err := collection.FindOne(c, filter).Decode(&identity)
+	return identity, err
+}
+
+func (ir *identityRepository) GetByUserID(c context.Context, userID string) ([]domain.Identity, error) {
+	identities := make([]domain.Identity, 0)
+	collection := ir.database.Collection(ir.collection)
+	filter := bson.D{{Key: "user_id", Value: userID}}
+
+	cursor, err := collection.Find(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(c, &identities)
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+func (ir *identityRepository) DeleteByID(c context.Context, identityID string) error {
+	collection := ir.database.Collection(ir.collection)
+	filter := bson.D{{Key: "_id", Value: identityID}}
+	_, err := collection.DeleteOne(c, filter)
+	return err
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"main/domain"
+	"sync"
+	"time"
+)
+
+type loginAttemptEntry struct {
+	attempt   domain.LoginAttempt
+	expiresAt time.Time
+}
+
+type memoryLoginAttemptRepository struct {
+	mu        sync.Mutex
+	entries   map[string]loginAttemptEntry
+	lastPurge time.Time
+}
+
+// NewMemoryLoginAttemptRepository keeps the counters in the memory of the process,
+// so they are per instance and are lost on restart.
+func NewMemoryLoginAttemptRepository() domain.LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{
+		entries: make(map[string]loginAttemptEntry),
+	}
+}
+
+func (lr *memoryLoginAttemptRepository) Get(_ context.Context, key string) (domain.LoginAttempt, error) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	entry, ok := lr.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return domain.LoginAttempt{}, nil
+	}
+	return entry.attempt, nil
+}
+
+func (lr *memoryLoginAttemptRepository) Reserve(
+	_ context.Context,
+	key string,
+	failures int,
+	at time.Time,
+	window time.Duration,
+) (domain.LoginAttempt, bool, error) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	lr.purge(at)
+
+	entry, ok := lr.entries[key]
+	if !ok || at.After(entry.expiresAt) {
+		entry = loginAttemptEntry{}
+	}
+	if entry.attempt.Failures != failures {
+		return entry.attempt, false, nil
+	}
+	entry.attempt.Failures++
+	entry.attempt.LastFailure = at
+	if expiresAt := at.Add(window); expiresAt.After(entry.expiresAt) {
+		entry.expiresAt = expiresAt
+	}
+	lr.entries[key] = entry
+	return entry.attempt, true, nil
+}
+
+// Release gives back an attempt that turned out to be successful.
+func (lr *memoryLoginAttemptRepository) Release(_ context.Context, key string) error {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	entry, ok := lr.entries[key]
+	if ok && entry.attempt.Failures > 0 {
+		entry.attempt.Failures--
+		lr.entries[key] = entry
+	}
+	return nil
+}
+
+func (lr *memoryLoginAttemptRepository) Touch(_ context.Context, key string, at time.Time) error {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	entry, ok := lr.entries[key]
+	if ok && at.After(entry.attempt.LastFailure) {
+		entry.attempt.LastFailure = at
+		lr.entries[key] = entry
+	}
+	return nil
+}
+
+func (lr *memoryLoginAttemptRepository) Lock(_ context.Context, key string, until time.Time) error {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	entry := lr.entries[key]
+	entry.attempt.LockedUntil = until
+	if until.After(entry.expiresAt) {
+		entry.expiresAt = until
+	}
+	lr.entries[key] = entry
+	return nil
+}
+
+func (lr *memoryLoginAttemptRepository) Reset(_ context.Context, key string) error {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	delete(lr.entries, key)
+	return nil
+}
+
+// purge drops the expired counters, at most once a minute.
+func (lr *memoryLoginAttemptRepository) purge(now time.Time) {
+	if now.Sub(lr.lastPurge) < time.Minute {
+		return
+	}
+	lr.lastPurge = now
+	for key, entry := range lr.entries {
+		if now.After(entry.expiresAt) {
+			delete(lr.entries, key)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+	"main/internal"
+)
+
+type loginAuditRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewLoginAuditRepository(db database.Database, collection string) domain.LoginAuditRepository {
+	return &loginAuditRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (lr *loginAuditRepository) Create(c context.Context, audit *domain.LoginAudit) (string, error) {
+	collection := lr.database.Collection(lr.collection)
+	audit.ID = internal.GenerateUUID()
+	return collection.InsertOne(c, audit)
+}
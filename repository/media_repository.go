@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"main/database"
+	"main/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type mediaRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewMediaRepository(db database.Database, collection string) domain.MediaRepository {
+	return &mediaRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (mr *mediaRepository) GetByID(c context.Context, mediaID string) (domain.Media, error) {
+	var media domain.Media
+	collection := mr.database.Collection(mr.collection)
+	filter := bson.D{{Key: "_id", Value: mediaID}}
+	err := collection.FindOne(c, filter).Decode(&media)
+	return media, err
+}
+
+const (
+	// tombstoneWait is how long Acquire waits for the media being deleted to be purged before it looks again
+	tombstoneWait = 10 * time.Millisecond
+	// staleTombstone is how long the media can be deleting, the purge of an older one was lost
+	staleTombstone = time.Minute
+)
+
+// Acquire counts the reference by one update, the filters make the steps exclusive: a reference of an owner,
+// the first reference of a user to the media, the first reference at all. The insert of the media made by
+// another request meanwhile fails on the key, then the reference is counted again. The media being deleted
+// is waited for: its object could be removed after the new one is put.
+func (mr *mediaRepository) Acquire(c context.Context, media *domain.Media, userID string) (bool, error) {
+	collection := mr.database.Collection(mr.collection)
+	alive := bson.D{{Key: "$ne", Value: true}}
+	for {
+		filter := bson.D{
+			{Key: "_id", Value: media.ID},
+			{Key: "deleting", Value: alive},
+			{Key: "owners.user_id", Value: userID},
+		}
+		update := bson.D{
+			{Key: "$inc", Value: bson.D{
+				{Key: "ref_count", Value: 1},
+				{Key: "owners.$.ref_count", Value: 1},
+			}},
+		}
+		res, err := collection.UpdateOne(c, filter, update)
+		if err != nil {
+			return false, err
+		}
+		if res.MatchedCount > 0 {
+			return true, nil
+		}
+
+		filter = bson.D{
+			{Key: "_id", Value: media.ID},
+			{Key: "deleting", Value: alive},
+			{Key: "owners.user_id", Value: bson.D{{Key: "$ne", Value: userID}}},
+		}
+		update = bson.D{
+			{Key: "$inc", Value: bson.D{
+				{Key: "ref_count", Value: 1},
+			}},
+			{Key: "$push", Value: bson.D{
+				{Key: "owners", Value: domain.MediaOwner{UserID: userID, RefCount: 1}},
+			}},
+		}
+		res, err = collection.UpdateOne(c, filter, update)
+		if err != nil {
+			return false, err
+		}
+		if res.MatchedCount > 0 {
+			return false, nil
+		}
+
+		media.RefCount = 1
+		media.Owners = []domain.MediaOwner{{UserID: userID, RefCount: 1}}
+		_, err = collection.InsertOne(c, media)
+		if mongo.IsDuplicateKeyError(err) {
+			err = mr.waitPurge(c, media.ID)
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+		return false, err
+	}
+}
+
+// waitPurge waits a bit if the media is being deleted, the media deleting for too long is purged.
+func (mr *mediaRepository) waitPurge(c context.Context, mediaID string) error {
+	collection := mr.database.Collection(mr.collection)
+	var media domain.Media
+	err := collection.FindOne(c, bson.D{{Key: "_id", Value: mediaID}}).Decode(&media)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil || !media.Deleting {
+		return err
+	}
+
+	if time.Since(time.Unix(media.DeletingAt, 0)) > staleTombstone {
+		filter := bson.D{
+			{Key: "_id", Value: mediaID},
+			{Key: "deleting", Value: true},
+			{Key: "deleting_at", Value: media.DeletingAt},
+		}
+		_, err = collection.DeleteOne(c, filter)
+		return err
+	}
+	select {
+	case <-c.Done():
+		return c.Err()
+	case <-time.After(tombstoneWait):
+		return nil
+	}
+}
+
+// Release counts the reference off like Acquire, the media is marked deleting once nothing references it.
+// mongo.ErrNoDocuments is returned if the user doesn't reference the media.
+func (mr *mediaRepository) Release(c context.Context, mediaID string, userID string) (domain.MediaRelease, error) {
+	collection := mr.database.Collection(mr.collection)
+	var release domain.MediaRelease
+
+	filter := bson.D{
+		{Key: "_id", Value: mediaID},
+		{Key: "owners", Value: bson.D{
+			{Key: "$elemMatch", Value: bson.D{
+				{Key: "user_id", Value: userID},
+				{Key: "ref_count", Value: bson.D{{Key: "$gt", Value: 1}}},
+			}},
+		}},
+	}
+	update := bson.D{
+		{Key: "$inc", Value: bson.D{
+			{Key: "ref_count", Value: -1},
+			{Key: "owners.$.ref_count", Value: -1},
+		}},
+	}
+	res, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return release, err
+	}
+	if res.MatchedCount > 0 {
+		return release, nil
+	}
+
+	filter = bson.D{
+		{Key: "_id", Value: mediaID},
+		{Key: "owners.user_id", Value: userID},
+	}
+	update = bson.D{
+		{Key: "$inc", Value: bson.D{
+			{Key: "ref_count", Value: -1},
+		}},
+		{Key: "$pull", Value: bson.D{
+			{Key: "owners", Value: bson.D{{Key: "user_id", Value: userID}}},
+		}},
+	}
+	res, err = collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return release, err
+	}
+	if res.MatchedCount == 0 {
+		return release, mongo.ErrNoDocuments
+	}
+	release.Disowned = true
+
+	filter = bson.D{
+		{Key: "_id", Value: mediaID},
+		{Key: "ref_count", Value: bson.D{{Key: "$lte", Value: 0}}},
+		{Key: "deleting", Value: bson.D{{Key: "$ne", Value: true}}},
+	}
+	update = bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "deleting", Value: true},
+			{Key: "deleting_at", Value: time.Now().Unix()},
+		}},
+	}
+	res, err = collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return release, err
+	}
+	release.Unused = res.ModifiedCount > 0
+	return release, nil
+}
+
+func (mr *mediaRepository) Purge(c context.Context, mediaID string) error {
+	collection := mr.database.Collection(mr.collection)
+	filter := bson.D{
+		{Key: "_id", Value: mediaID},
+		{Key: "deleting", Value: true},
+	}
+	_, err := collection.DeleteOne(c, filter)
+	return err
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type oauthStateRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewOAuthStateRepository(db database.Database, collection string) domain.OAuthStateRepository {
+	return &oauthStateRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (osr *oauthStateRepository) Create(c context.Context, state *domain.OAuthState) (string, error) {
+	collection := osr.database.Collection(osr.collection)
+	id, err := collection.InsertOne(c, state)
+	return id, err
+}
+
+func (osr *oauthStateRepository) Update(
+	c context.Context,
+	filter interface{},
+	update interface{},
+) (database.UpdateResult, error) {
+	collection := osr.database.Collection(osr.collection)
+	return collection.UpdateOne(c, filter, update)
+}
+
+func (osr *oauthStateRepository) GetByID(c context.Context, stateID string) (domain.OAuthState, error) {
+	var state domain.OAuthState
+	collection := osr.database.Collection(osr.collection)
+	filter := bson.D{{Key: "_id", Value: stateID}}
+	err := collection.FindOne(c, filter).Decode(&state)
+	return state, err
+}
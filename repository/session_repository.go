@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+	"main/internal"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type sessionRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewSessionRepository(db database.Database, collection string) domain.SessionRepository {
+	return &sessionRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (sr *sessionRepository) Create(c context.Context, session *domain.Session) (string, error) {
+	collection := sr.database.Collection(sr.collection)
+	session.ID = internal.GenerateUUID()
+	id, err := collection.InsertOne(c, session)
+	return id, err
+}
+
+func (sr *sessionRepository) Update(
+	c context.Context,
+	filter interface{},
+	update interface{},
+) (database.UpdateResult, error) {
+	collection := sr.database.Collection(sr.collection)
+	return collection.UpdateOne(c, filter, update)
+}
+
+func (sr *sessionRepository) GetByID(c context.Context, sessionID string) (domain.Session, error) {
+	var session domain.Session
+	collection := sr.database.Collection(sr.collection)
+	filter := bson.D{{Key: "_id", Value: sessionID}}
+	err := collection.FindOne(c, filter).Decode(&session)
+	return session, err
+}
+
+func (sr *sessionRepository) GetActiveByUserID(
+	c context.Context,
+	userID string,
+	now int64,
+) ([]domain.Session, error) {
+	sessions := make([]domain.Session, 0)
+	collection := sr.database.Collection(sr.collection)
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "revoked", Value: false},
+		{Key: "expires_at", Value: bson.D{{Key: "$gt", Value: now}}},
+	}
+
+	op := options.Find().SetSort(bson.D{{Key: "last_used_at", Value: -1}})
+	cursor, err := collection.Find(c, filter, op)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(c, &sessions)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (sr *sessionRepository) RevokeByID(c context.Context, sessionID string) error {
+	collection := sr.database.Collection(sr.collection)
+	filter := bson.D{{Key: "_id", Value: sessionID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "revoked", Value: true}}}}
+	_, err := collection.UpdateOne(c, filter, update)
+	return err
+}
+
+func (sr *sessionRepository) RevokeByUserID(c context.Context, userID string, keep string) error {
+	collection := sr.database.Collection(sr.collection)
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "revoked", Value: false},
+	}
+	if keep != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$ne", Value: keep}}})
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "revoked", Value: true}}}}
+	_, err := collection.UpdateMany(c, filter, update)
+	return err
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+	"main/internal"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type uploadRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewUploadRepository(db database.Database, collection string) domain.UploadRepository {
+	return &uploadRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (ur *uploadRepository) Create(c context.Context, upload *domain.Upload) (string, error) {
+	collection := ur.database.Collection(ur.collection)
+	upload.ID = internal.GenerateUUID()
+	id, err := collection.InsertOne(c, upload)
+	return id, err
+}
+
+func (ur *uploadRepository) GetByID(c context.Context, uploadID string) (domain.Upload, error) {
+	var upload domain.Upload
+	collection := ur.database.Collection(ur.collection)
+	filter := bson.D{{Key: "_id", Value: uploadID}}
+	err := collection.FindOne(c, filter).Decode(&upload)
+	return upload, err
+}
+
+func (ur *uploadRepository) GetExpiredByUserID(c context.Context, userID string, now int64) ([]domain.Upload, error) {
+	filter := append(bson.D{{Key: "user_id", Value: userID}}, expired(now)...)
+	return ur.find(c, filter)
+}
+
+func (ur *uploadRepository) GetExpired(c context.Context, now int64) ([]domain.Upload, error) {
+	return ur.find(c, expired(now))
+}
+
+func (ur *uploadRepository) Claim(c context.Context, uploadID string, now int64, until int64) (bool, error) {
+	collection := ur.database.Collection(ur.collection)
+	filter := bson.D{{Key: "_id", Value: uploadID}, unclaimed(now)}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "claimed_until", Value: until}}}}
+	res, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (ur *uploadRepository) Unclaim(c context.Context, uploadID string) error {
+	collection := ur.database.Collection(ur.collection)
+	filter := bson.D{{Key: "_id", Value: uploadID}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "claimed_until", Value: 0}}}}
+	_, err := collection.UpdateOne(c, filter, update)
+	return err
+}
+
+func (ur *uploadRepository) DeleteByID(c context.Context, uploadID string) (bool, error) {
+	collection := ur.database.Collection(ur.collection)
+	filter := bson.D{{Key: "_id", Value: uploadID}}
+	deleted, err := collection.DeleteOne(c, filter)
+	return deleted > 0, err
+}
+
+func (ur *uploadRepository) find(c context.Context, filter bson.D) ([]domain.Upload, error) {
+	uploads := make([]domain.Upload, 0)
+	collection := ur.database.Collection(ur.collection)
+	cursor, err := collection.Find(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(c, &uploads)
+	if err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
+
+// expired is the filter of the expired uploads, the ones being completed are left to their completion.
+func expired(now int64) bson.D {
+	return bson.D{{Key: "expires_at", Value: bson.D{{Key: "$lte", Value: now}}}, unclaimed(now)}
+}
+
+// unclaimed matches the uploads that are not being completed, the ones started before the claims have none.
+func unclaimed(now int64) bson.E {
+	return bson.E{Key: "$or", Value: bson.A{
+		bson.D{{Key: "claimed_until", Value: bson.D{{Key: "$lte", Value: now}}}},
+		bson.D{{Key: "claimed_until", Value: bson.D{{Key: "$exists", Value: false}}}},
+	}}
+}
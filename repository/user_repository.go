@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"main/database"
 	"main/domain"
 	"main/internal"
@@ -99,7 +98,7 @@ func (ur *userRepository) AddCollection(
 	}
 
 	if res.ModifiedCount == 0 {
-		return errors.New("user not found")
+		return domain.ErrUserNotFound
 	}
 
 	return nil
@@ -132,7 +131,7 @@ func (ur *userRepository) DeleteCollection(
 	}
 
 	if res.ModifiedCount == 0 {
-		return errors.New("user not found")
+		return domain.ErrUserNotFound
 	}
 
 	return nil
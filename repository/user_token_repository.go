@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type userTokenRepository struct {
+	database   database.Database
+	collection string
+}
+
+func NewUserTokenRepository(db database.Database, collection string) domain.UserTokenRepository {
+	return &userTokenRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (utr *userTokenRepository) Create(c context.Context, token *domain.UserToken) (string, error) {
+	collection := utr.database.Collection(utr.collection)
+	id, err := collection.InsertOne(c, token)
+	return id, err
+}
+
+func (utr *userTokenRepository) Update(
+	c context.Context,
+	filter interface{},
+	update interface{},
+) (database.UpdateResult, error) {
+	collection := utr.database.Collection(utr.collection)
+	return collection.UpdateOne(c, filter, update)
+}
+
+func (utr *userTokenRepository) GetByID(c context.Context, tokenID string) (domain.UserToken, error) {
+	var token domain.UserToken
+	collection := utr.database.Collection(utr.collection)
+	filter := bson.D{{Key: "_id", Value: tokenID}}
+	err := collection.FindOne(c, filter).Decode(&token)
+	return token, err
+}
+
+func (utr *userTokenRepository) GetLatestByUserID(
+	c context.Context,
+	userID string,
+	purpose string,
+) (domain.UserToken, error) {
+	tokens := make([]domain.UserToken, 0, 1)
+	collection := utr.database.Collection(utr.collection)
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "purpose", Value: purpose},
+	}
+
+	op := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(1)
+	cursor, err := collection.Find(c, filter, op)
+	if err != nil {
+		return domain.UserToken{}, err
+	}
+	err = cursor.All(c, &tokens)
+	if err != nil {
+		return domain.UserToken{}, err
+	}
+	if len(tokens) == 0 {
+		return domain.UserToken{}, mongo.ErrNoDocuments
+	}
+	return tokens[0], nil
+}
@@ -4,28 +4,90 @@ import (
 	"context"
 	"io"
 	"main/domain"
+	"time"
 )
 
 type collectionStorage struct {
-	storage Client
-	bucket  string
+	storage  Client
+	bucket   string
+	variants pictureVariants
 }
 
-func (cs *collectionStorage) GetObject(c context.Context, objectName string) ([]byte, error) {
+func (cs *collectionStorage) GetObject(c context.Context, objectName string) (domain.Object, error) {
 	return cs.storage.GetObject(c, cs.bucket, objectName)
 }
 
-func (cs *collectionStorage) PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64) error {
-	return cs.storage.PutObject(c, cs.bucket, objectName, reader, objectSize)
+// GetPicture returns the variant of the picture, it is made on the first request.
+func (cs *collectionStorage) GetPicture(
+	c context.Context,
+	objectName string,
+	size domain.PictureSize,
+) (domain.Object, error) {
+	return cs.variants.get(c, objectName, size)
+}
+
+// PresignGet returns the short-lived URL of the variant of the picture, it is made if there is none yet.
+func (cs *collectionStorage) PresignGet(
+	c context.Context,
+	objectName string,
+	size domain.PictureSize,
+	expires time.Duration,
+) (string, error) {
+	return cs.variants.presign(c, objectName, size, expires)
+}
+
+func (cs *collectionStorage) StatObject(c context.Context, objectName string) (domain.ObjectInfo, error) {
+	return cs.storage.StatObject(c, cs.bucket, objectName)
+}
+
+func (cs *collectionStorage) PutObject(
+	c context.Context,
+	objectName string,
+	reader io.Reader,
+	objectSize int64,
+	contentType string,
+) error {
+	err := cs.variants.remove(c, objectName)
+	if err != nil {
+		return err
+	}
+	return cs.storage.PutObject(c, cs.bucket, objectName, reader, objectSize, contentType)
 }
 
 func (cs *collectionStorage) RemoveObject(c context.Context, objectName string) error {
+	err := cs.variants.remove(c, objectName)
+	if err != nil {
+		return err
+	}
 	return cs.storage.RemoveObject(c, cs.bucket, objectName)
 }
 
+// audioForm is the extension of the audio objects, it keeps them apart from the pictures,
+// which have no variants of it and are named without an extension.
+const audioForm = ".audio"
+
+func (cs *collectionStorage) GetAudio(c context.Context, objectName string) (domain.Object, error) {
+	return cs.storage.GetObject(c, cs.bucket, objectName+audioForm)
+}
+
+func (cs *collectionStorage) PutAudio(
+	c context.Context,
+	objectName string,
+	reader io.Reader,
+	objectSize int64,
+	contentType string,
+) error {
+	return cs.storage.PutObject(c, cs.bucket, objectName+audioForm, reader, objectSize, contentType)
+}
+
+func (cs *collectionStorage) RemoveAudio(c context.Context, objectName string) error {
+	return cs.storage.RemoveObject(c, cs.bucket, objectName+audioForm)
+}
+
 func NewCollectionStorage(s Client, bucket string) domain.CollectionStorage {
 	return &collectionStorage{
-		storage: s,
-		bucket:  bucket,
+		storage:  s,
+		bucket:   bucket,
+		variants: pictureVariants{storage: s, bucket: bucket},
 	}
 }
@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // the ETag is not a security feature, S3 uses MD5 too
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"main/domain"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	tempPrefix = ".tmp-"
+	// metaDir is the directory of a bucket with the metadata of its objects,
+	// the object names can't start with a dot, so it never clashes with an object
+	metaDir = ".meta"
+)
+
+// fsClient keeps every bucket as a directory in root and every object as a file in it.
+type fsClient struct {
+	root string
+}
+
+type fsMeta struct {
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+}
+
+// NewFSClient stores the objects on the local disk under root, creating it if needed.
+// The objects are written to a temporary file and renamed, so readers never see a partial object.
+func NewFSClient(root string) (Client, error) {
+	//nolint:mnd // rwx for owner only
+	err := os.MkdirAll(root, 0o700)
+	if err != nil {
+		return nil, err
+	}
+	return &fsClient{root: root}, nil
+}
+
+func (fc *fsClient) GetObject(_ context.Context, bucketName string, objectName string) (domain.Object, error) {
+	path, err := fc.objectPath(bucketName, objectName)
+	if err != nil {
+		return domain.Object{}, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.Object{}, ErrObjectNotFound
+	}
+	if err != nil {
+		return domain.Object{}, err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return domain.Object{}, err
+	}
+	return domain.Object{ReadSeekCloser: file, ObjectInfo: fc.info(path, stat)}, nil
+}
+
+func (fc *fsClient) StatObject(_ context.Context, bucketName string, objectName string) (domain.ObjectInfo, error) {
+	path, err := fc.objectPath(bucketName, objectName)
+	if err != nil {
+		return domain.ObjectInfo{}, err
+	}
+	stat, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.ObjectInfo{}, ErrObjectNotFound
+	}
+	if err != nil {
+		return domain.ObjectInfo{}, err
+	}
+	return fc.info(path, stat), nil
+}
+
+func (fc *fsClient) PutObject(
+	_ context.Context,
+	bucketName string,
+	objectName string,
+	reader io.Reader,
+	objectSize int64,
+	contentType string,
+) error {
+	path, err := fc.objectPath(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+
+	hash := md5.New() //nolint:gosec // see the import
+	err = writeAtomically(path, func(w io.Writer) error {
+		written, err := io.Copy(io.MultiWriter(w, hash), io.LimitReader(reader, objectSize))
+		if err == nil && written != objectSize {
+			err = fmt.Errorf("object size %d does not match the data size %d", objectSize, written)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(fsMeta{ContentType: contentType, ETag: hex.EncodeToString(hash.Sum(nil))})
+	if err != nil {
+		return err
+	}
+	return writeAtomically(metaPath(path), func(w io.Writer) error {
+		_, err := w.Write(meta)
+		return err
+	})
+}
+
+func (fc *fsClient) RemoveObject(_ context.Context, bucketName string, objectName string) error {
+	path, err := fc.objectPath(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	err = os.Remove(metaPath(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (fc *fsClient) BucketExists(_ context.Context, bucketName string) (bool, error) {
+	path, err := fc.bucketPath(bucketName)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+func (fc *fsClient) MakeBucket(_ context.Context, bucketName string) error {
+	path, err := fc.bucketPath(bucketName)
+	if err != nil {
+		return err
+	}
+	//nolint:mnd // rwx for owner only
+	return os.Mkdir(path, 0o700)
+}
+
+// info reads the metadata of the object, the missing metadata leaves the content type unknown
+// and the ETag is made of the modification time and the size then.
+func (fc *fsClient) info(path string, stat fs.FileInfo) domain.ObjectInfo {
+	info := domain.ObjectInfo{
+		Size:         stat.Size(),
+		ContentType:  "application/octet-stream",
+		ETag:         fmt.Sprintf("%x-%x", stat.ModTime().UnixNano(), stat.Size()),
+		LastModified: stat.ModTime().UTC(),
+	}
+	data, err := os.ReadFile(metaPath(path))
+	if err != nil {
+		return info
+	}
+	var meta fsMeta
+	if json.Unmarshal(data, &meta) != nil {
+		return info
+	}
+	if meta.ContentType != "" {
+		info.ContentType = meta.ContentType
+	}
+	if meta.ETag != "" {
+		info.ETag = meta.ETag
+	}
+	return info
+}
+
+func (fc *fsClient) bucketPath(bucketName string) (string, error) {
+	if !validName(bucketName) {
+		return "", fmt.Errorf("invalid bucket name %q", bucketName)
+	}
+	return filepath.Join(fc.root, bucketName), nil
+}
+
+func (fc *fsClient) objectPath(bucketName string, objectName string) (string, error) {
+	bucket, err := fc.bucketPath(bucketName)
+	if err != nil {
+		return "", err
+	}
+	if !validName(objectName) {
+		return "", fmt.Errorf("invalid object name %q", objectName)
+	}
+	info, err := os.Stat(bucket)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("bucket %q does not exist", bucketName)
+	}
+	return filepath.Join(bucket, objectName), nil
+}
+
+func metaPath(objectPath string) string {
+	return filepath.Join(filepath.Dir(objectPath), metaDir, filepath.Base(objectPath))
+}
+
+// writeAtomically writes a temporary file next to path with write and renames it to path.
+func writeAtomically(path string, write func(w io.Writer) error) error {
+	//nolint:mnd // rwx for owner only
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), tempPrefix+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the temporary file is gone after the rename, so this only cleans up the failures
+		_ = os.Remove(tmp.Name())
+	}()
+
+	err = write(tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// validName keeps the names inside their directory and apart from the temporary files.
+func validName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, `/\`) &&
+		filepath.Base(name) == name
+}
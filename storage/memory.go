@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // the ETag is not a security feature, S3 uses MD5 too
+	"encoding/hex"
+	"fmt"
+	"io"
+	"main/domain"
+	"sync"
+	"time"
+)
+
+type memoryObject struct {
+	data []byte
+	info domain.ObjectInfo
+}
+
+type memoryClient struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string]memoryObject
+}
+
+// bytesReader is the reader of an object kept in memory, the stored data is never changed in place.
+type bytesReader struct {
+	*bytes.Reader
+}
+
+func (bytesReader) Close() error {
+	return nil
+}
+
+// NewMemoryClient keeps the objects in the memory of the process,
+// it is meant for tests and local runs.
+func NewMemoryClient() Client {
+	return &memoryClient{
+		buckets: make(map[string]map[string]memoryObject),
+	}
+}
+
+func (mc *memoryClient) GetObject(_ context.Context, bucketName string, objectName string) (domain.Object, error) {
+	obj, err := mc.object(bucketName, objectName)
+	if err != nil {
+		return domain.Object{}, err
+	}
+	return domain.Object{ReadSeekCloser: bytesReader{bytes.NewReader(obj.data)}, ObjectInfo: obj.info}, nil
+}
+
+func (mc *memoryClient) StatObject(
+	_ context.Context,
+	bucketName string,
+	objectName string,
+) (domain.ObjectInfo, error) {
+	obj, err := mc.object(bucketName, objectName)
+	return obj.info, err
+}
+
+func (mc *memoryClient) object(bucketName string, objectName string) (memoryObject, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	bucket, ok := mc.buckets[bucketName]
+	if !ok {
+		return memoryObject{}, fmt.Errorf("bucket %q does not exist", bucketName)
+	}
+	obj, ok := bucket[objectName]
+	if !ok {
+		return memoryObject{}, ErrObjectNotFound
+	}
+	return obj, nil
+}
+
+func (mc *memoryClient) PutObject(
+	_ context.Context,
+	bucketName string,
+	objectName string,
+	reader io.Reader,
+	objectSize int64,
+	contentType string,
+) error {
+	data, err := io.ReadAll(io.LimitReader(reader, objectSize))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != objectSize {
+		return fmt.Errorf("object size %d does not match the data size %d", objectSize, len(data))
+	}
+	sum := md5.Sum(data) //nolint:gosec // see the import
+	obj := memoryObject{
+		data: data,
+		info: domain.ObjectInfo{
+			Size:         objectSize,
+			ContentType:  contentType,
+			ETag:         hex.EncodeToString(sum[:]),
+			LastModified: time.Now().UTC(),
+		},
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	bucket, ok := mc.buckets[bucketName]
+	if !ok {
+		return fmt.Errorf("bucket %q does not exist", bucketName)
+	}
+	bucket[objectName] = obj
+	return nil
+}
+
+func (mc *memoryClient) RemoveObject(_ context.Context, bucketName string, objectName string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	bucket, ok := mc.buckets[bucketName]
+	if !ok {
+		return fmt.Errorf("bucket %q does not exist", bucketName)
+	}
+	delete(bucket, objectName)
+	return nil
+}
+
+func (mc *memoryClient) BucketExists(_ context.Context, bucketName string) (bool, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	_, ok := mc.buckets[bucketName]
+	return ok, nil
+}
+
+func (mc *memoryClient) MakeBucket(_ context.Context, bucketName string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, ok := mc.buckets[bucketName]; ok {
+		return fmt.Errorf("bucket %q already exists", bucketName)
+	}
+	mc.buckets[bucketName] = make(map[string]memoryObject)
+	return nil
+}
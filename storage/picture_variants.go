@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"main/domain"
+	"main/internal"
+	"time"
+)
+
+// pictureVariants makes the smaller variants of the pictures of a bucket on the first request and keeps them
+// in the bucket. A variant is named after the ETag of its original, so a replaced original never gets
+// the variant of the previous one, even if they are made at the same time.
+type pictureVariants struct {
+	storage Client
+	bucket  string
+}
+
+func (pv pictureVariants) get(c context.Context, objectName string, size domain.PictureSize) (domain.Object, error) {
+	side, ok := domain.PictureSides[size]
+	if !ok {
+		return pv.storage.GetObject(c, pv.bucket, objectName)
+	}
+
+	info, err := pv.storage.StatObject(c, pv.bucket, objectName)
+	if err != nil {
+		return domain.Object{}, err
+	}
+	variant, err := pv.storage.GetObject(c, pv.bucket, variantName(objectName, size, info.ETag))
+	if !errors.Is(err, ErrObjectNotFound) {
+		return variant, err
+	}
+
+	name, original, err := pv.make(c, objectName, size, side)
+	if err != nil || name == "" {
+		return original, err
+	}
+	return pv.storage.GetObject(c, pv.bucket, name)
+}
+
+// name returns the name of the object of the variant, the variant is made if there is none yet.
+// It is the name of the original if the original is small already.
+func (pv pictureVariants) name(c context.Context, objectName string, size domain.PictureSize) (string, error) {
+	side, ok := domain.PictureSides[size]
+	if !ok {
+		return objectName, nil
+	}
+
+	info, err := pv.storage.StatObject(c, pv.bucket, objectName)
+	if err != nil {
+		return "", err
+	}
+	name := variantName(objectName, size, info.ETag)
+	_, err = pv.storage.StatObject(c, pv.bucket, name)
+	if !errors.Is(err, ErrObjectNotFound) {
+		return name, err
+	}
+
+	name, original, err := pv.make(c, objectName, size, side)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		original.Close()
+		return objectName, nil
+	}
+	return name, nil
+}
+
+// make stores the variant of the original and returns its name. If the original is small already,
+// there is no variant and the name is empty, the original is returned as it is read instead.
+func (pv pictureVariants) make(
+	c context.Context,
+	objectName string,
+	size domain.PictureSize,
+	side int,
+) (string, domain.Object, error) {
+	// the variant is named after the original it is made of, it could be replaced since the stat
+	original, err := pv.storage.GetObject(c, pv.bucket, objectName)
+	if err != nil {
+		return "", domain.Object{}, err
+	}
+	data, err := io.ReadAll(original)
+	original.Close()
+	if err != nil {
+		return "", domain.Object{}, err
+	}
+	resized, contentType, ok := internal.ResizeImage(data, side)
+	if !ok {
+		object := domain.Object{ReadSeekCloser: bytesReader{bytes.NewReader(data)}, ObjectInfo: original.ObjectInfo}
+		return "", object, nil
+	}
+	name := variantName(objectName, size, original.ETag)
+
+	err = pv.storage.PutObject(c, pv.bucket, name, bytes.NewReader(resized), int64(len(resized)), contentType)
+	if err != nil {
+		return "", domain.Object{}, fmt.Errorf("store the %s variant of %s: %w", size, objectName, err)
+	}
+	return name, domain.Object{}, nil
+}
+
+// presign returns the presigned URL of the variant, ErrPresignNotSupported if the backend can't presign.
+func (pv pictureVariants) presign(
+	c context.Context,
+	objectName string,
+	size domain.PictureSize,
+	expires time.Duration,
+) (string, error) {
+	presigner, ok := pv.storage.(Presigner)
+	if !ok {
+		return "", ErrPresignNotSupported
+	}
+	name, err := pv.name(c, objectName, size)
+	if err != nil {
+		return "", err
+	}
+	return presigner.PresignGet(c, pv.bucket, name, expires)
+}
+
+// remove removes the variants of the current original, it is called before the original is replaced or removed.
+func (pv pictureVariants) remove(c context.Context, objectName string) error {
+	info, err := pv.storage.StatObject(c, pv.bucket, objectName)
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for size := range domain.PictureSides {
+		err = pv.storage.RemoveObject(c, pv.bucket, variantName(objectName, size, info.ETag))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func variantName(objectName string, size domain.PictureSize, etag string) string {
+	return objectName + "_" + string(size) + "_" + etag
+}
@@ -2,49 +2,129 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
+	"main/domain"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
+// ErrObjectNotFound is returned by GetObject of every backend when the bucket has no such object.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ErrPresignNotSupported is returned by the storages of a backend that is not a Presigner,
+// the objects of such a backend go through the server.
+var ErrPresignNotSupported = errors.New("the storage backend can't presign URLs")
+
 type Client interface {
-	GetObject(ctx context.Context, bucketName string, objectName string) ([]byte, error)
-	PutObject(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64) error
+	GetObject(ctx context.Context, bucketName string, objectName string) (domain.Object, error)
+	StatObject(ctx context.Context, bucketName string, objectName string) (domain.ObjectInfo, error)
+	PutObject(
+		ctx context.Context,
+		bucketName string,
+		objectName string,
+		reader io.Reader,
+		objectSize int64,
+		contentType string,
+	) error
 	RemoveObject(ctx context.Context, bucketName string, objectName string) error
 	BucketExists(ctx context.Context, bucketName string) (bool, error)
 	MakeBucket(ctx context.Context, bucketName string) error
 }
 
+// Presigner is a backend that issues the short-lived URLs to put and get the objects directly,
+// so that the bytes don't go through the server. The object is put by a form POST with the returned fields,
+// the backend refuses one larger than maxSize.
+type Presigner interface {
+	PresignPost(
+		ctx context.Context,
+		bucketName string,
+		objectName string,
+		expires time.Duration,
+		maxSize int64,
+	) (string, map[string]string, error)
+	PresignGet(ctx context.Context, bucketName string, objectName string, expires time.Duration) (string, error)
+}
+
 type storageClient struct {
 	cl *minio.Client
+	// signer signs the URLs for PublicEndpoint, it is cl if there is none
+	signer *minio.Client
 }
 
 type Options struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	UseSSL          bool
+	// PublicEndpoint is the endpoint the clients reach the storage at, if it is not the one the server does.
+	// The presigned URLs are signed for it.
+	PublicEndpoint string
 }
 
+// defaultRegion is the region of MinIO, the signer of the public endpoint is told it,
+// so that it doesn't ask the endpoint the server may not reach.
+const defaultRegion = "us-east-1"
+
+// jpegForm is the suffix of the picture names kept from the times all the pictures were JPEG,
+// the existing objects are found by it. The real type is in the Content-Type of the object.
+// Only the pictures and their variants are named without an extension, the objects of the other types
+// carry their own one and are kept under their name.
 const jpegForm = ".jpeg"
 
+func objectKey(objectName string) string {
+	if path.Ext(objectName) != "" {
+		return objectName
+	}
+	return objectName + jpegForm
+}
+
 func New(endpoint string, options Options) (Client, error) {
 	opts := &minio.Options{
 		Creds:  credentials.NewStaticV4(options.AccessKeyID, options.SecretAccessKey, ""),
 		Secure: options.UseSSL,
 	}
 	cl, err := minio.New(endpoint, opts)
-	return &storageClient{cl: cl}, err
+	if err != nil || options.PublicEndpoint == "" {
+		return &storageClient{cl: cl, signer: cl}, err
+	}
+	signer, err := minio.New(options.PublicEndpoint, &minio.Options{
+		Creds:  opts.Creds,
+		Secure: options.UseSSL,
+		Region: defaultRegion,
+	})
+	return &storageClient{cl: cl, signer: signer}, err
 }
 
-func (sc *storageClient) GetObject(ctx context.Context, bucketName string, objectName string) ([]byte, error) {
-	objectName += jpegForm
+// GetObject reads the object lazily, ctx has to outlive the reading.
+func (sc *storageClient) GetObject(ctx context.Context, bucketName string, objectName string) (domain.Object, error) {
+	objectName = objectKey(objectName)
 	obj, err := sc.cl.GetObject(ctx, bucketName, objectName, minio.GetObjectOptions{})
 	if err != nil {
-		return nil, err
+		return domain.Object{}, notFound(err)
+	}
+	info, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		return domain.Object{}, notFound(err)
 	}
-	fileBytes, err := io.ReadAll(obj)
-	return fileBytes, err
+	return domain.Object{ReadSeekCloser: obj, ObjectInfo: objectInfo(objectName, info)}, nil
+}
+
+func (sc *storageClient) StatObject(
+	ctx context.Context,
+	bucketName string,
+	objectName string,
+) (domain.ObjectInfo, error) {
+	objectName = objectKey(objectName)
+	info, err := sc.cl.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return domain.ObjectInfo{}, notFound(err)
+	}
+	return objectInfo(objectName, info), nil
 }
 
 func (sc *storageClient) PutObject(
@@ -53,14 +133,16 @@ func (sc *storageClient) PutObject(
 	objectName string,
 	reader io.Reader,
 	objectSize int64,
+	contentType string,
 ) error {
-	objectName += jpegForm
-	_, err := sc.cl.PutObject(ctx, bucketName, objectName, reader, objectSize, minio.PutObjectOptions{})
+	objectName = objectKey(objectName)
+	opts := minio.PutObjectOptions{ContentType: contentType}
+	_, err := sc.cl.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
 	return err
 }
 
 func (sc *storageClient) RemoveObject(ctx context.Context, bucketName string, objectName string) error {
-	objectName += jpegForm
+	objectName = objectKey(objectName)
 	return sc.cl.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{})
 }
 
@@ -71,3 +153,63 @@ func (sc *storageClient) BucketExists(ctx context.Context, bucketName string) (b
 func (sc *storageClient) MakeBucket(ctx context.Context, bucketName string) error {
 	return sc.cl.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{})
 }
+
+func (sc *storageClient) PresignPost(
+	ctx context.Context,
+	bucketName string,
+	objectName string,
+	expires time.Duration,
+	maxSize int64,
+) (string, map[string]string, error) {
+	objectName = objectKey(objectName)
+	policy := minio.NewPostPolicy()
+	err := errors.Join(
+		policy.SetBucket(bucketName),
+		policy.SetKey(objectName),
+		policy.SetExpires(time.Now().UTC().Add(expires)),
+		policy.SetContentLengthRange(1, maxSize),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+	u, fields, err := sc.signer.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, err
+	}
+	return u.String(), fields, nil
+}
+
+func (sc *storageClient) PresignGet(
+	ctx context.Context,
+	bucketName string,
+	objectName string,
+	expires time.Duration,
+) (string, error) {
+	objectName = objectKey(objectName)
+	u, err := sc.signer.PresignedGetObject(ctx, bucketName, objectName, expires, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func objectInfo(key string, info minio.ObjectInfo) domain.ObjectInfo {
+	contentType := info.ContentType
+	if (contentType == "" || contentType == "application/octet-stream") && strings.HasSuffix(key, jpegForm) {
+		// the pictures uploaded before the content type was stored are JPEG
+		contentType = "image/jpeg"
+	}
+	return domain.ObjectInfo{
+		Size:         info.Size,
+		ContentType:  contentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}
+}
+
+func notFound(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrObjectNotFound
+	}
+	return err
+}
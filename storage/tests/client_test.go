@@ -0,0 +1,129 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"main/storage"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backends(t *testing.T) map[string]storage.Client {
+	t.Helper()
+	fsClient, err := storage.NewFSClient(filepath.Join(t.TempDir(), "storage"))
+	require.NoError(t, err)
+	return map[string]storage.Client{
+		"memory": storage.NewMemoryClient(),
+		"fs":     fsClient,
+	}
+}
+
+func put(t *testing.T, client storage.Client, bucket string, name string, data []byte) {
+	t.Helper()
+	err := client.PutObject(context.Background(), bucket, name, bytes.NewReader(data), int64(len(data)), "image/jpeg")
+	require.NoError(t, err)
+}
+
+func get(t *testing.T, client storage.Client, bucket string, name string) []byte {
+	t.Helper()
+	object, err := client.GetObject(context.Background(), bucket, name)
+	require.NoError(t, err)
+	defer object.Close()
+	data, err := io.ReadAll(object)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), object.Size)
+	return data
+}
+
+func TestClient(t *testing.T) {
+	for name, client := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			found, err := client.BucketExists(ctx, "pictures")
+			require.NoError(t, err)
+			assert.False(t, found)
+			require.NoError(t, client.MakeBucket(ctx, "pictures"))
+			assert.Error(t, client.MakeBucket(ctx, "pictures"))
+			found, err = client.BucketExists(ctx, "pictures")
+			require.NoError(t, err)
+			assert.True(t, found)
+
+			_, err = client.GetObject(ctx, "pictures", "cat")
+			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+			_, err = client.StatObject(ctx, "pictures", "cat")
+			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+			put(t, client, "pictures", "cat", []byte("meow"))
+			assert.Equal(t, []byte("meow"), get(t, client, "pictures", "cat"))
+			info, err := client.StatObject(ctx, "pictures", "cat")
+			require.NoError(t, err)
+			assert.Equal(t, int64(4), info.Size)
+			assert.Equal(t, "image/jpeg", info.ContentType)
+			assert.NotEmpty(t, info.ETag)
+			assert.False(t, info.LastModified.IsZero())
+
+			// the object is replaced as a whole, with a new ETag
+			put(t, client, "pictures", "cat", []byte("purr"))
+			assert.Equal(t, []byte("purr"), get(t, client, "pictures", "cat"))
+			replaced, err := client.StatObject(ctx, "pictures", "cat")
+			require.NoError(t, err)
+			assert.NotEqual(t, info.ETag, replaced.ETag)
+
+			// the object is seekable for the range requests
+			object, err := client.GetObject(ctx, "pictures", "cat")
+			require.NoError(t, err)
+			_, err = object.Seek(2, io.SeekStart)
+			require.NoError(t, err)
+			rest, err := io.ReadAll(object)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("rr"), rest)
+			require.NoError(t, object.Close())
+
+			err = client.PutObject(ctx, "pictures", "cat", bytes.NewReader([]byte("hiss")), 10, "image/jpeg")
+			assert.Error(t, err, "the data is shorter than the size")
+			assert.Equal(t, []byte("purr"), get(t, client, "pictures", "cat"), "a failed write keeps the object")
+
+			require.NoError(t, client.RemoveObject(ctx, "pictures", "cat"))
+			require.NoError(t, client.RemoveObject(ctx, "pictures", "cat"))
+			_, err = client.GetObject(ctx, "pictures", "cat")
+			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+			err = client.PutObject(ctx, "missing", "cat", bytes.NewReader([]byte("meow")), 4, "image/jpeg")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFSClient_Layout(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	client, err := storage.NewFSClient(root)
+	require.NoError(t, err)
+	require.NoError(t, client.MakeBucket(ctx, "users"))
+
+	data := []byte("picture")
+	put(t, client, "users", "42", data)
+
+	stored, err := os.ReadFile(filepath.Join(root, "users", "42"))
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+	entries, err := os.ReadDir(filepath.Join(root, "users"))
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"42", ".meta"}, names, "no temporary files are left")
+
+	for _, name := range []string{"", "..", "../users", "a/b", ".tmp-42", ".meta"} {
+		err = client.PutObject(ctx, "users", name, bytes.NewReader(data), int64(len(data)), "image/jpeg")
+		assert.Error(t, err, name)
+	}
+	assert.Error(t, client.MakeBucket(ctx, "../outside"))
+}
@@ -0,0 +1,143 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"io"
+	"main/domain"
+	"main/storage"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeJPEG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil))
+	return buf.Bytes()
+}
+
+type picture struct {
+	domain.ObjectInfo
+	// side is the longest side of the picture in pixels
+	side int
+}
+
+func getPicture(t *testing.T, s domain.CollectionStorage, name string, size domain.PictureSize) picture {
+	t.Helper()
+	object, err := s.GetPicture(context.Background(), name, size)
+	require.NoError(t, err)
+	defer object.Close()
+	data, err := io.ReadAll(object)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), object.Size)
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	return picture{ObjectInfo: object.ObjectInfo, side: max(config.Width, config.Height)}
+}
+
+func TestPictureVariants(t *testing.T) {
+	ctx := context.Background()
+	client := storage.NewMemoryClient()
+	require.NoError(t, client.MakeBucket(ctx, "pictures"))
+	pictures := storage.NewCollectionStorage(client, "pictures")
+
+	original := encodeJPEG(t, 1200, 600)
+	require.NoError(t, pictures.PutObject(ctx, "mona", bytes.NewReader(original), int64(len(original)), "image/jpeg"))
+
+	thumb := getPicture(t, pictures, "mona", domain.PictureSizeThumb)
+	assert.Equal(t, 256, thumb.side, "the longest side")
+	assert.Equal(t, "image/jpeg", thumb.ContentType)
+	medium := getPicture(t, pictures, "mona", domain.PictureSizeMedium)
+	assert.Equal(t, 1024, medium.side)
+	assert.Equal(t, 1200, getPicture(t, pictures, "mona", domain.PictureSizeOriginal).side)
+	assert.Equal(t, thumb.ETag, getPicture(t, pictures, "mona", domain.PictureSizeThumb).ETag, "the thumb is kept")
+
+	// the variants of a replaced picture are made again
+	replaced := encodeJPEG(t, 300, 900)
+	require.NoError(t, pictures.PutObject(ctx, "mona", bytes.NewReader(replaced), int64(len(replaced)), "image/jpeg"))
+	assert.Equal(t, 256, getPicture(t, pictures, "mona", domain.PictureSizeThumb).side)
+	assert.Equal(t, 900, getPicture(t, pictures, "mona", domain.PictureSizeMedium).side, "it is small already")
+	info, err := pictures.StatObject(ctx, "mona")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(replaced)), info.Size, "the original stays as it is")
+
+	// the variants go with the original
+	_, err = client.StatObject(ctx, "pictures", "mona_thumb_"+info.ETag)
+	require.NoError(t, err, "the variant is kept next to the original")
+	require.NoError(t, pictures.RemoveObject(ctx, "mona"))
+	_, err = client.StatObject(ctx, "pictures", "mona_thumb_"+info.ETag)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = pictures.GetPicture(ctx, "mona", domain.PictureSizeThumb)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+// presigningClient presigns the URLs as the object names, the way to the object is up to MinIO.
+type presigningClient struct {
+	storage.Client
+}
+
+func (presigningClient) PresignPost(
+	_ context.Context,
+	bucket string,
+	objectName string,
+	_ time.Duration,
+	maxSize int64,
+) (string, map[string]string, error) {
+	return "post:" + bucket, map[string]string{"key": objectName, "max": strconv.FormatInt(maxSize, 10)}, nil
+}
+
+func (presigningClient) PresignGet(
+	_ context.Context,
+	bucket string,
+	objectName string,
+	_ time.Duration,
+) (string, error) {
+	return "get:" + bucket + "/" + objectName, nil
+}
+
+func TestPictureVariants_Presign(t *testing.T) {
+	ctx := context.Background()
+	client := presigningClient{Client: storage.NewMemoryClient()}
+	require.NoError(t, client.MakeBucket(ctx, "pictures"))
+	pictures := storage.NewCollectionStorage(client, "pictures")
+
+	original := encodeJPEG(t, 1200, 600)
+	require.NoError(t, pictures.PutObject(ctx, "mona", bytes.NewReader(original), int64(len(original)), "image/jpeg"))
+	small := encodeJPEG(t, 100, 100)
+	require.NoError(t, pictures.PutObject(ctx, "tiny", bytes.NewReader(small), int64(len(small)), "image/jpeg"))
+	info, err := pictures.StatObject(ctx, "mona")
+	require.NoError(t, err)
+
+	url, err := pictures.PresignGet(ctx, "mona", domain.PictureSizeOriginal, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "get:pictures/mona", url)
+	url, err = pictures.PresignGet(ctx, "mona", domain.PictureSizeThumb, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "get:pictures/mona_thumb_"+info.ETag, url)
+	_, err = client.StatObject(ctx, "pictures", "mona_thumb_"+info.ETag)
+	require.NoError(t, err, "the variant is made for the URL")
+	url, err = pictures.PresignGet(ctx, "tiny", domain.PictureSizeMedium, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "get:pictures/tiny", url, "the small picture is the variant itself")
+	_, err = pictures.PresignGet(ctx, "nobody", domain.PictureSizeThumb, time.Minute)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	url, fields, err := storage.NewUploadStorage(client, "uploads").PresignPost(ctx, "upload", time.Minute, 42)
+	require.NoError(t, err)
+	assert.Equal(t, "post:uploads", url)
+	assert.Equal(t, map[string]string{"key": "upload", "max": "42"}, fields)
+
+	// the other backends go through the server
+	memory := storage.NewMemoryClient()
+	_, err = storage.NewUserStorage(memory, "pictures").PresignGet(ctx, "mona", domain.PictureSizeThumb, time.Minute)
+	assert.ErrorIs(t, err, storage.ErrPresignNotSupported)
+	_, _, err = storage.NewUploadStorage(memory, "uploads").PresignPost(ctx, "upload", time.Minute, 42)
+	assert.ErrorIs(t, err, storage.ErrPresignNotSupported)
+}
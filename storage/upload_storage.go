@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"main/domain"
+	"time"
+)
+
+type uploadStorage struct {
+	storage Client
+	bucket  string
+}
+
+func (us *uploadStorage) GetObject(c context.Context, objectName string) (domain.Object, error) {
+	return us.storage.GetObject(c, us.bucket, objectName)
+}
+
+func (us *uploadStorage) StatObject(c context.Context, objectName string) (domain.ObjectInfo, error) {
+	return us.storage.StatObject(c, us.bucket, objectName)
+}
+
+func (us *uploadStorage) PutObject(
+	c context.Context,
+	objectName string,
+	reader io.Reader,
+	objectSize int64,
+	contentType string,
+) error {
+	return us.storage.PutObject(c, us.bucket, objectName, reader, objectSize, contentType)
+}
+
+func (us *uploadStorage) RemoveObject(c context.Context, objectName string) error {
+	return us.storage.RemoveObject(c, us.bucket, objectName)
+}
+
+// PresignPost returns the short-lived URL and the form fields the client posts the object with,
+// ErrPresignNotSupported if the backend can't presign.
+func (us *uploadStorage) PresignPost(
+	c context.Context,
+	objectName string,
+	expires time.Duration,
+	maxSize int64,
+) (string, map[string]string, error) {
+	presigner, ok := us.storage.(Presigner)
+	if !ok {
+		return "", nil, ErrPresignNotSupported
+	}
+	return presigner.PresignPost(c, us.bucket, objectName, expires, maxSize)
+}
+
+func NewUploadStorage(s Client, bucket string) domain.UploadStorage {
+	return &uploadStorage{
+		storage: s,
+		bucket:  bucket,
+	}
+}
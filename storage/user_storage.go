@@ -4,28 +4,68 @@ import (
 	"context"
 	"io"
 	"main/domain"
+	"time"
 )
 
 type userStorage struct {
-	storage Client
-	bucket  string
+	storage  Client
+	bucket   string
+	variants pictureVariants
 }
 
-func (us *userStorage) GetObject(c context.Context, objectName string) ([]byte, error) {
+func (us *userStorage) GetObject(c context.Context, objectName string) (domain.Object, error) {
 	return us.storage.GetObject(c, us.bucket, objectName)
 }
 
-func (us *userStorage) PutObject(c context.Context, objectName string, reader io.Reader, objectSize int64) error {
-	return us.storage.PutObject(c, us.bucket, objectName, reader, objectSize)
+// GetPicture returns the variant of the picture, it is made on the first request.
+func (us *userStorage) GetPicture(
+	c context.Context,
+	objectName string,
+	size domain.PictureSize,
+) (domain.Object, error) {
+	return us.variants.get(c, objectName, size)
+}
+
+// PresignGet returns the short-lived URL of the variant of the picture, it is made if there is none yet.
+func (us *userStorage) PresignGet(
+	c context.Context,
+	objectName string,
+	size domain.PictureSize,
+	expires time.Duration,
+) (string, error) {
+	return us.variants.presign(c, objectName, size, expires)
+}
+
+func (us *userStorage) StatObject(c context.Context, objectName string) (domain.ObjectInfo, error) {
+	return us.storage.StatObject(c, us.bucket, objectName)
+}
+
+func (us *userStorage) PutObject(
+	c context.Context,
+	objectName string,
+	reader io.Reader,
+	objectSize int64,
+	contentType string,
+) error {
+	err := us.variants.remove(c, objectName)
+	if err != nil {
+		return err
+	}
+	return us.storage.PutObject(c, us.bucket, objectName, reader, objectSize, contentType)
 }
 
 func (us *userStorage) RemoveObject(c context.Context, objectName string) error {
+	err := us.variants.remove(c, objectName)
+	if err != nil {
+		return err
+	}
 	return us.storage.RemoveObject(c, us.bucket, objectName)
 }
 
 func NewUserStorage(s Client, bucket string) domain.UserStorage {
 	return &userStorage{
-		storage: s,
-		bucket:  bucket,
+		storage:  s,
+		bucket:   bucket,
+		variants: pictureVariants{storage: s, bucket: bucket},
 	}
 }
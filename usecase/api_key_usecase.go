@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"main/domain"
+	"main/internal"
+	"slices"
+	"time"
+)
+
+const (
+	apiKeyPrefix       = "tp_"
+	apiKeyShownPrefix  = 11
+	apiKeyLastUsedStep = time.Minute
+)
+
+type apiKeyUseCase struct {
+	apiKeyRepository domain.ApiKeyRepository
+	contextTimeout   time.Duration
+}
+
+func NewApiKeyUseCase(apiKeyRepository domain.ApiKeyRepository, timeout time.Duration) domain.ApiKeyUseCase {
+	return &apiKeyUseCase{
+		apiKeyRepository: apiKeyRepository,
+		contextTimeout:   timeout,
+	}
+}
+
+func (au *apiKeyUseCase) Create(
+	c context.Context,
+	userID string,
+	request *domain.CreateApiKeyRequest,
+) (domain.CreateApiKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	if request.Name == "" || len(request.Scopes) == 0 || request.ExpiresInDays < 0 {
+		return domain.CreateApiKeyResponse{}, domain.ErrInvalidData
+	}
+	for _, scope := range request.Scopes {
+		if !slices.Contains(domain.ApiKeyScopes, scope) {
+			return domain.CreateApiKeyResponse{}, domain.ErrInvalidData.WithDetails(domain.FieldError{
+				Field:   "scopes",
+				Code:    "unknown_scope",
+				Message: "unknown scope " + scope,
+			})
+		}
+	}
+
+	secret, err := internal.GenerateSecretToken()
+	if err != nil {
+		return domain.CreateApiKeyResponse{}, err
+	}
+	key := apiKeyPrefix + secret
+
+	now := time.Now()
+	apiKey := domain.ApiKey{
+		UserID:    userID,
+		Name:      request.Name,
+		Prefix:    key[:apiKeyShownPrefix],
+		Hash:      internal.HashSecretToken(key),
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(request.Scopes))),
+		CreatedAt: now.Unix(),
+	}
+	if request.ExpiresInDays > 0 {
+		apiKey.ExpiresAt = now.AddDate(0, 0, request.ExpiresInDays).Unix()
+	}
+
+	_, err = au.apiKeyRepository.Create(ctx, &apiKey)
+	if err != nil {
+		return domain.CreateApiKeyResponse{}, err
+	}
+	return domain.CreateApiKeyResponse{Key: key, ApiKey: apiKey}, nil
+}
+
+func (au *apiKeyUseCase) GetByUserID(c context.Context, userID string) ([]domain.ApiKey, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+	return au.apiKeyRepository.GetByUserID(ctx, userID)
+}
+
+func (au *apiKeyUseCase) Delete(c context.Context, userID string, apiKeyID string) error {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	deleted, err := au.apiKeyRepository.DeleteByID(ctx, userID, apiKeyID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrApiKeyNotFound
+	}
+	return nil
+}
+
+func (au *apiKeyUseCase) Authenticate(c context.Context, key string) (domain.ApiKey, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	invalid := domain.ErrInvalidApiKey
+	if len(key) <= apiKeyShownPrefix {
+		return domain.ApiKey{}, invalid
+	}
+
+	apiKey, err := au.apiKeyRepository.GetByHash(ctx, internal.HashSecretToken(key))
+	if err != nil {
+		return domain.ApiKey{}, invalid
+	}
+
+	now := time.Now()
+	if apiKey.ExpiresAt != 0 && apiKey.ExpiresAt <= now.Unix() {
+		return domain.ApiKey{}, domain.ErrApiKeyExpired
+	}
+
+	// last use is only needed roughly, so scripts hammering the API
+	// don't turn every read into a write
+	if now.Sub(time.Unix(apiKey.LastUsedAt, 0)) >= apiKeyLastUsedStep {
+		err = au.apiKeyRepository.UpdateLastUsed(ctx, apiKey.ID, now.Unix())
+		if err != nil {
+			return domain.ApiKey{}, err
+		}
+		apiKey.LastUsedAt = now.Unix()
+	}
+	return apiKey, nil
+}
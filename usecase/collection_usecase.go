@@ -1,34 +1,43 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"main/database"
 	"main/domain"
+	"main/internal"
 	"main/repository"
+	"main/storage"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type collectionUseCase struct {
 	collectionRepository domain.CollectionRepository
 	collectionStorage    domain.CollectionStorage
+	mediaRepository      domain.MediaRepository
 	userRepository       domain.UserRepository
 	contextTimeout       time.Duration
 }
 
 func NewCollectionUseCase(
 	collectionRepository domain.CollectionRepository, collectionStorage domain.CollectionStorage,
-	userRepository domain.UserRepository, timeout time.Duration,
+	mediaRepository domain.MediaRepository, userRepository domain.UserRepository, timeout time.Duration,
 ) domain.CollectionUseCase {
 	return &collectionUseCase{
 		collectionRepository: collectionRepository,
 		collectionStorage:    collectionStorage,
+		mediaRepository:      mediaRepository,
 		userRepository:       userRepository,
 		contextTimeout:       timeout,
 	}
@@ -86,7 +95,7 @@ func (cu *collectionUseCase) PutByID(c context.Context, collectionID string, col
 	}
 
 	if res.MatchedCount == 0 {
-		return errors.New("collection not exists")
+		return domain.ErrCollectionNotFound
 	}
 	return nil
 }
@@ -118,7 +127,7 @@ func (cu *collectionUseCase) AddLike(
 			return nil, err
 		}
 		if res.MatchedCount == 0 {
-			return nil, errors.New("collection not exists")
+			return nil, domain.ErrCollectionNotFound
 		}
 
 		return true, nil
@@ -173,7 +182,7 @@ func (cu *collectionUseCase) RemoveLike(
 		}
 
 		if res.MatchedCount == 0 {
-			return nil, errors.New("collection not exists")
+			return nil, domain.ErrCollectionNotFound
 		}
 		return true, nil
 	})
@@ -201,30 +210,30 @@ func (cu *collectionUseCase) DeleteByID(c context.Context, collectionID, userID
 
 	defer session.EndSession(ctx)
 
+	var coll domain.Collection
 	_, err = session.WithTransaction(ctx, func(transactionCtx context.Context) (interface{}, error) {
 		err = cu.userRepository.DeleteCollection(transactionCtx, userID, collectionID, "collections")
 		if err != nil {
 			return nil, err
 		}
-		var coll domain.Collection
 		coll, err = cu.GetByID(transactionCtx, collectionID)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, elem := range coll.Cards {
-			if elem.Attachment != "" {
-				err = cu.RemoveCardPicture(transactionCtx, userID, collectionID, elem.LocalID, elem.Attachment)
-				if err != nil && err.Error() != "card picture not exists" {
-					return nil, err
-				}
-			}
-		}
-
 		return nil, cu.collectionRepository.DeleteByID(transactionCtx, collectionID)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	// the files are released once the collection is gone: the storage is not part of the transaction,
+	// and the retries of the transaction would release them more than once
+	var errs []error
+	for _, card := range coll.Cards {
+		errs = append(errs, cu.releaseCardFiles(ctx, userID, card))
+	}
+	return errors.Join(errs...)
 }
 
 func (cu *collectionUseCase) GetByID(c context.Context, collectionID string) (domain.Collection, error) {
@@ -326,6 +335,10 @@ func (cu *collectionUseCase) AddCard(c context.Context, collectionID string, car
 	}
 
 	card.LocalID = collection.MaxID
+	// the attachments and the audio are added by their upload only, so that they are charged to the limits
+	card.Attachments = make([]domain.CardAttachment, 0)
+	card.LegacyAttachment = ""
+	card.QuestionAudio, card.AnswerAudio = "", ""
 	if card.OtherAnswers.Items == nil {
 		card.OtherAnswers.Items = make([]string, 0)
 	}
@@ -342,7 +355,7 @@ func (cu *collectionUseCase) AddCard(c context.Context, collectionID string, car
 		LocalID:      card.LocalID,
 		Question:     card.Question,
 		Answer:       card.Answer,
-		Attachment:   card.Attachment,
+		Attachments:  card.Attachments,
 		OtherAnswers: card.OtherAnswers,
 	}
 
@@ -350,7 +363,17 @@ func (cu *collectionUseCase) AddCard(c context.Context, collectionID string, car
 	return answer, err
 }
 
-func (cu *collectionUseCase) DeleteCard(c context.Context, collectionID string, cardLocalID int) error {
+// DeleteCard deletes the card with its attachments and audio, as DeleteByID does.
+func (cu *collectionUseCase) DeleteCard(c context.Context, userID string, collectionID string, cardLocalID int) error {
+	card, err := cu.getCard(c, collectionID, cardLocalID)
+	if err != nil {
+		return err
+	}
+	err = cu.removeCardFiles(c, userID, collectionID, card)
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
 	defer cancel()
 
@@ -366,7 +389,7 @@ func (cu *collectionUseCase) DeleteCard(c context.Context, collectionID string,
 		return err
 	}
 	if res.ModifiedCount == 0 {
-		return errors.New("card not exists")
+		return domain.ErrCardNotFound
 	}
 
 	return nil
@@ -398,115 +421,675 @@ func (cu *collectionUseCase) UpdateCard(c context.Context, collectionID string,
 	}
 
 	if res.MatchedCount == 0 {
-		return errors.New("card not exists")
+		return domain.ErrCardNotFound
 	}
 	return nil
 }
 
-func (cu *collectionUseCase) GetCardPhoto(c context.Context, objectName string) ([]byte, error) {
+// GetCardPhoto opens the variant of the picture, see domain.Object.
+func (cu *collectionUseCase) GetCardPhoto(
+	c context.Context,
+	objectName string,
+	size domain.PictureSize,
+) (domain.Object, error) {
+	return cu.collectionStorage.GetPicture(c, objectName, size)
+}
+
+// GetCardPhotoURL presigns the URL of the variant of the picture, the URL is empty and Proxy is set
+// if the storage can't presign.
+func (cu *collectionUseCase) GetCardPhotoURL(
+	c context.Context,
+	objectName string,
+	size domain.PictureSize,
+) (domain.PresignedURL, error) {
 	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
 	defer cancel()
+	return presignedURL(cu.collectionStorage.PresignGet(ctx, objectName, size, domain.PresignExpiry))
+}
+
+// AddCardAttachment stores the picture and adds it after the other attachments of the card.
+func (cu *collectionUseCase) AddCardAttachment(
+	c context.Context,
+	userID string,
+	collectionID string,
+	cardID int,
+	side domain.CardSide,
+	picture io.Reader,
+	size int64,
+	contentType string,
+) (domain.CardAttachment, error) {
+	media, data, err := readMedia(picture, size, contentType)
+	if err != nil {
+		return domain.CardAttachment{}, err
+	}
 
-	return cu.collectionStorage.GetObject(ctx, objectName)
+	attachment := domain.CardAttachment{
+		ID:        internal.GenerateUUID(),
+		Side:      side,
+		MediaType: contentType,
+		Size:      media.Size,
+		Object:    media.ID,
+		Owner:     userID,
+	}
+	for {
+		card, err := cu.getCard(c, collectionID, cardID)
+		if err != nil {
+			return domain.CardAttachment{}, err
+		}
+		if len(card.Attachments) >= domain.MaxCardAttachments {
+			return domain.CardAttachment{}, domain.ErrTooManyAttachments
+		}
+		attachment.Order = 0
+		for _, other := range card.Attachments {
+			attachment.Order = max(attachment.Order, other.Order+1)
+		}
+
+		// the limit and the order are checked again by the update,
+		// it misses the card if another attachment was added meanwhile and is tried again
+		cardFilter := bson.D{
+			{Key: "local_id", Value: cardID},
+			{Key: "attachments." + strconv.Itoa(domain.MaxCardAttachments-1), Value: bson.D{
+				{Key: "$exists", Value: false},
+			}},
+			{Key: "attachments.order", Value: bson.D{{Key: "$ne", Value: attachment.Order}}},
+		}
+		update := bson.D{
+			{Key: "$push", Value: bson.D{
+				{Key: "cards.$.attachments", Value: attachment},
+			}},
+		}
+		err = cu.putCardFile(c, userID, collectionID, cardFilter, media, data, update)
+		if errors.Is(err, errCardChanged) {
+			continue
+		}
+		if err != nil {
+			return domain.CardAttachment{}, err
+		}
+		return attachment, nil
+	}
 }
 
-func (cu *collectionUseCase) UploadCardPhoto(
-	c context.Context, userID string, collectionID string, cardID int, picture io.Reader, size int64,
-) (string, error) {
+func (cu *collectionUseCase) RemoveCardAttachment(
+	c context.Context,
+	userID, collectionID string,
+	cardID int,
+	attachmentID string,
+) error {
+	card, err := cu.getCard(c, collectionID, cardID)
+	if err != nil {
+		return err
+	}
+	// only the attachments of the card are removed, the id comes from the client
+	attachment, ok := card.Attachment(attachmentID)
+	if !ok {
+		return domain.ErrCardPictureNotFound
+	}
+
+	update := bson.D{
+		{Key: "$pull", Value: bson.D{
+			{Key: "cards.$.attachments", Value: bson.D{{Key: "id", Value: attachmentID}}},
+		}},
+	}
+	cardFilter := bson.D{
+		{Key: "local_id", Value: cardID},
+		{Key: "attachments.id", Value: attachmentID},
+	}
+	return cu.removeCardFile(
+		c, fileOwner(attachment.Owner, userID), collectionID, cardFilter, attachment.ObjectName(),
+		domain.ErrCardPictureNotFound, update,
+	)
+}
+
+// ReorderCardAttachments sets the order of the attachments of the card to the order of ids,
+// ids have to be the IDs of all its attachments.
+func (cu *collectionUseCase) ReorderCardAttachments(
+	c context.Context,
+	collectionID string,
+	cardID int,
+	ids []string,
+) ([]domain.CardAttachment, error) {
+	card, err := cu.getCard(c, collectionID, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(card.Attachments) {
+		return nil, domain.InvalidFields("ids")
+	}
+
+	attachments := make([]domain.CardAttachment, 0, len(ids))
+	for i, id := range ids {
+		attachment, ok := card.Attachment(id)
+		if !ok || slices.Contains(ids[:i], id) {
+			return nil, domain.InvalidFields("ids")
+		}
+		attachment.Order = i
+		attachments = append(attachments, attachment)
+	}
+
 	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
 	defer cancel()
 
-	user, err := cu.userRepository.GetByID(ctx, userID)
+	filter := bson.D{
+		{Key: "_id", Value: collectionID},
+		{Key: "cards.local_id", Value: cardID},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "cards.$.attachments", Value: attachments},
+		}},
+	}
+	_, err = cu.collectionRepository.Update(ctx, filter, update)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	return attachments, nil
+}
+
+// MigrateAttachments moves the pictures of the cards stored before the attachments list into the list
+// and returns the number of the moved pictures. It is run on the start and does nothing once they are moved,
+// the pictures that are not in the storage anymore are dropped and the cards without one get an empty list.
+func (cu *collectionUseCase) MigrateAttachments(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
 
-	if user.Limits.TotalFileSize+int(size) > domain.MAX_TOTAL_FILE_SIZE {
-		return "", errors.New("you reached the total file size limit")
+	filter := bson.D{
+		{Key: "cards.attachment", Value: bson.D{
+			{Key: "$exists", Value: true},
+		}},
+	}
+	collections, err := cu.collectionRepository.GetByFilter(ctx, filter, database.FindOptions{})
+	cancel()
+	if err != nil {
+		return 0, err
 	}
 
-	if !slices.Contains(user.Collections, collectionID) {
-		return "", errors.New("access denied")
+	migrated := 0
+	for _, collection := range collections {
+		for _, card := range collection.Cards {
+			if card.LegacyAttachment == "" && card.Attachments != nil {
+				continue
+			}
+			var moved bool
+			moved, err = cu.migrateAttachment(c, collection.ID, card)
+			if err != nil {
+				return migrated, fmt.Errorf("migrate the attachment of card %d of %s: %w", card.LocalID, collection.ID, err)
+			}
+			if moved {
+				migrated++
+			}
+		}
 	}
+	return migrated, nil
+}
+
+func (cu *collectionUseCase) migrateAttachment(c context.Context, collectionID string, card domain.Card) (bool, error) {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
 
-	user.Limits.TotalFileSize += int(size)
+	attachments := make([]domain.CardAttachment, 0, len(card.Attachments)+1)
+	moved := false
+	if card.LegacyAttachment != "" {
+		info, err := cu.collectionStorage.StatObject(ctx, card.LegacyAttachment)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+			return false, err
+		}
+		// the picture was the only one, it goes before the others
+		if err == nil {
+			attachments = append(attachments, domain.CardAttachment{
+				ID:        card.LegacyAttachment,
+				Side:      domain.CardSideQuestion,
+				MediaType: info.ContentType,
+				Size:      info.Size,
+			})
+			moved = true
+		}
+	}
+	for _, attachment := range card.Attachments {
+		if moved {
+			attachment.Order++
+		}
+		attachments = append(attachments, attachment)
+	}
 
+	filter := bson.D{
+		{Key: "_id", Value: collectionID},
+		{Key: "cards.local_id", Value: card.LocalID},
+	}
 	update := bson.D{
 		{Key: "$set", Value: bson.D{
-			{Key: "limits", Value: user.Limits},
+			{Key: "cards.$.attachments", Value: attachments},
 		}},
+		{Key: "$unset", Value: bson.D{
+			{Key: "cards.$.attachment", Value: ""},
+		}},
+	}
+	_, err := cu.collectionRepository.Update(ctx, filter, update)
+	return moved, err
+}
+
+func (cu *collectionUseCase) getCard(c context.Context, collectionID string, cardID int) (domain.Card, error) {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	collection, err := cu.collectionRepository.GetByID(ctx, collectionID)
+	if err != nil {
+		return domain.Card{}, domain.ErrCollectionNotFound
 	}
+	for _, card := range collection.Cards {
+		if card.LocalID == cardID {
+			return card, nil
+		}
+	}
+	return domain.Card{}, domain.ErrCardNotFound
+}
+
+// GetCardAudio opens the audio, see domain.Object.
+func (cu *collectionUseCase) GetCardAudio(c context.Context, objectName string) (domain.Object, error) {
+	return cu.collectionStorage.GetAudio(c, objectName)
+}
 
-	_, err = cu.userRepository.UpdateByID(ctx, userID, update)
+func (cu *collectionUseCase) UploadCardAudio(
+	c context.Context,
+	userID string,
+	collectionID string,
+	cardID int,
+	side domain.CardSide,
+	audio io.Reader,
+	size int64,
+	contentType string,
+) (string, error) {
+	media, data, err := readMedia(audio, size, contentType)
 	if err != nil {
 		return "", err
 	}
-
-	objectName := collectionID + "_" + strconv.Itoa(cardID) + "_" + strconv.FormatInt(time.Now().Unix(), 10)
-	err = cu.collectionStorage.PutObject(ctx, objectName, picture, size)
+	card, err := cu.getCard(c, collectionID, cardID)
 	if err != nil {
 		return "", err
 	}
 
-	filter := bson.D{
-		{Key: "_id", Value: collectionID},
-		{Key: "cards.local_id", Value: cardID},
+	// the audio is swapped only if the previous one is still there, so that it is released once
+	previous := card.Audio(side)
+	var previousFilter interface{} = previous
+	if previous == "" {
+		// the cards stored before the audio have no field
+		previousFilter = bson.D{{Key: "$in", Value: bson.A{"", nil}}}
 	}
-
-	update = bson.D{
+	cardFilter := bson.D{
+		{Key: "local_id", Value: cardID},
+		{Key: audioField(side), Value: previousFilter},
+	}
+	update := bson.D{
 		{Key: "$set", Value: bson.D{
-			{Key: "cards.$.attachment", Value: objectName},
+			{Key: "cards.$." + audioField(side), Value: media.ID},
+			{Key: "cards.$." + audioField(side) + "_owner", Value: userID},
 		}},
 	}
-
-	_, err = cu.collectionRepository.Update(ctx, filter, update)
+	err = cu.putCardFile(c, userID, collectionID, cardFilter, media, data, update)
+	if errors.Is(err, errCardChanged) {
+		return "", domain.ErrCardAudioChanged
+	}
 	if err != nil {
 		return "", err
 	}
+	if previous == "" {
+		return media.ID, nil
+	}
 
-	return objectName, nil
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+	previousMedia, err := cu.mediaRepository.GetByID(ctx, previous)
+	if err != nil {
+		return "", err
+	}
+	err = cu.releaseMedia(ctx, fileOwner(card.AudioOwner(side), userID), previousMedia)
+	if err != nil {
+		return "", err
+	}
+	return media.ID, nil
 }
 
-func (cu *collectionUseCase) RemoveCardPicture(
+func (cu *collectionUseCase) RemoveCardAudio(
 	c context.Context,
 	userID, collectionID string,
 	cardID int,
+	side domain.CardSide,
+	objectName string,
+) error {
+	card, err := cu.getCard(c, collectionID, cardID)
+	if err != nil {
+		return err
+	}
+
+	// the audio could be replaced meanwhile, the new one is left
+	cardFilter := bson.D{
+		{Key: "local_id", Value: cardID},
+		{Key: audioField(side), Value: objectName},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "cards.$." + audioField(side), Value: ""},
+			{Key: "cards.$." + audioField(side) + "_owner", Value: ""},
+		}},
+	}
+	owner := fileOwner(card.AudioOwner(side), userID)
+	return cu.removeCardFile(c, owner, collectionID, cardFilter, objectName, domain.ErrCardAudioNotFound, update)
+}
+
+// releaseCardFiles releases the attachments and the audio of the card that is deleted already.
+func (cu *collectionUseCase) releaseCardFiles(ctx context.Context, userID string, card domain.Card) error {
+	for _, attachment := range card.Attachments {
+		err := cu.releaseCardFile(ctx, fileOwner(attachment.Owner, userID), attachment.ObjectName())
+		if err != nil {
+			return err
+		}
+	}
+	for _, side := range []domain.CardSide{domain.CardSideQuestion, domain.CardSideAnswer} {
+		if card.Audio(side) == "" {
+			continue
+		}
+		err := cu.releaseCardFile(ctx, fileOwner(card.AudioOwner(side), userID), card.Audio(side))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseCardFile releases the reference of the owner to the media or the file stored before Media.
+func (cu *collectionUseCase) releaseCardFile(ctx context.Context, owner, objectName string) error {
+	media, err := cu.mediaRepository.GetByID(ctx, objectName)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		info, statErr := cu.collectionStorage.StatObject(ctx, objectName)
+		if errors.Is(statErr, storage.ErrObjectNotFound) {
+			return nil
+		}
+		if statErr != nil {
+			return statErr
+		}
+		return cu.releaseLegacyFile(ctx, owner, objectName, info.Size)
+	}
+	if err != nil {
+		return err
+	}
+	return cu.releaseMedia(ctx, owner, media)
+}
+
+// removeCardFiles removes the attachments and the audio of the card, the ones removed meanwhile are skipped.
+func (cu *collectionUseCase) removeCardFiles(c context.Context, userID, collectionID string, card domain.Card) error {
+	for _, attachment := range card.Attachments {
+		err := cu.RemoveCardAttachment(c, userID, collectionID, card.LocalID, attachment.ID)
+		if err != nil && !errors.Is(err, domain.ErrCardPictureNotFound) {
+			return err
+		}
+	}
+	for _, side := range []domain.CardSide{domain.CardSideQuestion, domain.CardSideAnswer} {
+		if card.Audio(side) == "" {
+			continue
+		}
+		err := cu.RemoveCardAudio(c, userID, collectionID, card.LocalID, side, card.Audio(side))
+		if err != nil && !errors.Is(err, domain.ErrCardAudioNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileOwner returns the user a file of a card is charged to. The files stored before the owners were kept
+// are charged to the user who removes them, as they were then.
+func fileOwner(owner string, userID string) string {
+	if owner == "" {
+		return userID
+	}
+	return owner
+}
+
+func audioField(side domain.CardSide) string {
+	return string(side) + "_audio"
+}
+
+// readMedia reads the file and returns the Media it is stored as, the ID is the SHA-256 of the content.
+func readMedia(file io.Reader, size int64, contentType string) (domain.Media, []byte, error) {
+	data, err := io.ReadAll(io.LimitReader(file, size))
+	if err != nil {
+		return domain.Media{}, nil, err
+	}
+	sum := sha256.Sum256(data)
+	media := domain.Media{
+		ID:          hex.EncodeToString(sum[:]),
+		Size:        int64(len(data)),
+		ContentType: contentType,
+	}
+	return media, data, nil
+}
+
+// errCardChanged is returned by putCardFile if no card of the collection matches the filter,
+// the reference to the media is released then.
+var errCardChanged = errors.New("the card has changed")
+
+// putCardFile stores the media, adds the reference of the user to it and applies the update to the card
+// that matches cardFilter, the update refers to the card with the positional "$". The size of the media
+// is charged to the limits of the user once, with their first reference to it.
+func (cu *collectionUseCase) putCardFile(
+	c context.Context,
+	userID string,
+	collectionID string,
+	cardFilter bson.D,
+	media domain.Media,
+	data []byte,
+	cardUpdate bson.D,
+) error {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	user, err := cu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(user.Collections, collectionID) {
+		return domain.ErrAccessDenied
+	}
+
+	owned, err := cu.mediaRepository.Acquire(ctx, &media, userID)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		// the limit is checked by the update, so that the concurrent uploads can't exceed it together
+		filter := bson.D{
+			{Key: "_id", Value: userID},
+			{Key: "limits.total_file_size", Value: bson.D{
+				{Key: "$lte", Value: domain.MAX_TOTAL_FILE_SIZE - int(media.Size)},
+			}},
+		}
+		update := bson.D{
+			{Key: "$inc", Value: bson.D{
+				{Key: "limits.total_file_size", Value: media.Size},
+			}},
+		}
+		var res database.UpdateResult
+		res, err = cu.userRepository.Update(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			return cu.dropMedia(ctx, userID, media, domain.ErrFileSizeLimit)
+		}
+	}
+
+	// the object is put even if the media was stored: the last reference could be released
+	// before it was acquired again, and the object removed with it
+	err = cu.putMediaObject(ctx, media, data)
+	if err != nil {
+		return errors.Join(err, cu.releaseMedia(ctx, userID, media))
+	}
+
+	filter := bson.D{
+		{Key: "_id", Value: collectionID},
+		{Key: "cards", Value: bson.D{{Key: "$elemMatch", Value: cardFilter}}},
+	}
+	res, err := cu.collectionRepository.Update(ctx, filter, cardUpdate)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		err = cu.releaseMedia(ctx, userID, media)
+		if err != nil {
+			return err
+		}
+		return errCardChanged
+	}
+	return nil
+}
+
+// dropMedia releases the reference of the user to the media that was not charged to them and returns err,
+// the object is removed if nothing references the media anymore.
+func (cu *collectionUseCase) dropMedia(ctx context.Context, userID string, media domain.Media, err error) error {
+	release, releaseErr := cu.mediaRepository.Release(ctx, media.ID, userID)
+	if releaseErr == nil && release.Unused {
+		releaseErr = cu.purgeMedia(ctx, media)
+	}
+	if releaseErr != nil {
+		return releaseErr
+	}
+	return err
+}
+
+// removeCardFile applies the update to the card that matches cardFilter and releases the reference
+// of the owner to the media, notFound is returned if there is no such file. cardFilter has to match
+// the card only while it has the file, so that the concurrent removals release it once.
+func (cu *collectionUseCase) removeCardFile(
+	c context.Context,
+	owner, collectionID string,
+	cardFilter bson.D,
 	objectName string,
+	notFound error,
+	cardUpdate bson.D,
 ) error {
 	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
 	defer cancel()
 
-	obj, err := cu.collectionStorage.GetObject(ctx, objectName)
+	media, err := cu.mediaRepository.GetByID(ctx, objectName)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return cu.removeLegacyCardFile(ctx, owner, collectionID, cardFilter, objectName, notFound, cardUpdate)
+	}
 	if err != nil {
-		return errors.New("card picture not exists")
+		return err
 	}
 
-	// update card in collection
 	filter := bson.D{
 		{Key: "_id", Value: collectionID},
-		{Key: "cards.local_id", Value: cardID},
+		{Key: "cards", Value: bson.D{{Key: "$elemMatch", Value: cardFilter}}},
 	}
 
-	update := bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "cards.$.attachment", Value: ""},
-		}},
+	res, err := cu.collectionRepository.Update(ctx, filter, cardUpdate)
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount != 1 {
+		return notFound
 	}
+	return cu.releaseMedia(ctx, owner, media)
+}
 
-	_, err = cu.collectionRepository.Update(ctx, filter, update)
+// releaseMedia releases the reference of the user to the media, the size is given back to the limits
+// of the user with their last reference and the object is removed with the last one at all.
+func (cu *collectionUseCase) releaseMedia(ctx context.Context, userID string, media domain.Media) error {
+	release, err := cu.mediaRepository.Release(ctx, media.ID, userID)
+	// the user was never charged for the media
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if release.Disowned {
+		update := bson.D{
+			{Key: "$inc", Value: bson.D{
+				{Key: "limits.total_file_size", Value: -media.Size},
+			}},
+		}
+		_, err = cu.userRepository.UpdateByID(ctx, userID, update)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !release.Unused {
+		return nil
+	}
+	return cu.purgeMedia(ctx, media)
+}
+
+// purgeMedia removes the object of the media released by the last reference, then the media itself,
+// which lets it be acquired again.
+func (cu *collectionUseCase) purgeMedia(ctx context.Context, media domain.Media) error {
+	err := cu.removeMediaObject(ctx, media)
+	return errors.Join(err, cu.mediaRepository.Purge(ctx, media.ID))
+}
+
+// putMediaObject stores the media as a picture or as an audio, the storage keeps them apart.
+func (cu *collectionUseCase) putMediaObject(ctx context.Context, media domain.Media, data []byte) error {
+	if slices.Contains(internal.AudioContentTypes, media.ContentType) {
+		return cu.collectionStorage.PutAudio(ctx, media.ID, bytes.NewReader(data), media.Size, media.ContentType)
+	}
+	return cu.collectionStorage.PutObject(ctx, media.ID, bytes.NewReader(data), media.Size, media.ContentType)
+}
+
+func (cu *collectionUseCase) removeMediaObject(ctx context.Context, media domain.Media) error {
+	if slices.Contains(internal.AudioContentTypes, media.ContentType) {
+		return cu.collectionStorage.RemoveAudio(ctx, media.ID)
+	}
+	return cu.collectionStorage.RemoveObject(ctx, media.ID)
+}
+
+// removeLegacyCardFile removes the file stored before Media under a name of its own: it applies the update
+// to the card, gives the size of the file back to the limits of the user and removes the file.
+func (cu *collectionUseCase) removeLegacyCardFile(
+	c context.Context,
+	userID, collectionID string,
+	cardFilter bson.D,
+	objectName string,
+	notFound error,
+	cardUpdate bson.D,
+) error {
+	ctx, cancel := context.WithTimeout(c, cu.contextTimeout)
+	defer cancel()
+
+	info, err := cu.collectionStorage.StatObject(ctx, objectName)
+	if err != nil {
+		return notFound
+	}
+
+	// update card in collection
+	filter := bson.D{
+		{Key: "_id", Value: collectionID},
+		{Key: "cards", Value: bson.D{{Key: "$elemMatch", Value: cardFilter}}},
+	}
+
+	res, err := cu.collectionRepository.Update(ctx, filter, cardUpdate)
 	if err != nil {
 		return err
 	}
+	if res.ModifiedCount != 1 {
+		return notFound
+	}
 	//
 
+	return cu.releaseLegacyFile(ctx, userID, objectName, info.Size)
+}
+
+// releaseLegacyFile gives the size of the file stored before Media back to the limits of the user
+// and removes the file.
+func (cu *collectionUseCase) releaseLegacyFile(ctx context.Context, userID, objectName string, size int64) error {
 	// update user limits
-	update = bson.D{
+	update := bson.D{
 		{Key: "$inc", Value: bson.D{
-			{Key: "limits.total_file_size", Value: -len(obj)},
+			{Key: "limits.total_file_size", Value: -size},
 		}},
 	}
 
-	_, err = cu.userRepository.UpdateByID(ctx, userID, update)
+	_, err := cu.userRepository.UpdateByID(ctx, userID, update)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"main/domain"
+	"strings"
+	"time"
+
+	"github.com/gookit/slog"
+)
+
+type loginAttemptUseCase struct {
+	loginAttemptRepository domain.LoginAttemptRepository
+	loginAuditRepository   domain.LoginAuditRepository
+	policy                 domain.LoginAttemptPolicy
+	contextTimeout         time.Duration
+}
+
+func NewLoginAttemptUseCase(
+	loginAttemptRepository domain.LoginAttemptRepository,
+	loginAuditRepository domain.LoginAuditRepository,
+	policy domain.LoginAttemptPolicy,
+	timeout time.Duration,
+) domain.LoginAttemptUseCase {
+	return &loginAttemptUseCase{
+		loginAttemptRepository: loginAttemptRepository,
+		loginAuditRepository:   loginAuditRepository,
+		policy:                 policy,
+		contextTimeout:         timeout,
+	}
+}
+
+func emailAttemptKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+func userAttemptKey(userID string) string {
+	return "user:" + userID
+}
+
+// Reserve counts the attempt before the password is compared, so that the concurrent attempts
+// can't all pass the check before any of them has failed. Fail keeps the count, Succeed gives it back.
+func (lu *loginAttemptUseCase) Reserve(c context.Context, email string, ip string) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	emailKey := emailAttemptKey(email)
+	err := lu.reserve(ctx, emailKey, lu.policy.FreeAttempts)
+	if err != nil {
+		return err
+	}
+
+	err = lu.reserve(ctx, ipAttemptKey(ip), lu.policy.IPFreeAttempts)
+	if err != nil {
+		if err := lu.loginAttemptRepository.Release(ctx, emailKey); err != nil {
+			slog.Warnf("Can't release the login attempt of %s: %v", email, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// maxReserveTries bounds how many times the reservation is retried when other attempts are counted in between.
+const maxReserveTries = 5
+
+func (lu *loginAttemptUseCase) reserve(c context.Context, key string, free int) error {
+	for range maxReserveTries {
+		now := time.Now()
+		attempt, err := lu.loginAttemptRepository.Get(c, key)
+		if err != nil {
+			return err
+		}
+		if now.Before(attempt.LockedUntil) {
+			return domain.ErrAccountLocked.WithRetryAfter(attempt.LockedUntil.Sub(now))
+		}
+		if retryAfter := lu.backoff(attempt, free, now); retryAfter > 0 {
+			return domain.ErrTooManyLoginAttempts.WithRetryAfter(retryAfter)
+		}
+
+		_, ok, err := lu.loginAttemptRepository.Reserve(c, key, attempt.Failures, now, lu.policy.Window)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return domain.ErrTooManyLoginAttempts.WithRetryAfter(time.Second)
+}
+
+// backoff returns how long the client has to wait before the next attempt:
+// the delay doubles with every failure after the free ones.
+func (lu *loginAttemptUseCase) backoff(attempt domain.LoginAttempt, free int, now time.Time) time.Duration {
+	n := attempt.Failures - free
+	if n <= 0 || lu.policy.BackoffBase <= 0 {
+		return 0
+	}
+
+	delay := lu.policy.BackoffMax
+	if n <= 30 {
+		delay = min(lu.policy.BackoffBase<<(n-1), lu.policy.BackoffMax)
+	}
+	next := attempt.LastFailure.Add(delay)
+	if !now.Before(next) {
+		return 0
+	}
+	return next.Sub(now)
+}
+
+// Fail starts the backoff from the time the attempt has failed, locks the email once the reserved
+// attempts reach the threshold and writes the audit record. The failure is already counted by Reserve,
+// so the limits hold even if the audit can't be written; the audit is best-effort and only logged on error.
+func (lu *loginAttemptUseCase) Fail(c context.Context, audit *domain.LoginAudit) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	err := lu.loginAttemptRepository.Touch(ctx, ipAttemptKey(audit.IP), now)
+	if err != nil {
+		return err
+	}
+
+	err = lu.fail(ctx, emailAttemptKey(audit.Email), now)
+	if err != nil {
+		return err
+	}
+
+	audit.CreatedAt = now.Unix()
+	if _, err := lu.loginAuditRepository.Create(ctx, audit); err != nil {
+		slog.Warnf("Can't write the login audit of %s: %v", audit.Email, err)
+	}
+	return nil
+}
+
+// Succeed forgets the failures of the email. Only the reserved attempt is given back to the IP counter,
+// so that one valid account can't be used to reset the limit for guessing others.
+func (lu *loginAttemptUseCase) Succeed(c context.Context, email string, ip string) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	err := lu.loginAttemptRepository.Release(ctx, ipAttemptKey(ip))
+	if err != nil {
+		return err
+	}
+	return lu.loginAttemptRepository.Reset(ctx, emailAttemptKey(email))
+}
+
+// fail starts the backoff of the key from now and locks it once the reserved attempts reach the threshold.
+func (lu *loginAttemptUseCase) fail(c context.Context, key string, now time.Time) error {
+	err := lu.loginAttemptRepository.Touch(c, key, now)
+	if err != nil {
+		return err
+	}
+	attempt, err := lu.loginAttemptRepository.Get(c, key)
+	if err != nil {
+		return err
+	}
+	if lu.policy.LockoutThreshold > 0 && attempt.Failures >= lu.policy.LockoutThreshold {
+		return lu.loginAttemptRepository.Lock(c, key, now.Add(lu.policy.LockoutDuration))
+	}
+	return nil
+}
+
+// ReserveCode counts the code attempt of the user the same way as a login, so that a stolen access token
+// can't be used to guess the code and turn the two-factor authentication off.
+func (lu *loginAttemptUseCase) ReserveCode(c context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+	return lu.reserve(ctx, userAttemptKey(userID), lu.policy.FreeAttempts)
+}
+
+func (lu *loginAttemptUseCase) FailCode(c context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+	return lu.fail(ctx, userAttemptKey(userID), time.Now())
+}
+
+func (lu *loginAttemptUseCase) SucceedCode(c context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+	return lu.loginAttemptRepository.Reset(ctx, userAttemptKey(userID))
+}
@@ -8,14 +8,18 @@ import (
 )
 
 type loginUseCase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository    domain.UserRepository
+	sessionRepository domain.SessionRepository
+	contextTimeout    time.Duration
 }
 
-func NewLoginUseCase(userRepository domain.UserRepository, timeout time.Duration) domain.LoginUseCase {
+func NewLoginUseCase(
+	userRepository domain.UserRepository, sessionRepository domain.SessionRepository, timeout time.Duration,
+) domain.LoginUseCase {
 	return &loginUseCase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		contextTimeout:    timeout,
 	}
 }
 
@@ -25,12 +29,24 @@ func (lu *loginUseCase) GetUserByEmail(c context.Context, email string) (domain.
 	return lu.userRepository.GetByEmail(ctx, email)
 }
 
-func (lu *loginUseCase) CreateAccessToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
-	accessToken, exp, err := internal.CreateAccessToken(user, secret, expiry)
+func (lu *loginUseCase) CreateAccessToken(
+	user *domain.User,
+	sessionID string,
+	keySet domain.KeySet,
+	expiry int,
+) (string, time.Time, error) {
+	accessToken, exp, err := internal.CreateAccessToken(user, sessionID, keySet, expiry)
 	return accessToken, exp, err
 }
 
-func (lu *loginUseCase) CreateRefreshToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
-	refreshToken, exp, err := internal.CreateRefreshToken(user, secret, expiry)
-	return refreshToken, exp, err
+func (lu *loginUseCase) CreateRefreshToken(
+	c context.Context,
+	user *domain.User,
+	session *domain.Session,
+	secret string,
+	expiry int,
+) (string, time.Time, error) {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+	return createSession(ctx, lu.sessionRepository, user, session, secret, expiry)
 }
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"main/domain"
+	"main/internal"
+	"main/oauth"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gookit/slog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type oauthUseCase struct {
+	userRepository       domain.UserRepository
+	identityRepository   domain.IdentityRepository
+	oauthStateRepository domain.OAuthStateRepository
+	providers            oauth.Providers
+	contextTimeout       time.Duration
+}
+
+func NewOAuthUseCase(
+	userRepository domain.UserRepository,
+	identityRepository domain.IdentityRepository,
+	oauthStateRepository domain.OAuthStateRepository,
+	providers oauth.Providers,
+	timeout time.Duration,
+) domain.OAuthUseCase {
+	return &oauthUseCase{
+		userRepository:       userRepository,
+		identityRepository:   identityRepository,
+		oauthStateRepository: oauthStateRepository,
+		providers:            providers,
+		contextTimeout:       timeout,
+	}
+}
+
+func (ou *oauthUseCase) Start(
+	c context.Context,
+	provider string,
+	linkUserID string,
+	expiry int,
+) (domain.OAuthStartResponse, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	p, ok := ou.providers[provider]
+	if !ok {
+		return domain.OAuthStartResponse{}, domain.ErrUnknownProvider
+	}
+
+	state, err := internal.GenerateSecretToken()
+	if err != nil {
+		return domain.OAuthStartResponse{}, err
+	}
+	verifier, err := internal.GenerateSecretToken()
+	if err != nil {
+		return domain.OAuthStartResponse{}, err
+	}
+
+	now := time.Now()
+	_, err = ou.oauthStateRepository.Create(ctx, &domain.OAuthState{
+		ID:           internal.HashSecretToken(state),
+		Provider:     provider,
+		CodeVerifier: verifier,
+		LinkUserID:   linkUserID,
+		CreatedAt:    now.Unix(),
+		ExpiresAt:    now.Add(time.Duration(expiry) * time.Minute).Unix(),
+	})
+	if err != nil {
+		return domain.OAuthStartResponse{}, err
+	}
+
+	return domain.OAuthStartResponse{
+		AuthorizationURL: p.AuthCodeURL(state, internal.PKCEChallenge(verifier)),
+		State:            state,
+	}, nil
+}
+
+func (ou *oauthUseCase) Complete(
+	c context.Context,
+	provider string,
+	code string,
+	state string,
+	extra url.Values,
+) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	p, ok := ou.providers[provider]
+	if !ok {
+		return domain.User{}, domain.ErrUnknownProvider
+	}
+
+	oauthState, err := ou.consumeState(ctx, provider, state)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	info, err := p.Exchange(ctx, code, oauthState.CodeVerifier, extra)
+	if err != nil {
+		slog.Warnf("OAuth exchange with %s failed: %v", provider, err)
+		return domain.User{}, domain.ErrOAuthExchange
+	}
+
+	identityID := provider + ":" + info.Subject
+	identity, err := ou.identityRepository.GetByID(ctx, identityID)
+	switch {
+	case err == nil:
+		if oauthState.LinkUserID != "" && oauthState.LinkUserID != identity.UserID {
+			return domain.User{}, domain.ErrIdentityLinked
+		}
+		return ou.userRepository.GetByID(ctx, identity.UserID)
+	case !errors.Is(err, mongo.ErrNoDocuments):
+		return domain.User{}, err
+	}
+
+	user, err := ou.findUser(ctx, oauthState.LinkUserID, &info)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	_, err = ou.identityRepository.Create(ctx, &domain.Identity{
+		ID:        identityID,
+		UserID:    user.ID,
+		Provider:  provider,
+		Subject:   info.Subject,
+		Email:     info.Email,
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+func (ou *oauthUseCase) GetIdentities(c context.Context, userID string) ([]domain.Identity, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+	return ou.identityRepository.GetByUserID(ctx, userID)
+}
+
+func (ou *oauthUseCase) Unlink(c context.Context, userID string, provider string) error {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	user, err := ou.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	identities, err := ou.identityRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, identity := range identities {
+		if identity.Provider != provider {
+			continue
+		}
+		// users who signed up through a provider have no password to fall back to
+		if user.Password == "" && len(identities) == 1 {
+			return domain.ErrLastSignInMethod
+		}
+		return ou.identityRepository.DeleteByID(ctx, identity.ID)
+	}
+	return domain.ErrIdentityNotFound
+}
+
+func (ou *oauthUseCase) consumeState(c context.Context, provider string, state string) (domain.OAuthState, error) {
+	invalid := domain.ErrInvalidOAuthState
+
+	oauthState, err := ou.oauthStateRepository.GetByID(c, internal.HashSecretToken(state))
+	if err != nil {
+		return domain.OAuthState{}, invalid
+	}
+	if oauthState.Used || oauthState.Provider != provider || oauthState.ExpiresAt <= time.Now().Unix() {
+		return domain.OAuthState{}, invalid
+	}
+
+	filter := bson.D{
+		{Key: "_id", Value: oauthState.ID},
+		{Key: "used", Value: false},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "used", Value: true}}}}
+	res, err := ou.oauthStateRepository.Update(c, filter, update)
+	if err != nil {
+		return domain.OAuthState{}, err
+	}
+	if res.MatchedCount == 0 {
+		return domain.OAuthState{}, invalid
+	}
+	return oauthState, nil
+}
+
+// findUser picks the account a new identity gets linked to: the signed in user,
+// an existing account with the same verified email, or a freshly created one.
+func (ou *oauthUseCase) findUser(c context.Context, linkUserID string, info *oauth.UserInfo) (domain.User, error) {
+	if linkUserID != "" {
+		return ou.userRepository.GetByID(c, linkUserID)
+	}
+
+	if info.Email == "" || !info.EmailVerified {
+		return domain.User{}, domain.ErrProviderEmailNotVerified
+	}
+
+	user, err := ou.userRepository.GetByEmail(c, info.Email)
+	if err == nil {
+		// whoever registered an unconfirmed address may not own it,
+		// so such an account can only be linked from its settings
+		if !user.EmailVerified {
+			return domain.User{}, domain.ErrLinkProvider
+		}
+		return user, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.User{}, err
+	}
+
+	username := info.Name
+	if username == "" {
+		username, _, _ = strings.Cut(info.Email, "@")
+	}
+	user = domain.User{
+		Username:      username,
+		Email:         info.Email,
+		EmailVerified: true,
+		Collections:   make([]string, 0),
+		Favourite:     make([]string, 0),
+	}
+	_, err = ou.userRepository.Create(c, &user)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return user, nil
+}
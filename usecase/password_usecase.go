@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/domain"
+	"main/mail"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type passwordUseCase struct {
+	userRepository      domain.UserRepository
+	userTokenRepository domain.UserTokenRepository
+	sessionRepository   domain.SessionRepository
+	mailSender          mail.Sender
+	contextTimeout      time.Duration
+}
+
+func NewPasswordUseCase(
+	userRepository domain.UserRepository,
+	userTokenRepository domain.UserTokenRepository,
+	sessionRepository domain.SessionRepository,
+	mailSender mail.Sender,
+	timeout time.Duration,
+) domain.PasswordUseCase {
+	return &passwordUseCase{
+		userRepository:      userRepository,
+		userTokenRepository: userTokenRepository,
+		sessionRepository:   sessionRepository,
+		mailSender:          mailSender,
+		contextTimeout:      timeout,
+	}
+}
+
+func (pu *passwordUseCase) GetUserByID(c context.Context, userID string) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+	return pu.userRepository.GetByID(ctx, userID)
+}
+
+func (pu *passwordUseCase) UpdatePassword(c context.Context, userID string, hashedPassword string) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+	return pu.setPassword(ctx, userID, hashedPassword)
+}
+
+func (pu *passwordUseCase) RequestReset(c context.Context, email string, expiry int) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	user, err := pu.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		// nobody should learn from this endpoint which emails are registered
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return err
+	}
+	// the code goes only to an address its owner has confirmed
+	if !user.EmailVerified {
+		return nil
+	}
+
+	ttl := time.Duration(expiry) * time.Minute
+	token, err := issueUserToken(ctx, pu.userTokenRepository, &user, domain.PasswordResetPurpose, ttl)
+	if err != nil {
+		return err
+	}
+
+	return pu.mailSender.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "T-Prep password reset",
+		Body: fmt.Sprintf(
+			"Hello, %s!\n\nUse this code to reset your password: %s\nIt is valid for %d minutes.\n"+
+				"If you did not request a reset, just ignore this message.",
+			user.Username, token, expiry,
+		),
+	})
+}
+
+func (pu *passwordUseCase) Reset(c context.Context, token string, hashedPassword string) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	userToken, err := consumeUserToken(ctx, pu.userTokenRepository, token, domain.PasswordResetPurpose)
+	if err != nil {
+		return err
+	}
+
+	err = pu.setPassword(ctx, userToken.UserID, hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	return pu.sessionRepository.RevokeByUserID(ctx, userToken.UserID, "")
+}
+
+func (pu *passwordUseCase) setPassword(c context.Context, userID string, hashedPassword string) error {
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "password", Value: hashedPassword},
+		}},
+	}
+	res, err := pu.userRepository.UpdateByID(c, userID, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
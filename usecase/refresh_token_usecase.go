@@ -5,17 +5,23 @@ import (
 	"main/domain"
 	"main/internal"
 	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 type refreshTokenUseCase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository    domain.UserRepository
+	sessionRepository domain.SessionRepository
+	contextTimeout    time.Duration
 }
 
-func NewRefreshTokenUseCase(userRepository domain.UserRepository, timeout time.Duration) domain.RefreshTokenUseCase {
+func NewRefreshTokenUseCase(
+	userRepository domain.UserRepository, sessionRepository domain.SessionRepository, timeout time.Duration,
+) domain.RefreshTokenUseCase {
 	return &refreshTokenUseCase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		contextTimeout:    timeout,
 	}
 }
 
@@ -27,22 +33,78 @@ func (rtu *refreshTokenUseCase) GetUserByID(c context.Context, email string) (do
 
 func (rtu *refreshTokenUseCase) CreateAccessToken(
 	user *domain.User,
-	secret string,
+	sessionID string,
+	keySet domain.KeySet,
 	expiry int,
 ) (string, time.Time, error) {
-	accessToken, exp, err := internal.CreateAccessToken(user, secret, expiry)
+	accessToken, exp, err := internal.CreateAccessToken(user, sessionID, keySet, expiry)
 	return accessToken, exp, err
 }
 
-func (rtu *refreshTokenUseCase) CreateRefreshToken(
+func (rtu *refreshTokenUseCase) ParseRefreshToken(
+	requestToken string,
+	secret string,
+) (*domain.JwtCustomRefreshClaims, error) {
+	return internal.ParseRefreshToken(requestToken, secret)
+}
+
+func (rtu *refreshTokenUseCase) RotateRefreshToken(
+	c context.Context,
 	user *domain.User,
+	claims *domain.JwtCustomRefreshClaims,
 	secret string,
 	expiry int,
 ) (string, time.Time, error) {
-	refreshToken, exp, err := internal.CreateRefreshToken(user, secret, expiry)
-	return refreshToken, exp, err
+	ctx, cancel := context.WithTimeout(c, rtu.contextTimeout)
+	defer cancel()
+
+	session, err := rtu.sessionRepository.GetByID(ctx, claims.SessionID)
+	if err != nil || session.UserID != user.ID {
+		return "", time.Time{}, domain.ErrSessionNotFound
+	}
+
+	if session.Revoked {
+		return "", time.Time{}, domain.ErrSessionRevoked
+	}
+
+	// the token was already rotated, so somebody else holds a copy of it
+	if session.TokenID != claims.TokenID {
+		return "", time.Time{}, rtu.revokeReused(ctx, session.ID)
+	}
+
+	now := time.Now()
+	tokenID := internal.GenerateUUID()
+
+	filter := bson.D{
+		{Key: "_id", Value: session.ID},
+		{Key: "token_id", Value: claims.TokenID},
+		{Key: "revoked", Value: false},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "token_id", Value: tokenID},
+			{Key: "last_used_at", Value: now.Unix()},
+			{Key: "expires_at", Value: now.Add(time.Duration(expiry) * time.Hour).Unix()},
+		}},
+	}
+
+	res, err := rtu.sessionRepository.Update(ctx, filter, update)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	// lost the race against a concurrent refresh with the same token
+	if res.MatchedCount == 0 {
+		return "", time.Time{}, rtu.revokeReused(ctx, session.ID)
+	}
+
+	return internal.CreateRefreshToken(user, session.ID, tokenID, secret, expiry)
 }
 
-func (rtu *refreshTokenUseCase) ExtractIDFromToken(requestToken string, secret string) (string, error) {
-	return internal.ExtractIDFromToken(requestToken, secret)
+func (rtu *refreshTokenUseCase) revokeReused(c context.Context, sessionID string) error {
+	err := rtu.sessionRepository.RevokeByID(c, sessionID)
+	if err != nil {
+		return err
+	}
+	return domain.ErrRefreshTokenReused
 }
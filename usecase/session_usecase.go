@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"main/domain"
+	"main/internal"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	sessionCacheTTL  = 30 * time.Second
+	sessionCacheSize = 10000
+)
+
+type sessionCacheEntry struct {
+	userID    string
+	active    bool
+	checkedAt time.Time
+}
+
+// sessionCache remembers recent session lookups so that not every request goes to the database.
+// The sessions revoked through the usecase are forgotten at once, the ones revoked elsewhere
+// (password reset, reused refresh token) are rejected within sessionCacheTTL.
+type sessionCache struct {
+	mu      sync.Mutex
+	entries map[string]sessionCacheEntry
+}
+
+func (sc *sessionCache) get(sessionID string) (bool, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	entry, ok := sc.entries[sessionID]
+	if !ok || time.Since(entry.checkedAt) > sessionCacheTTL {
+		return false, false
+	}
+	return entry.active, true
+}
+
+func (sc *sessionCache) set(sessionID string, userID string, active bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if len(sc.entries) >= sessionCacheSize {
+		for id, entry := range sc.entries {
+			if time.Since(entry.checkedAt) > sessionCacheTTL {
+				delete(sc.entries, id)
+			}
+		}
+	}
+	// all the entries are fresh, any of them makes room
+	for id := range sc.entries {
+		if len(sc.entries) < sessionCacheSize {
+			break
+		}
+		delete(sc.entries, id)
+	}
+	sc.entries[sessionID] = sessionCacheEntry{userID: userID, active: active, checkedAt: time.Now()}
+}
+
+func (sc *sessionCache) forget(sessionID string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	delete(sc.entries, sessionID)
+}
+
+func (sc *sessionCache) forgetUser(userID string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for id, entry := range sc.entries {
+		if entry.userID == userID {
+			delete(sc.entries, id)
+		}
+	}
+}
+
+type sessionUseCase struct {
+	sessionRepository domain.SessionRepository
+	cache             *sessionCache
+	contextTimeout    time.Duration
+}
+
+// NewSessionUseCase returns the usecase with its own cache of the session checks, so one usecase
+// is to be shared by the checks and the revocations.
+func NewSessionUseCase(sessionRepository domain.SessionRepository, timeout time.Duration) domain.SessionUseCase {
+	return &sessionUseCase{
+		sessionRepository: sessionRepository,
+		cache:             &sessionCache{entries: make(map[string]sessionCacheEntry)},
+		contextTimeout:    timeout,
+	}
+}
+
+func (su *sessionUseCase) ParseRefreshToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	return internal.ParseRefreshToken(requestToken, secret)
+}
+
+func (su *sessionUseCase) GetActiveByUserID(c context.Context, userID string) ([]domain.Session, error) {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+	return su.sessionRepository.GetActiveByUserID(ctx, userID, time.Now().Unix())
+}
+
+func (su *sessionUseCase) IsActive(c context.Context, sessionID string) (bool, error) {
+	active, ok := su.cache.get(sessionID)
+	if ok {
+		return active, nil
+	}
+
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	session, err := su.sessionRepository.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	active = !session.Revoked && session.ExpiresAt > time.Now().Unix()
+	su.cache.set(sessionID, session.UserID, active)
+	return active, nil
+}
+
+func (su *sessionUseCase) Revoke(c context.Context, userID string, sessionID string) error {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	session, err := su.sessionRepository.GetByID(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return domain.ErrSessionNotFound
+	}
+
+	err = su.sessionRepository.RevokeByID(ctx, sessionID)
+	su.cache.forget(sessionID)
+	return err
+}
+
+func (su *sessionUseCase) RevokeAll(c context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	err := su.sessionRepository.RevokeByUserID(ctx, userID, "")
+	su.cache.forgetUser(userID)
+	return err
+}
+
+// RevokeOthers revokes the sessions of the user but the one the request is made in,
+// all of them if sessionID is empty, e.g. for a token issued before the sessions.
+func (su *sessionUseCase) RevokeOthers(c context.Context, userID string, sessionID string) error {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	err := su.sessionRepository.RevokeByUserID(ctx, userID, sessionID)
+	su.cache.forgetUser(userID)
+	return err
+}
+
+// createSession stores a new refresh token family for the given device
+// description and returns its first token; session.ID is filled in.
+func createSession(
+	c context.Context,
+	sessionRepository domain.SessionRepository,
+	user *domain.User,
+	session *domain.Session,
+	secret string,
+	expiry int,
+) (string, time.Time, error) {
+	now := time.Now()
+	session.UserID = user.ID
+	session.TokenID = internal.GenerateUUID()
+	session.CreatedAt = now.Unix()
+	session.LastUsedAt = now.Unix()
+	session.ExpiresAt = now.Add(time.Duration(expiry) * time.Hour).Unix()
+
+	_, err := sessionRepository.Create(c, session)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return internal.CreateRefreshToken(user, session.ID, session.TokenID, secret, expiry)
+}
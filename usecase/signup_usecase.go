@@ -8,14 +8,18 @@ import (
 )
 
 type signupUseCase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository    domain.UserRepository
+	sessionRepository domain.SessionRepository
+	contextTimeout    time.Duration
 }
 
-func NewSignupUseCase(userRepository domain.UserRepository, timeout time.Duration) domain.SignupUseCase {
+func NewSignupUseCase(
+	userRepository domain.UserRepository, sessionRepository domain.SessionRepository, timeout time.Duration,
+) domain.SignupUseCase {
 	return &signupUseCase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		contextTimeout:    timeout,
 	}
 }
 
@@ -34,12 +38,24 @@ func (su *signupUseCase) GetUserByEmail(c context.Context, email string) (domain
 	return su.userRepository.GetByEmail(ctx, email)
 }
 
-func (su *signupUseCase) CreateAccessToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
-	accessToken, exp, err := internal.CreateAccessToken(user, secret, expiry)
+func (su *signupUseCase) CreateAccessToken(
+	user *domain.User,
+	sessionID string,
+	keySet domain.KeySet,
+	expiry int,
+) (string, time.Time, error) {
+	accessToken, exp, err := internal.CreateAccessToken(user, sessionID, keySet, expiry)
 	return accessToken, exp, err
 }
 
-func (su *signupUseCase) CreateRefreshToken(user *domain.User, secret string, expiry int) (string, time.Time, error) {
-	refreshToken, exp, err := internal.CreateRefreshToken(user, secret, expiry)
-	return refreshToken, exp, err
+func (su *signupUseCase) CreateRefreshToken(
+	c context.Context,
+	user *domain.User,
+	session *domain.Session,
+	secret string,
+	expiry int,
+) (string, time.Time, error) {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+	return createSession(ctx, su.sessionRepository, user, session, secret, expiry)
 }
@@ -0,0 +1,614 @@
+package tests_test
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+	"main/repository"
+	"main/storage"
+	"main/usecase"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type collectionFixture struct {
+	usecase     domain.CollectionUseCase
+	users       domain.UserRepository
+	collections domain.CollectionRepository
+	media       domain.MediaRepository
+	db          database.Database
+	storage     domain.CollectionStorage
+	userID      string
+}
+
+// newCollectionFixture wires the usecase with the repositories on the in-memory database.
+func newCollectionFixture(t *testing.T) collectionFixture {
+	t.Helper()
+	client := database.NewMemoryClient()
+	repository.SetClient(client)
+	db := client.Database("tprep")
+
+	s := storage.NewMemoryClient()
+	require.NoError(t, s.MakeBucket(context.Background(), domain.CollectionBucket))
+
+	f := collectionFixture{
+		users:       repository.NewUserRepository(db, domain.UserCollection),
+		collections: repository.NewCollectionRepository(db, domain.CollectionCollection),
+		db:          db,
+		storage:     storage.NewCollectionStorage(s, domain.CollectionBucket),
+	}
+	f.media = repository.NewMediaRepository(db, domain.MediaCollection)
+	f.usecase = usecase.NewCollectionUseCase(f.collections, f.storage, f.media, f.users, time.Second)
+
+	userID, err := f.users.Create(context.Background(), &domain.User{
+		Username:    "author",
+		Collections: make([]string, 0),
+		Favourite:   make([]string, 0),
+	})
+	require.NoError(t, err)
+	f.userID = userID
+	return f
+}
+
+func TestCollectionUseCase_Cards(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+
+	collectionID, err := f.usecase.Create(ctx, &domain.Collection{Name: "Capitals", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	user, err := f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{collectionID}, user.Collections)
+
+	for _, question := range []string{"France", "Italy", "Spain"} {
+		_, err = f.usecase.AddCard(ctx, collectionID, &domain.Card{Question: question})
+		require.NoError(t, err)
+	}
+	err = f.usecase.UpdateCard(ctx, collectionID, &domain.Card{LocalID: 1, Question: "Italy", Answer: "Rome"})
+	require.NoError(t, err)
+	require.NoError(t, f.usecase.DeleteCard(ctx, f.userID, collectionID, 0))
+
+	err = f.usecase.DeleteCard(ctx, f.userID, collectionID, 0)
+	assert.ErrorIs(t, err, domain.ErrCardNotFound)
+	err = f.usecase.UpdateCard(ctx, collectionID, &domain.Card{LocalID: 7})
+	assert.ErrorIs(t, err, domain.ErrCardNotFound)
+
+	collection, err := f.usecase.GetByID(ctx, collectionID)
+	require.NoError(t, err)
+	require.Len(t, collection.Cards, 2)
+	assert.Equal(t, "Rome", collection.Cards[0].Answer)
+	assert.Equal(t, 2, collection.Cards[1].LocalID)
+	assert.Equal(t, 3, collection.MaxID)
+}
+
+func TestCollectionUseCase_AddLike(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+
+	collectionID, err := f.usecase.Create(ctx, &domain.Collection{Name: "Capitals", IsPublic: true}, f.userID)
+	require.NoError(t, err)
+
+	liked, err := f.usecase.AddLike(ctx, collectionID, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, liked.Likes)
+
+	// the favourite is rolled back with the transaction when the collection is missing
+	_, err = f.usecase.AddLike(ctx, "missing", f.userID)
+	assert.ErrorIs(t, err, domain.ErrCollectionNotFound)
+	user, err := f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{collectionID}, user.Favourite)
+
+	unliked, err := f.usecase.RemoveLike(ctx, collectionID, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, unliked.Likes)
+
+	require.NoError(t, f.usecase.DeleteByID(ctx, collectionID, f.userID))
+	_, err = f.collections.GetByID(ctx, collectionID)
+	assert.Error(t, err)
+	user, err = f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Empty(t, user.Collections)
+}
+
+func (f collectionFixture) addAttachment(
+	t *testing.T,
+	collectionID string,
+	side domain.CardSide,
+	size int,
+) (domain.CardAttachment, error) {
+	t.Helper()
+	picture := strings.Repeat("p", size)
+	return f.usecase.AddCardAttachment(
+		context.Background(), f.userID, collectionID, 0, side, strings.NewReader(picture), int64(size), "image/png",
+	)
+}
+
+func TestCollectionUseCase_Attachments(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+
+	collectionID, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	_, err = f.usecase.AddCard(ctx, collectionID, &domain.Card{
+		Question:    "Robin",
+		Attachments: []domain.CardAttachment{{ID: "someone-else", Size: 1}},
+	})
+	require.NoError(t, err)
+
+	first, err := f.addAttachment(t, collectionID, domain.CardSideQuestion, 10)
+	require.NoError(t, err)
+	second, err := f.addAttachment(t, collectionID, domain.CardSideAnswer, 20)
+	require.NoError(t, err)
+	third, err := f.addAttachment(t, collectionID, domain.CardSideQuestion, 30)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, []int{first.Order, second.Order, third.Order})
+	assert.Equal(t, "image/png", second.MediaType)
+	assert.Equal(t, int64(20), second.Size)
+	user, err := f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, 60, user.Limits.TotalFileSize)
+
+	collection, err := f.usecase.GetByID(ctx, collectionID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CardAttachment{first, second, third}, collection.Cards[0].Attachments,
+		"the attachments are only added by the upload")
+
+	reordered, err := f.usecase.ReorderCardAttachments(ctx, collectionID, 0, []string{third.ID, first.ID, second.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{third.ID, first.ID, second.ID}, []string{reordered[0].ID, reordered[1].ID, reordered[2].ID})
+	assert.Equal(t, []int{0, 1, 2}, []int{reordered[0].Order, reordered[1].Order, reordered[2].Order})
+	collection, err = f.usecase.GetByID(ctx, collectionID)
+	require.NoError(t, err)
+	assert.Equal(t, reordered, collection.Cards[0].Attachments)
+	for _, ids := range [][]string{
+		{third.ID, first.ID},
+		{third.ID, first.ID, first.ID},
+		{third.ID, first.ID, "someone-else"},
+	} {
+		_, err = f.usecase.ReorderCardAttachments(ctx, collectionID, 0, ids)
+		assert.Equal(t, domain.InvalidFields("ids"), err, ids)
+	}
+
+	require.NoError(t, f.usecase.RemoveCardAttachment(ctx, f.userID, collectionID, 0, first.ID))
+	err = f.usecase.RemoveCardAttachment(ctx, f.userID, collectionID, 0, first.ID)
+	assert.ErrorIs(t, err, domain.ErrCardPictureNotFound)
+	collection, err = f.usecase.GetByID(ctx, collectionID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{third.ID, second.ID},
+		[]string{collection.Cards[0].Attachments[0].ID, collection.Cards[0].Attachments[1].ID})
+	user, err = f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, 50, user.Limits.TotalFileSize)
+	_, err = f.storage.StatObject(ctx, first.Object)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	for range domain.MaxCardAttachments - 2 {
+		_, err = f.addAttachment(t, collectionID, domain.CardSideAnswer, 1)
+		require.NoError(t, err)
+	}
+	_, err = f.addAttachment(t, collectionID, domain.CardSideAnswer, 1)
+	assert.ErrorIs(t, err, domain.ErrTooManyAttachments)
+}
+
+func TestCollectionUseCase_ConcurrentAttachments(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+	collectionID, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	_, err = f.usecase.AddCard(ctx, collectionID, &domain.Card{Question: "Robin"})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2*domain.MaxCardAttachments)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = f.addAttachment(t, collectionID, domain.CardSideQuestion, i+1)
+		}()
+	}
+	wg.Wait()
+
+	added, size := 0, 0
+	for i, err := range errs {
+		if err == nil {
+			added++
+			size += i + 1
+			continue
+		}
+		assert.ErrorIs(t, err, domain.ErrTooManyAttachments)
+	}
+	assert.Equal(t, domain.MaxCardAttachments, added)
+
+	collection, err := f.usecase.GetByID(ctx, collectionID)
+	require.NoError(t, err)
+	orders := make(map[int]bool)
+	for _, attachment := range collection.Cards[0].Attachments {
+		orders[attachment.Order] = true
+	}
+	assert.Len(t, orders, domain.MaxCardAttachments, "the orders are distinct")
+
+	// the rejected pictures are not charged
+	user, err := f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, size, user.Limits.TotalFileSize)
+}
+
+func TestCollectionUseCase_MigrateAttachments(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+
+	picture := "a picture of a bird"
+	require.NoError(t, f.storage.PutObject(ctx, "birds_0_1743853284", strings.NewReader(picture),
+		int64(len(picture)), "image/jpeg"))
+	// the cards as they were stored with the attachment field
+	_, err := f.db.Collection(domain.CollectionCollection).InsertOne(ctx, bson.M{
+		"_id":  "birds",
+		"name": "Birds",
+		"cards": bson.A{
+			bson.M{"local_id": 0, "question": "Robin", "attachment": "birds_0_1743853284"},
+			bson.M{"local_id": 1, "question": "Wren", "attachment": ""},
+			bson.M{"local_id": 2, "question": "Owl", "attachment": "birds_2_1743853290"},
+		},
+		"max_id": 3,
+	})
+	require.NoError(t, err)
+
+	migrated, err := f.usecase.MigrateAttachments(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, migrated, "the missing picture is dropped")
+
+	collection, err := f.usecase.GetByID(ctx, "birds")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CardAttachment{{
+		ID:        "birds_0_1743853284",
+		Side:      domain.CardSideQuestion,
+		MediaType: "image/jpeg",
+		Size:      int64(len(picture)),
+	}}, collection.Cards[0].Attachments)
+	for _, card := range collection.Cards {
+		assert.Empty(t, card.LegacyAttachment)
+	}
+	assert.Equal(t, []domain.CardAttachment{}, collection.Cards[1].Attachments, "the list is never null")
+	assert.Equal(t, []domain.CardAttachment{}, collection.Cards[2].Attachments)
+
+	migrated, err = f.usecase.MigrateAttachments(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, migrated, "the pictures are moved once")
+}
+
+func TestCollectionUseCase_SharedMedia(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+	readerID, err := f.users.Create(ctx, &domain.User{
+		Username:    "reader",
+		Collections: make([]string, 0),
+		Favourite:   make([]string, 0),
+	})
+	require.NoError(t, err)
+
+	birds, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	forked, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: readerID}, readerID)
+	require.NoError(t, err)
+	for _, collectionID := range []string{birds, birds, forked} {
+		_, err = f.usecase.AddCard(ctx, collectionID, &domain.Card{Question: "Robin"})
+		require.NoError(t, err)
+	}
+	picture := "a picture of a robin"
+	add := func(userID, collectionID string, cardID int) domain.CardAttachment {
+		attachment, addErr := f.usecase.AddCardAttachment(ctx, userID, collectionID, cardID, domain.CardSideQuestion,
+			strings.NewReader(picture), int64(len(picture)), "image/png")
+		require.NoError(t, addErr)
+		return attachment
+	}
+	charged := func(userID string) int {
+		user, getErr := f.users.GetByID(ctx, userID)
+		require.NoError(t, getErr)
+		return user.Limits.TotalFileSize
+	}
+
+	first := add(f.userID, birds, 0)
+	second := add(f.userID, birds, 1)
+	twice := add(f.userID, birds, 1)
+	forkedFirst := add(readerID, forked, 0)
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Equal(t, first.Object, second.Object, "the identical pictures share the object")
+	assert.Equal(t, first.Object, forkedFirst.Object)
+	assert.Equal(t, len(picture), charged(f.userID), "the user is charged once for the object")
+	assert.Equal(t, len(picture), charged(readerID))
+	media, err := f.media.GetByID(ctx, first.Object)
+	require.NoError(t, err)
+	assert.Equal(t, 4, media.RefCount)
+
+	require.NoError(t, f.usecase.RemoveCardAttachment(ctx, f.userID, birds, 0, first.ID))
+	require.NoError(t, f.usecase.RemoveCardAttachment(ctx, f.userID, birds, 1, second.ID))
+	assert.Equal(t, len(picture), charged(f.userID), "the user still has a reference")
+	require.NoError(t, f.usecase.RemoveCardAttachment(ctx, f.userID, birds, 1, twice.ID))
+	assert.Equal(t, 0, charged(f.userID))
+	object, err := f.storage.GetObject(ctx, first.Object)
+	require.NoError(t, err, "the object is kept for the other user")
+	object.Close()
+
+	require.NoError(t, f.usecase.RemoveCardAttachment(ctx, readerID, forked, 0, forkedFirst.ID))
+	assert.Equal(t, 0, charged(readerID))
+	_, err = f.storage.StatObject(ctx, first.Object)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound, "the object is removed with the last reference")
+	_, err = f.media.GetByID(ctx, first.Object)
+	assert.Error(t, err)
+
+	// the audio is stored the same way
+	sound := "a song of a robin"
+	var audio []string
+	for _, cardID := range []int{0, 1} {
+		objectName, uploadErr := f.usecase.UploadCardAudio(ctx, f.userID, birds, cardID, domain.CardSideAnswer,
+			strings.NewReader(sound), int64(len(sound)), "audio/mpeg")
+		require.NoError(t, uploadErr)
+		audio = append(audio, objectName)
+	}
+	assert.Equal(t, audio[0], audio[1])
+	assert.Equal(t, len(sound), charged(f.userID))
+	require.NoError(t, f.usecase.RemoveCardAudio(ctx, f.userID, birds, 0, domain.CardSideAnswer, audio[0]))
+	object, err = f.storage.GetAudio(ctx, audio[1])
+	require.NoError(t, err)
+	object.Close()
+	assert.Equal(t, "audio/mpeg", object.ContentType)
+	_, err = f.storage.StatObject(ctx, audio[1])
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound, "the audio is not kept as a picture")
+}
+
+func TestCollectionUseCase_ReplaceCardAudio(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+	birds, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	_, err = f.usecase.AddCard(ctx, birds, &domain.Card{Question: "Robin"})
+	require.NoError(t, err)
+	upload := func(sound string) string {
+		objectName, uploadErr := f.usecase.UploadCardAudio(ctx, f.userID, birds, 0, domain.CardSideAnswer,
+			strings.NewReader(sound), int64(len(sound)), "audio/mpeg")
+		require.NoError(t, uploadErr)
+		return objectName
+	}
+
+	song, call := "a song of a robin", "a call of a robin"
+	first := upload(song)
+	second := upload(call)
+	user, err := f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, len(call), user.Limits.TotalFileSize, "the previous audio is given back with the swap")
+	_, err = f.storage.GetAudio(ctx, first)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = f.media.GetByID(ctx, first)
+	require.Error(t, err)
+
+	// the audio that is not on the card anymore is not removed again
+	err = f.usecase.RemoveCardAudio(ctx, f.userID, birds, 0, domain.CardSideAnswer, first)
+	require.ErrorIs(t, err, domain.ErrCardAudioNotFound)
+	collection, err := f.collections.GetByID(ctx, birds)
+	require.NoError(t, err)
+	assert.Equal(t, second, collection.Cards[0].AnswerAudio)
+}
+
+func TestCollectionUseCase_FileOwners(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+	editorID, err := f.users.Create(ctx, &domain.User{
+		Username:    "editor",
+		Collections: make([]string, 0),
+		Favourite:   make([]string, 0),
+	})
+	require.NoError(t, err)
+	birds, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	require.NoError(t, f.users.AddCollection(ctx, editorID, birds, "collections"))
+	for range 2 {
+		_, err = f.usecase.AddCard(ctx, birds, &domain.Card{Question: "Robin"})
+		require.NoError(t, err)
+	}
+	charged := func(userID string) int {
+		user, getErr := f.users.GetByID(ctx, userID)
+		require.NoError(t, getErr)
+		return user.Limits.TotalFileSize
+	}
+	addFiles := func(cardID int) (domain.CardAttachment, string) {
+		picture, sound := "a picture of a robin", "a song of a robin"
+		attachment, addErr := f.usecase.AddCardAttachment(ctx, f.userID, birds, cardID, domain.CardSideQuestion,
+			strings.NewReader(picture), int64(len(picture)), "image/png")
+		require.NoError(t, addErr)
+		audio, addErr := f.usecase.UploadCardAudio(ctx, f.userID, birds, cardID, domain.CardSideAnswer,
+			strings.NewReader(sound), int64(len(sound)), "audio/mpeg")
+		require.NoError(t, addErr)
+		return attachment, audio
+	}
+
+	// the files are given back to the user who added them, whoever removes them
+	attachment, audio := addFiles(0)
+	assert.Equal(t, f.userID, attachment.Owner)
+	require.NoError(t, f.usecase.RemoveCardAttachment(ctx, editorID, birds, 0, attachment.ID))
+	require.NoError(t, f.usecase.RemoveCardAudio(ctx, editorID, birds, 0, domain.CardSideAnswer, audio))
+	assert.Equal(t, 0, charged(f.userID))
+	assert.Equal(t, 0, charged(editorID))
+	_, err = f.storage.StatObject(ctx, attachment.Object)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = f.storage.GetAudio(ctx, audio)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+
+	// the files of the deleted card are removed with it
+	attachment, audio = addFiles(1)
+	require.NoError(t, f.usecase.DeleteCard(ctx, editorID, birds, 1))
+	assert.Equal(t, 0, charged(f.userID))
+	for _, objectName := range []string{attachment.Object, audio} {
+		_, err = f.media.GetByID(ctx, objectName)
+		require.Error(t, err)
+	}
+	_, err = f.storage.StatObject(ctx, attachment.Object)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = f.storage.GetAudio(ctx, audio)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+// staleCollectionRepository reads the collection as it was, like the requests that read it
+// before the concurrent ones changed it.
+type staleCollectionRepository struct {
+	domain.CollectionRepository
+	stale domain.Collection
+}
+
+func (r staleCollectionRepository) GetByID(context.Context, string) (domain.Collection, error) {
+	return r.stale, nil
+}
+
+func TestCollectionUseCase_RemoveCardAttachmentTwice(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+	birds, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	picture := "a picture of a robin"
+	var attachments []domain.CardAttachment
+	for cardID := range 2 {
+		_, err = f.usecase.AddCard(ctx, birds, &domain.Card{Question: "Robin"})
+		require.NoError(t, err)
+		attachment, addErr := f.usecase.AddCardAttachment(ctx, f.userID, birds, cardID, domain.CardSideQuestion,
+			strings.NewReader(picture), int64(len(picture)), "image/png")
+		require.NoError(t, addErr)
+		attachments = append(attachments, attachment)
+	}
+
+	// both removals read the card with the attachment, it is released once
+	stale, err := f.collections.GetByID(ctx, birds)
+	require.NoError(t, err)
+	uc := usecase.NewCollectionUseCase(
+		staleCollectionRepository{CollectionRepository: f.collections, stale: stale},
+		f.storage, f.media, f.users, time.Second,
+	)
+	require.NoError(t, uc.RemoveCardAttachment(ctx, f.userID, birds, 0, attachments[0].ID))
+	err = uc.RemoveCardAttachment(ctx, f.userID, birds, 0, attachments[0].ID)
+	require.ErrorIs(t, err, domain.ErrCardPictureNotFound)
+
+	media, err := f.media.GetByID(ctx, attachments[1].Object)
+	require.NoError(t, err, "the picture of the other card is kept")
+	assert.Equal(t, 1, media.RefCount)
+	user, err := f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, len(picture), user.Limits.TotalFileSize)
+}
+
+func TestMediaRepository_AcquireWaitsForPurge(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+	media := domain.Media{ID: "robin", Size: 10, ContentType: "image/png"}
+	_, err := f.media.Acquire(ctx, &media, f.userID)
+	require.NoError(t, err)
+	release, err := f.media.Release(ctx, media.ID, f.userID)
+	require.NoError(t, err)
+	require.True(t, release.Unused)
+
+	// the media can't be acquired while its object is being removed, the new object would be removed with it
+	acquired := make(chan error, 1)
+	go func() {
+		again := domain.Media{ID: media.ID, Size: media.Size, ContentType: media.ContentType}
+		_, acquireErr := f.media.Acquire(ctx, &again, "reader")
+		acquired <- acquireErr
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("the media is acquired before it is purged")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, f.media.Purge(ctx, media.ID))
+	select {
+	case err = <-acquired:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the media is not acquired after it is purged")
+	}
+	stored, err := f.media.GetByID(ctx, media.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.RefCount)
+	assert.False(t, stored.Deleting)
+	assert.True(t, stored.Owned("reader"))
+}
+
+func TestCollectionUseCase_DeleteByIDReleasesFiles(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+	birds, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	_, err = f.usecase.AddCard(ctx, birds, &domain.Card{Question: "Robin"})
+	require.NoError(t, err)
+	attachment, err := f.addAttachment(t, birds, domain.CardSideQuestion, 10)
+	require.NoError(t, err)
+	sound := "a song of a robin"
+	audio, err := f.usecase.UploadCardAudio(ctx, f.userID, birds, 0, domain.CardSideAnswer,
+		strings.NewReader(sound), int64(len(sound)), "audio/mpeg")
+	require.NoError(t, err)
+
+	require.NoError(t, f.usecase.DeleteByID(ctx, birds, f.userID))
+	user, err := f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, user.Limits.TotalFileSize, "the files are given back with the collection")
+	for _, objectName := range []string{attachment.Object, audio} {
+		_, err = f.media.GetByID(ctx, objectName)
+		require.Error(t, err)
+	}
+	_, err = f.storage.StatObject(ctx, attachment.Object)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	_, err = f.storage.GetAudio(ctx, audio)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestCollectionUseCase_ConcurrentQuota(t *testing.T) {
+	ctx := context.Background()
+	f := newCollectionFixture(t)
+	quota := domain.MAX_TOTAL_FILE_SIZE
+	domain.MAX_TOTAL_FILE_SIZE = 25
+	t.Cleanup(func() { domain.MAX_TOTAL_FILE_SIZE = quota })
+	collectionID, err := f.usecase.Create(ctx, &domain.Collection{Name: "Birds", Author: f.userID}, f.userID)
+	require.NoError(t, err)
+	_, err = f.usecase.AddCard(ctx, collectionID, &domain.Card{Question: "Robin"})
+	require.NoError(t, err)
+
+	// the pictures are distinct, any two of them fit and three don't
+	var wg sync.WaitGroup
+	errs := make([]error, domain.MaxCardAttachments)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			picture := strings.Repeat(strconv.Itoa(i), 10)
+			_, errs[i] = f.usecase.AddCardAttachment(ctx, f.userID, collectionID, 0, domain.CardSideQuestion,
+				strings.NewReader(picture), int64(len(picture)), "image/png")
+		}()
+	}
+	wg.Wait()
+
+	added := 0
+	for _, err := range errs {
+		if err == nil {
+			added++
+			continue
+		}
+		assert.ErrorIs(t, err, domain.ErrFileSizeLimit)
+	}
+	assert.Equal(t, 2, added)
+	user, err := f.users.GetByID(ctx, f.userID)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, user.Limits.TotalFileSize, domain.MAX_TOTAL_FILE_SIZE)
+	collection, err := f.usecase.GetByID(ctx, collectionID)
+	require.NoError(t, err)
+	size := 0
+	for _, attachment := range collection.Cards[0].Attachments {
+		size += int(attachment.Size)
+	}
+	assert.Equal(t, size, user.Limits.TotalFileSize, "the refused pictures are not charged")
+}
@@ -0,0 +1,47 @@
+package tests_test
+
+import (
+	"context"
+	"main/database"
+	"main/domain"
+	"main/mail"
+	mocks "main/mocks/mail"
+	"main/repository"
+	"main/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordUseCase_RequestReset_OnlyVerifiedEmail(t *testing.T) {
+	client := database.NewMemoryClient()
+	repository.SetClient(client)
+	db := client.Database("tprep")
+	users := repository.NewUserRepository(db, domain.UserCollection)
+
+	sender := new(mocks.Sender)
+	sender.On("Send", mock.Anything, mock.Anything).Return(nil)
+	pu := usecase.NewPasswordUseCase(
+		users,
+		repository.NewUserTokenRepository(db, domain.UserTokenCollection),
+		repository.NewSessionRepository(db, domain.SessionCollection),
+		sender,
+		time.Second,
+	)
+
+	ctx := context.Background()
+	_, err := users.Create(ctx, &domain.User{Username: "squatter", Email: "unverified@email.com"})
+	require.NoError(t, err)
+	_, err = users.Create(ctx, &domain.User{Username: "owner", Email: "verified@email.com", EmailVerified: true})
+	require.NoError(t, err)
+
+	require.NoError(t, pu.RequestReset(ctx, "unverified@email.com", 15))
+	sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+
+	require.NoError(t, pu.RequestReset(ctx, "verified@email.com", 15))
+	sender.AssertCalled(t, "Send", mock.Anything, mock.MatchedBy(func(m mail.Message) bool {
+		return m.To == "verified@email.com"
+	}))
+}
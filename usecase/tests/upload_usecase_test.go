@@ -0,0 +1,128 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"main/database"
+	"main/domain"
+	"main/repository"
+	"main/storage"
+	"main/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type uploadFixture struct {
+	usecase domain.UploadUseCase
+	uploads domain.UploadRepository
+	storage domain.UploadStorage
+}
+
+func newUploadFixture(t *testing.T) uploadFixture {
+	t.Helper()
+	client := database.NewMemoryClient()
+	repository.SetClient(client)
+	db := client.Database("tprep")
+
+	s := storage.NewMemoryClient()
+	require.NoError(t, s.MakeBucket(context.Background(), domain.UploadBucket))
+
+	f := uploadFixture{
+		uploads: repository.NewUploadRepository(db, domain.UploadCollection),
+		storage: storage.NewUploadStorage(s, domain.UploadBucket),
+	}
+	f.usecase = usecase.NewUploadUseCase(f.uploads, f.storage, time.Second)
+	return f
+}
+
+// upload starts the upload of the user and puts the picture to it.
+func (f uploadFixture) upload(t *testing.T, userID string, picture []byte) string {
+	t.Helper()
+	ctx := context.Background()
+	ticket, err := f.usecase.Start(ctx, &domain.Upload{UserID: userID, Target: domain.UploadTargetProfilePicture})
+	require.NoError(t, err)
+	require.True(t, ticket.Proxy, "the in-memory storage can't presign")
+	err = f.usecase.Put(ctx, userID, ticket.ID, domain.UploadTargetProfilePicture,
+		bytes.NewReader(picture), int64(len(picture)))
+	require.NoError(t, err)
+	return ticket.ID
+}
+
+func TestUploadUseCase_Complete(t *testing.T) {
+	ctx := context.Background()
+	f := newUploadFixture(t)
+	uploadID := f.upload(t, "author", []byte("picture"))
+	target := domain.UploadTargetProfilePicture
+
+	// the upload that is not stored is kept
+	errStore := errors.New("can't store")
+	err := f.usecase.Complete(ctx, "author", uploadID, target, func(context.Context, domain.Upload, []byte) error {
+		return errStore
+	})
+	require.ErrorIs(t, err, errStore)
+
+	// the upload being completed is not found by the other completions
+	storeTwice := func(context.Context, domain.Upload, []byte) error {
+		t.Error("the upload is stored twice")
+		return nil
+	}
+	var stored []byte
+	err = f.usecase.Complete(ctx, "author", uploadID, target,
+		func(c context.Context, upload domain.Upload, data []byte) error {
+			again := f.usecase.Complete(c, "author", uploadID, target, storeTwice)
+			assert.ErrorIs(t, again, domain.ErrUploadNotFound)
+			assert.Equal(t, "author", upload.UserID)
+			stored = data
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("picture"), stored)
+
+	err = f.usecase.Complete(ctx, "author", uploadID, target, storeTwice)
+	require.ErrorIs(t, err, domain.ErrUploadNotFound)
+	_, err = f.storage.StatObject(ctx, uploadID)
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound, "the picture of the completed upload is removed")
+}
+
+func TestUploadUseCase_RemoveExpired(t *testing.T) {
+	ctx := context.Background()
+	f := newUploadFixture(t)
+	live := f.upload(t, "author", []byte("live"))
+
+	// expire makes the upload of the user that expired a minute ago
+	expire := func(userID string) string {
+		upload := domain.Upload{
+			UserID:    userID,
+			Target:    domain.UploadTargetProfilePicture,
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		}
+		_, err := f.uploads.Create(ctx, &upload)
+		require.NoError(t, err)
+		require.NoError(t, f.storage.PutObject(ctx, upload.ID, bytes.NewReader([]byte("x")), 1, ""))
+		return upload.ID
+	}
+	expired := []string{expire("author"), expire("reader")}
+	claimed := expire("reader")
+	now := time.Now()
+	ok, err := f.uploads.Claim(ctx, claimed, now.Unix(), now.Add(time.Minute).Unix())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	removed, err := f.usecase.RemoveExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed, "the expired uploads of all the users are removed")
+	for _, uploadID := range expired {
+		_, err = f.uploads.GetByID(ctx, uploadID)
+		require.Error(t, err)
+		_, err = f.storage.StatObject(ctx, uploadID)
+		assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	}
+	for _, uploadID := range []string{live, claimed} {
+		_, err = f.uploads.GetByID(ctx, uploadID)
+		require.NoError(t, err, "the live and the claimed uploads are kept")
+	}
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"main/domain"
+	"main/internal"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const recoveryCodesCount = 10
+
+type twoFactorUseCase struct {
+	userRepository      domain.UserRepository
+	userTokenRepository domain.UserTokenRepository
+	contextTimeout      time.Duration
+}
+
+func NewTwoFactorUseCase(
+	userRepository domain.UserRepository,
+	userTokenRepository domain.UserTokenRepository,
+	timeout time.Duration,
+) domain.TwoFactorUseCase {
+	return &twoFactorUseCase{
+		userRepository:      userRepository,
+		userTokenRepository: userTokenRepository,
+		contextTimeout:      timeout,
+	}
+}
+
+func (tu *twoFactorUseCase) Enroll(c context.Context, userID string, issuer string) (domain.TOTPEnrollResponse, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	user, err := tu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return domain.TOTPEnrollResponse{}, err
+	}
+	if user.TwoFactor.Enabled {
+		return domain.TOTPEnrollResponse{}, domain.ErrTwoFactorEnabled
+	}
+
+	secret, err := internal.GenerateTOTPSecret()
+	if err != nil {
+		return domain.TOTPEnrollResponse{}, err
+	}
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "two_factor", Value: domain.TwoFactor{Secret: secret}},
+		}},
+	}
+	_, err = tu.userRepository.UpdateByID(ctx, userID, update)
+	if err != nil {
+		return domain.TOTPEnrollResponse{}, err
+	}
+
+	return domain.TOTPEnrollResponse{
+		Secret: secret,
+		URI:    internal.TOTPURI(issuer, user.Email, secret),
+	}, nil
+}
+
+func (tu *twoFactorUseCase) Confirm(c context.Context, userID string, code string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	user, err := tu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactor.Enabled {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+	if user.TwoFactor.Secret == "" {
+		return nil, domain.ErrTwoFactorNotEnrolled
+	}
+
+	step, ok := internal.ValidateTOTP(user.TwoFactor.Secret, code, time.Now())
+	if !ok {
+		return nil, domain.ErrInvalidCode
+	}
+
+	codes, err := internal.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, internal.HashRecoveryCode(code))
+	}
+
+	// the secret may have been replaced by a concurrent enrolment
+	filter := bson.D{
+		{Key: "_id", Value: userID},
+		{Key: "two_factor.secret", Value: user.TwoFactor.Secret},
+		{Key: "two_factor.enabled", Value: false},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "two_factor.enabled", Value: true},
+			{Key: "two_factor.last_step", Value: step},
+			{Key: "two_factor.recovery_codes", Value: hashes},
+		}},
+	}
+	res, err := tu.userRepository.Update(ctx, filter, update)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, domain.ErrTwoFactorNotEnrolled
+	}
+	return codes, nil
+}
+
+func (tu *twoFactorUseCase) Disable(c context.Context, userID string, code string) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	user, err := tu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactor.Enabled {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	err = tu.checkCode(ctx, &user, code)
+	if err != nil {
+		return err
+	}
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "two_factor", Value: domain.TwoFactor{}},
+		}},
+	}
+	_, err = tu.userRepository.UpdateByID(ctx, userID, update)
+	return err
+}
+
+func (tu *twoFactorUseCase) CreateChallenge(c context.Context, user *domain.User, expiry int) (string, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ttl := time.Duration(expiry) * time.Minute
+	return issueUserToken(ctx, tu.userTokenRepository, user, domain.MFAChallengePurpose, ttl)
+}
+
+// VerifyChallenge spends the challenge on the first attempt,
+// so a wrong code means logging in with the password again.
+func (tu *twoFactorUseCase) VerifyChallenge(c context.Context, token string, code string) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	userToken, err := consumeUserToken(ctx, tu.userTokenRepository, token, domain.MFAChallengePurpose)
+	if errors.Is(err, domain.ErrInvalidOrExpiredToken) {
+		return domain.User{}, domain.ErrInvalidMFAToken
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user, err := tu.userRepository.GetByID(ctx, userToken.UserID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if !user.TwoFactor.Enabled {
+		return user, nil
+	}
+
+	err = tu.checkCode(ctx, &user, code)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+// checkCode accepts either a TOTP code, which can't be used twice,
+// or one of the recovery codes, which is removed once used.
+func (tu *twoFactorUseCase) checkCode(c context.Context, user *domain.User, code string) error {
+	var filter, update bson.D
+
+	step, ok := internal.ValidateTOTP(user.TwoFactor.Secret, code, time.Now())
+	if ok {
+		filter = bson.D{
+			{Key: "_id", Value: user.ID},
+			{Key: "two_factor.last_step", Value: bson.D{{Key: "$lt", Value: step}}},
+		}
+		update = bson.D{{Key: "$set", Value: bson.D{{Key: "two_factor.last_step", Value: step}}}}
+	} else {
+		hash := internal.HashRecoveryCode(code)
+		filter = bson.D{
+			{Key: "_id", Value: user.ID},
+			{Key: "two_factor.recovery_codes", Value: hash},
+		}
+		update = bson.D{{Key: "$pull", Value: bson.D{{Key: "two_factor.recovery_codes", Value: hash}}}}
+	}
+
+	res, err := tu.userRepository.Update(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrInvalidCode
+	}
+	return nil
+}
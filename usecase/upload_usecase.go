@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"main/domain"
+	"main/storage"
+	"net/http"
+	"time"
+
+	"github.com/gookit/slog"
+)
+
+type uploadUseCase struct {
+	uploadRepository domain.UploadRepository
+	uploadStorage    domain.UploadStorage
+	contextTimeout   time.Duration
+}
+
+func NewUploadUseCase(
+	uploadRepository domain.UploadRepository,
+	uploadStorage domain.UploadStorage,
+	timeout time.Duration,
+) domain.UploadUseCase {
+	return &uploadUseCase{
+		uploadRepository: uploadRepository,
+		uploadStorage:    uploadStorage,
+		contextTimeout:   timeout,
+	}
+}
+
+// Start registers the upload and presigns the form the picture is posted with. If the storage can't presign,
+// the ticket is a proxy one without the URL, the picture is put through Put then.
+// The uploads of the user that were never completed are removed on the way.
+func (uu *uploadUseCase) Start(c context.Context, upload *domain.Upload) (domain.UploadTicket, error) {
+	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	expired, err := uu.uploadRepository.GetExpiredByUserID(ctx, upload.UserID, now.Unix())
+	if err != nil {
+		return domain.UploadTicket{}, err
+	}
+	for _, old := range expired {
+		err = uu.remove(ctx, old.ID)
+		if err != nil {
+			return domain.UploadTicket{}, err
+		}
+	}
+
+	upload.CreatedAt = now.Unix()
+	upload.ExpiresAt = now.Add(domain.UploadExpiry).Unix()
+	_, err = uu.uploadRepository.Create(ctx, upload)
+	if err != nil {
+		return domain.UploadTicket{}, err
+	}
+
+	ticket := domain.UploadTicket{ID: upload.ID}
+	url, fields, err := uu.uploadStorage.PresignPost(ctx, upload.ID, domain.PresignExpiry, domain.MaxPictureSize)
+	switch {
+	case errors.Is(err, storage.ErrPresignNotSupported):
+		ticket.Method = http.MethodPut
+		ticket.Proxy = true
+		ticket.ExpiresAt = upload.ExpiresAt
+	case err != nil:
+		return domain.UploadTicket{}, err
+	default:
+		ticket.URL = url
+		ticket.Method = http.MethodPost
+		ticket.Fields = fields
+		ticket.ExpiresAt = now.Add(domain.PresignExpiry).Unix()
+	}
+	return ticket, nil
+}
+
+// Put stores the picture of the upload that goes through the server, the picture is checked on Complete.
+func (uu *uploadUseCase) Put(
+	c context.Context,
+	userID string,
+	uploadID string,
+	target domain.UploadTarget,
+	file io.Reader,
+	size int64,
+) error {
+	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+	defer cancel()
+
+	_, err := uu.get(ctx, userID, uploadID, target)
+	if err != nil {
+		return err
+	}
+	if size > domain.MaxPictureSize {
+		return domain.ErrImageTooLarge
+	}
+	return uu.uploadStorage.PutObject(ctx, uploadID, file, size, "application/octet-stream")
+}
+
+// Complete passes the uploaded picture to store, which checks it and stores it as the target.
+// The upload is removed once the picture is stored, so that it is stored once. If store fails,
+// the upload is kept and can be completed again until it expires. While the upload is being completed,
+// it is not found by the other completions.
+func (uu *uploadUseCase) Complete(
+	c context.Context,
+	userID string,
+	uploadID string,
+	target domain.UploadTarget,
+	store func(c context.Context, upload domain.Upload, data []byte) error,
+) error {
+	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+	defer cancel()
+
+	upload, err := uu.get(ctx, userID, uploadID, target)
+	if err != nil {
+		return err
+	}
+	// the claim lasts as long as ctx, a completion cut off midway doesn't hold the upload longer
+	now := time.Now()
+	claimed, err := uu.uploadRepository.Claim(ctx, uploadID, now.Unix(), now.Add(uu.contextTimeout).Unix())
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return domain.ErrUploadNotFound
+	}
+
+	data, err := uu.read(ctx, uploadID)
+	if err == nil {
+		err = store(ctx, upload, data)
+	}
+	if err != nil {
+		unclaimErr := uu.uploadRepository.Unclaim(ctx, uploadID)
+		if unclaimErr != nil {
+			slog.Warnf("Can't unclaim the upload %s: %v", uploadID, unclaimErr)
+		}
+		return err
+	}
+
+	// the picture is stored already, the upload left behind is removed once it expires
+	err = uu.remove(ctx, uploadID)
+	if err != nil {
+		slog.Warnf("Can't remove the completed upload %s: %v", uploadID, err)
+	}
+	return nil
+}
+
+// RemoveExpired removes the uploads of all the users that were never completed, it returns how many.
+func (uu *uploadUseCase) RemoveExpired(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
+	defer cancel()
+
+	expired, err := uu.uploadRepository.GetExpired(ctx, time.Now().Unix())
+	if err != nil {
+		return 0, err
+	}
+	for i, upload := range expired {
+		err = uu.remove(ctx, upload.ID)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(expired), nil
+}
+
+// read returns the uploaded picture, the upload of a picture larger than MaxPictureSize is removed.
+func (uu *uploadUseCase) read(c context.Context, uploadID string) ([]byte, error) {
+	info, err := uu.uploadStorage.StatObject(c, uploadID)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, domain.ErrUploadIncomplete
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.Size > domain.MaxPictureSize {
+		return nil, uu.reject(c, uploadID, domain.ErrImageTooLarge)
+	}
+
+	object, err := uu.uploadStorage.GetObject(c, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	// the URL is still valid, the picture could be replaced by a larger one since the stat
+	data, err := io.ReadAll(io.LimitReader(object, domain.MaxPictureSize+1))
+	object.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > domain.MaxPictureSize {
+		return nil, uu.reject(c, uploadID, domain.ErrImageTooLarge)
+	}
+	return data, nil
+}
+
+func (uu *uploadUseCase) get(
+	c context.Context,
+	userID string,
+	uploadID string,
+	target domain.UploadTarget,
+) (domain.Upload, error) {
+	upload, err := uu.uploadRepository.GetByID(c, uploadID)
+	if err != nil || upload.UserID != userID || upload.Target != target || upload.ExpiresAt <= time.Now().Unix() {
+		return domain.Upload{}, domain.ErrUploadNotFound
+	}
+	return upload, nil
+}
+
+func (uu *uploadUseCase) remove(c context.Context, uploadID string) error {
+	_, err := uu.uploadRepository.DeleteByID(c, uploadID)
+	if err != nil {
+		return err
+	}
+	return uu.uploadStorage.RemoveObject(c, uploadID)
+}
+
+// reject removes the upload that can't be completed and returns err.
+func (uu *uploadUseCase) reject(c context.Context, uploadID string, err error) error {
+	removeErr := uu.remove(c, uploadID)
+	if removeErr != nil {
+		return removeErr
+	}
+	return err
+}
+
+// presignedURL is the result of presigning a GET of an object, the proxy one if the storage can't presign.
+func presignedURL(url string, err error) (domain.PresignedURL, error) {
+	presigned := domain.PresignedURL{URL: url, Method: http.MethodGet}
+	switch {
+	case errors.Is(err, storage.ErrPresignNotSupported):
+		presigned.Proxy = true
+	case err != nil:
+		return domain.PresignedURL{}, err
+	default:
+		presigned.ExpiresAt = time.Now().Add(domain.PresignExpiry).Unix()
+	}
+	return presigned, nil
+}
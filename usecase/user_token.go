@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"main/domain"
+	"main/internal"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// issueUserToken stores a new single-use token for the user and returns
+// the secret that has to be delivered to them.
+func issueUserToken(
+	c context.Context,
+	userTokenRepository domain.UserTokenRepository,
+	user *domain.User,
+	purpose string,
+	ttl time.Duration,
+) (string, error) {
+	secret, err := internal.GenerateSecretToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	token := &domain.UserToken{
+		ID:        internal.HashSecretToken(secret),
+		UserID:    user.ID,
+		Purpose:   purpose,
+		Email:     user.Email,
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+
+	_, err = userTokenRepository.Create(c, token)
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// consumeUserToken marks the token as used, so that it can't be applied twice.
+func consumeUserToken(
+	c context.Context,
+	userTokenRepository domain.UserTokenRepository,
+	secret string,
+	purpose string,
+) (domain.UserToken, error) {
+	invalid := domain.ErrInvalidOrExpiredToken
+
+	token, err := userTokenRepository.GetByID(c, internal.HashSecretToken(secret))
+	if err != nil {
+		return domain.UserToken{}, invalid
+	}
+
+	if token.Used || token.Purpose != purpose || token.ExpiresAt <= time.Now().Unix() {
+		return domain.UserToken{}, invalid
+	}
+
+	filter := bson.D{
+		{Key: "_id", Value: token.ID},
+		{Key: "used", Value: false},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "used", Value: true}}}}
+
+	res, err := userTokenRepository.Update(c, filter, update)
+	if err != nil {
+		return domain.UserToken{}, err
+	}
+	if res.MatchedCount == 0 {
+		return domain.UserToken{}, invalid
+	}
+
+	return token, nil
+}
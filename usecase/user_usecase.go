@@ -2,11 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"io"
 	"main/domain"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type userUseCase struct {
@@ -25,18 +27,38 @@ func NewUserUseCase(
 	}
 }
 
-func (uu *userUseCase) PutByID(c context.Context, userID string, user *domain.User) error {
+func (uu *userUseCase) PutByID(c context.Context, userID string, user *domain.User) (bool, error) {
 	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
 	defer cancel()
 
-	update := bson.D{
-		{Key: "$set", Value: bson.D{
-			{Key: "username", Value: user.Username},
-			{Key: "email", Value: user.Email},
-		}},
+	current, err := uu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
 	}
-	_, err := uu.userRepository.UpdateByID(ctx, userID, update)
-	return err
+
+	set := bson.D{
+		{Key: "username", Value: user.Username},
+		{Key: "email", Value: user.Email},
+	}
+
+	emailChanged := current.Email != user.Email
+	if emailChanged {
+		other, err := uu.userRepository.GetByEmail(ctx, user.Email)
+		switch {
+		case err == nil && other.ID != userID:
+			return false, domain.ErrEmailInUse
+		case err != nil && !errors.Is(err, mongo.ErrNoDocuments):
+			return false, err
+		}
+		// the new address has to be confirmed again
+		set = append(set, bson.E{Key: "email_verified", Value: false})
+	}
+
+	_, err = uu.userRepository.UpdateByID(ctx, userID, bson.D{{Key: "$set", Value: set}})
+	if err != nil {
+		return false, err
+	}
+	return emailChanged, nil
 }
 
 func (uu *userUseCase) DeleteByID(c context.Context, userID string) error {
@@ -51,14 +73,34 @@ func (uu *userUseCase) GetByID(c context.Context, userID string) (domain.User, e
 	return uu.userRepository.GetByID(ctx, userID)
 }
 
-func (uu *userUseCase) GetProfilePicture(c context.Context, userID string) ([]byte, error) {
+// GetProfilePicture opens the variant of the picture, see domain.Object.
+func (uu *userUseCase) GetProfilePicture(
+	c context.Context,
+	userID string,
+	size domain.PictureSize,
+) (domain.Object, error) {
+	return uu.userStorage.GetPicture(c, userID, size)
+}
+
+// GetProfilePictureURL presigns the URL of the variant of the picture, the URL is empty and Proxy is set
+// if the storage can't presign.
+func (uu *userUseCase) GetProfilePictureURL(
+	c context.Context,
+	userID string,
+	size domain.PictureSize,
+) (domain.PresignedURL, error) {
 	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
 	defer cancel()
-
-	return uu.userStorage.GetObject(ctx, userID)
+	return presignedURL(uu.userStorage.PresignGet(ctx, userID, size, domain.PresignExpiry))
 }
 
-func (uu *userUseCase) UploadProfilePicture(c context.Context, userID string, picture io.Reader, size int64) error {
+func (uu *userUseCase) UploadProfilePicture(
+	c context.Context,
+	userID string,
+	picture io.Reader,
+	size int64,
+	contentType string,
+) error {
 	ctx, cancel := context.WithTimeout(c, uu.contextTimeout)
 	defer cancel()
 
@@ -73,7 +115,7 @@ func (uu *userUseCase) UploadProfilePicture(c context.Context, userID string, pi
 		return err
 	}
 
-	return uu.userStorage.PutObject(ctx, userID, picture, size)
+	return uu.userStorage.PutObject(ctx, userID, picture, size, contentType)
 }
 
 func (uu *userUseCase) RemoveProfilePicture(c context.Context, userID string) error {
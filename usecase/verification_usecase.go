@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/domain"
+	"main/mail"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type verificationUseCase struct {
+	userRepository      domain.UserRepository
+	userTokenRepository domain.UserTokenRepository
+	mailSender          mail.Sender
+	contextTimeout      time.Duration
+}
+
+func NewVerificationUseCase(
+	userRepository domain.UserRepository,
+	userTokenRepository domain.UserTokenRepository,
+	mailSender mail.Sender,
+	timeout time.Duration,
+) domain.VerificationUseCase {
+	return &verificationUseCase{
+		userRepository:      userRepository,
+		userTokenRepository: userTokenRepository,
+		mailSender:          mailSender,
+		contextTimeout:      timeout,
+	}
+}
+
+func (vu *verificationUseCase) SendVerification(c context.Context, userID string, expiry int) error {
+	ctx, cancel := context.WithTimeout(c, vu.contextTimeout)
+	defer cancel()
+
+	user, err := vu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return vu.send(ctx, &user, expiry)
+}
+
+func (vu *verificationUseCase) ResendVerification(c context.Context, userID string, expiry int, cooldown int) error {
+	ctx, cancel := context.WithTimeout(c, vu.contextTimeout)
+	defer cancel()
+
+	user, err := vu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	last, err := vu.userTokenRepository.GetLatestByUserID(ctx, userID, domain.EmailVerificationPurpose)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	if err == nil && last.Email == user.Email &&
+		time.Since(time.Unix(last.CreatedAt, 0)) < time.Duration(cooldown)*time.Second {
+		return domain.ErrVerificationSent.WithRetryAfter(time.Duration(cooldown) * time.Second)
+	}
+
+	return vu.send(ctx, &user, expiry)
+}
+
+func (vu *verificationUseCase) Verify(c context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(c, vu.contextTimeout)
+	defer cancel()
+
+	userToken, err := consumeUserToken(ctx, vu.userTokenRepository, token, domain.EmailVerificationPurpose)
+	if err != nil {
+		return err
+	}
+
+	// the email may have been changed after the token was sent
+	filter := bson.D{
+		{Key: "_id", Value: userToken.UserID},
+		{Key: "email", Value: userToken.Email},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "email_verified", Value: true}}}}
+	res, err := vu.userRepository.Update(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrInvalidOrExpiredToken
+	}
+	return nil
+}
+
+func (vu *verificationUseCase) send(c context.Context, user *domain.User, expiry int) error {
+	ttl := time.Duration(expiry) * time.Hour
+	token, err := issueUserToken(c, vu.userTokenRepository, user, domain.EmailVerificationPurpose, ttl)
+	if err != nil {
+		return err
+	}
+
+	return vu.mailSender.Send(c, mail.Message{
+		To:      user.Email,
+		Subject: "T-Prep email verification",
+		Body: fmt.Sprintf(
+			"Hello, %s!\n\nUse this code to confirm your email: %s\nIt is valid for %d hours.",
+			user.Username, token, expiry,
+		),
+	})
+}